@@ -11,12 +11,17 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/start-codex/tookly/internal/auth"
 	"github.com/start-codex/tookly/internal/boards"
+	"github.com/start-codex/tookly/internal/comments"
+	"github.com/start-codex/tookly/internal/customfields"
 	"github.com/start-codex/tookly/internal/instance"
 	"github.com/start-codex/tookly/internal/invitations"
 	"github.com/start-codex/tookly/internal/issues"
 	"github.com/start-codex/tookly/internal/issuetypes"
+	"github.com/start-codex/tookly/internal/labels"
 	"github.com/start-codex/tookly/internal/oidc"
 	"github.com/start-codex/tookly/internal/projects"
+	"github.com/start-codex/tookly/internal/search"
+	"github.com/start-codex/tookly/internal/sprints"
 	"github.com/start-codex/tookly/internal/statuses"
 	"github.com/start-codex/tookly/internal/workspaces"
 )
@@ -34,5 +39,10 @@ func newAPIHandler(db *sqlx.DB) http.Handler {
 	issuetypes.RegisterRoutes(api, db)
 	boards.RegisterRoutes(api, db)
 	issues.RegisterRoutes(api, db)
+	comments.RegisterRoutes(api, db)
+	sprints.RegisterRoutes(api, db)
+	labels.RegisterRoutes(api, db)
+	customfields.RegisterRoutes(api, db)
+	search.RegisterRoutes(api, db)
 	return withAuth(api, db)
 }
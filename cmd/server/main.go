@@ -29,6 +29,11 @@ func main() {
 	if port == "" {
 		port = "8080"
 	}
+	retention, err := parseRetention(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
 
 	db, err := sqlx.Open("postgres", dsn)
 	if err != nil {
@@ -50,6 +55,12 @@ func main() {
 	}
 	slog.Info("migrations applied")
 
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if retention > 0 {
+		go runRetention(jobCtx, db, retention)
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/api/", http.StripPrefix("/api", newAPIHandler(db)))
 	registerUI(mux)
@@ -75,6 +86,7 @@ func main() {
 
 	<-stop
 	slog.Info("shutting down gracefully")
+	stopJobs()
 
 	shutCtx, shutCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutCancel()
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/boards"
+	"github.com/start-codex/tookly/internal/issues"
+	"github.com/start-codex/tookly/internal/projects"
+	"github.com/start-codex/tookly/internal/statuses"
+)
+
+const retentionInterval = time.Hour
+
+// parseRetention reads TRASH_RETENTION_DAYS. Zero or empty disables purging.
+func parseRetention(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("TRASH_RETENTION_DAYS must be a non-negative number of days, got %q", raw)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// runRetention purges items archived longer than retention, once at startup
+// and then every retentionInterval until ctx is done.
+func runRetention(ctx context.Context, db *sqlx.DB, retention time.Duration) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+	for {
+		purgeTrash(ctx, db, time.Now().Add(-retention))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash deletes projects first so their contents go with them, and
+// statuses last so issues purged in the same run no longer hold them.
+func purgeTrash(ctx context.Context, db *sqlx.DB, before time.Time) {
+	steps := []struct {
+		name  string
+		purge func(context.Context, *sqlx.DB, time.Time) (int64, error)
+	}{
+		{"projects", projects.PurgeArchived},
+		{"boards", boards.PurgeArchived},
+		{"issues", issues.PurgeArchived},
+		{"statuses", statuses.PurgeArchived},
+	}
+	for _, step := range steps {
+		n, err := step.purge(ctx, db, before)
+		if err != nil {
+			slog.Error("failed to purge archived items", "kind", step.name, "error", err)
+			continue
+		}
+		if n > 0 {
+			slog.Info("purged archived items", "kind", step.name, "count", n)
+		}
+	}
+}
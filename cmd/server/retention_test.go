@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "unset disables purging", raw: "", want: 0},
+		{name: "zero disables purging", raw: "0", want: 0},
+		{name: "days", raw: "30", want: 30 * 24 * time.Hour},
+		{name: "negative", raw: "-1", wantErr: true},
+		{name: "not a number", raw: "30d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRetention(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRetention(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("parseRetention(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
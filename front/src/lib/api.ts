@@ -166,14 +166,29 @@ export const boards = {
 };
 
 // --- Issues ---
+function listIssuePage(projectID: string, params?: IssueListParams) {
+	const qs = new URLSearchParams(
+		Object.entries(params ?? {})
+			.filter(([, v]) => v !== undefined && v !== '')
+			.map(([k, v]) => [k, String(v)])
+	).toString();
+	return get<IssuePage>(`/projects/${projectID}/issues${qs ? `?${qs}` : ''}`);
+}
+
 export const issues = {
 	create: (projectID: string, body: CreateIssueBody) =>
 		post<Issue>(`/projects/${projectID}/issues`, body),
-	list: (projectID: string, params?: { status_id?: string; assignee_id?: string }) => {
-		const qs = new URLSearchParams(
-			Object.entries(params ?? {}).filter(([, v]) => v) as [string, string][]
-		).toString();
-		return get<Issue[]>(`/projects/${projectID}/issues${qs ? `?${qs}` : ''}`);
+	listPage: listIssuePage,
+	// list follows next_cursor until every matching issue is loaded.
+	list: async (projectID: string, params?: Omit<IssueListParams, 'limit' | 'cursor'>): Promise<Issue[]> => {
+		const all: Issue[] = [];
+		let cursor: string | undefined;
+		do {
+			const page = await listIssuePage(projectID, { ...params, limit: 200, cursor });
+			all.push(...(page?.issues ?? []));
+			cursor = page?.next_cursor;
+		} while (cursor);
+		return all;
 	},
 	get: (projectID: string, issueID: string) =>
 		get<Issue>(`/projects/${projectID}/issues/${issueID}`),
@@ -253,6 +268,13 @@ export interface Issue {
 	assignee_id?: string; reporter_id: string; due_date?: string;
 	status_position: number; created_at: string; updated_at: string; archived_at?: string;
 }
+export interface IssueListParams {
+	status_id?: string; assignee_id?: string; sort?: string; order?: 'asc' | 'desc';
+	limit?: number; cursor?: string;
+}
+export interface IssuePage {
+	issues: Issue[]; total: number; next_cursor?: string;
+}
 export interface CreateIssueBody {
 	issue_type_id: string; status_id: string; title: string;
 	description?: string; priority?: string;
@@ -10,7 +10,7 @@ export const load: PageLoad = async ({ params }) => {
 
 	const [statusList, issueList, typeList, memberList] = await Promise.all([
 		statuses.list(board.project_id).then((r) => r ?? []),
-		issues.list(board.project_id).then((r) => r ?? []),
+		issues.list(board.project_id),
 		issueTypes.list(board.project_id).then((r) => r ?? []),
 		workspaces.members.list(project.workspace_id).then((r) => r ?? [])
 	]);
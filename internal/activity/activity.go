@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/authz"
+)
+
+// Event types of the issue activity log.
+const (
+	Created     = "created"
+	Updated     = "updated"
+	Moved       = "moved"
+	Archived    = "archived"
+	Restored    = "restored"
+	Transferred = "transferred"
+	Commented   = "commented"
+)
+
+// Actor resolves the user recorded on an issue event. HTTP requests always
+// carry the authenticated user; callers without one (tests, background jobs)
+// fall back to the given ID. An empty result records a system event.
+func Actor(ctx context.Context, fallback string) string {
+	if userID, err := authz.UserIDFromContext(ctx); err == nil {
+		return userID
+	}
+	return fallback
+}
+
+// Record appends an event to an issue's activity log inside tx, so that it
+// commits or rolls back with the change it describes. An empty actorID
+// records the event as made by the system.
+func Record(ctx context.Context, tx *sqlx.Tx, issueID, actorID, eventType string, payload any) error {
+	var actor *string
+	if actorID != "" {
+		actor = &actorID
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s event payload: %w", eventType, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO issue_events (issue_id, actor_id, event_type, payload_json)
+		 VALUES ($1, $2, $3, $4)`,
+		issueID, actor, eventType, payloadJSON,
+	); err != nil {
+		return fmt.Errorf("insert %s event: %w", eventType, err)
+	}
+	return nil
+}
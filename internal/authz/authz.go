@@ -20,6 +20,7 @@ var (
 	ErrProjectNotFound   = errors.New("project not found")
 	ErrBoardNotFound     = errors.New("board not found")
 	ErrColumnNotFound    = errors.New("column not found")
+	ErrIssueNotFound     = errors.New("issue not found")
 )
 
 type ctxKey struct{}
@@ -161,6 +162,27 @@ func RequireBoardAccess(ctx context.Context, db *sqlx.DB, boardID string) (strin
 	return wsID, projID, nil
 }
 
+// RequireIssueAccess verifies that the authenticated user is a member of the
+// workspace that owns the issue's project. Returns workspaceID and projectID.
+// It is meant for issues referenced outside the request path, such as link targets.
+func RequireIssueAccess(ctx context.Context, db *sqlx.DB, issueID string) (string, string, error) {
+	if db == nil {
+		return "", "", errors.New("db is required")
+	}
+	if issueID == "" {
+		return "", "", errors.New("issueID is required")
+	}
+	projID, err := issueProjectID(ctx, db, issueID)
+	if err != nil {
+		return "", "", err
+	}
+	wsID, err := RequireProjectMembership(ctx, db, projID)
+	if err != nil {
+		return "", "", err
+	}
+	return wsID, projID, nil
+}
+
 // RequireColumnAccess verifies that the authenticated user is a member of the
 // workspace that owns the column's board's project. Returns workspaceID,
 // projectID, and boardID.
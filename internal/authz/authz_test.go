@@ -57,6 +57,7 @@ func TestSentinelsAreDistinct(t *testing.T) {
 		ErrProjectNotFound,
 		ErrBoardNotFound,
 		ErrColumnNotFound,
+		ErrIssueNotFound,
 	}
 	for i, a := range sentinels {
 		for j, b := range sentinels {
@@ -165,6 +166,27 @@ func TestRequireBoardAccess_Guards(t *testing.T) {
 	}
 }
 
+func TestRequireIssueAccess_Guards(t *testing.T) {
+	ctx := WithUserID(context.Background(), "user-1")
+	tests := []struct {
+		name    string
+		db      *sqlx.DB
+		issueID string
+		wantErr string
+	}{
+		{name: "nil db", db: nil, issueID: "i-1", wantErr: "db is required"},
+		{name: "empty issueID", db: fakeDB(t), issueID: "", wantErr: "issueID is required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := RequireIssueAccess(ctx, tc.db, tc.issueID)
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestRequireColumnAccess_Guards(t *testing.T) {
 	ctx := WithUserID(context.Background(), "user-1")
 	tests := []struct {
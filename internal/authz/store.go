@@ -97,6 +97,21 @@ func boardProjectID(ctx context.Context, db *sqlx.DB, boardID string) (string, e
 	return projID, nil
 }
 
+func issueProjectID(ctx context.Context, db *sqlx.DB, issueID string) (string, error) {
+	var projID string
+	err := db.GetContext(ctx, &projID,
+		`SELECT project_id FROM issues WHERE id = $1 AND archived_at IS NULL`,
+		issueID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrIssueNotFound
+		}
+		return "", fmt.Errorf("resolve issue project: %w", err)
+	}
+	return projID, nil
+}
+
 func columnBoardID(ctx context.Context, db *sqlx.DB, columnID string) (string, error) {
 	var boardID string
 	err := db.GetContext(ctx, &boardID,
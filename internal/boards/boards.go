@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/issues"
 )
 
 var (
@@ -23,26 +24,65 @@ var (
 var validBoardTypes = map[string]bool{"kanban": true, "scrum": true}
 
 type Board struct {
-	ID          string     `db:"id"           json:"id"`
-	ProjectID   string     `db:"project_id"   json:"project_id"`
-	Name        string     `db:"name"         json:"name"`
-	Type        string     `db:"type"         json:"type"`
-	FilterQuery string     `db:"filter_query" json:"filter_query"`
-	CreatedAt   time.Time  `db:"created_at"   json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"   json:"updated_at"`
-	ArchivedAt  *time.Time `db:"archived_at"  json:"archived_at,omitempty"`
+	ID              string     `db:"id"                json:"id"`
+	ProjectID       string     `db:"project_id"        json:"project_id"`
+	Name            string     `db:"name"              json:"name"`
+	Type            string     `db:"type"              json:"type"`
+	FilterQuery     string     `db:"filter_query"      json:"filter_query"`
+	SwimlaneBy      string     `db:"swimlane_by"       json:"swimlane_by"`
+	SwimlaneFieldID *string    `db:"swimlane_field_id" json:"swimlane_field_id,omitempty"`
+	CreatedAt       time.Time  `db:"created_at"        json:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"        json:"updated_at"`
+	ArchivedAt      *time.Time `db:"archived_at"       json:"archived_at,omitempty"`
 }
 
+// Column is a board column. WIPMin and WIPMax are optional limits on the
+// number of active issues in its statuses; WIPMode says whether breaking
+// them only warns or blocks moves.
 type Column struct {
 	ID         string     `db:"id"          json:"id"`
 	BoardID    string     `db:"board_id"    json:"board_id"`
 	Name       string     `db:"name"        json:"name"`
 	Position   int        `db:"position"    json:"position"`
+	WIPMin     *int       `db:"wip_min"     json:"wip_min"`
+	WIPMax     *int       `db:"wip_max"     json:"wip_max"`
+	WIPMode    string     `db:"wip_mode"    json:"wip_mode"`
 	CreatedAt  time.Time  `db:"created_at"  json:"created_at"`
 	UpdatedAt  time.Time  `db:"updated_at"  json:"updated_at"`
 	ArchivedAt *time.Time `db:"archived_at" json:"archived_at,omitempty"`
 }
 
+// ColumnIssues is a board column with the issues of its statuses that match
+// the board's filter query.
+type ColumnIssues struct {
+	Column Column         `json:"column"`
+	Issues []issues.Issue `json:"issues"`
+}
+
+// View is everything needed to render a board: its columns with their mapped
+// statuses and filtered issues, plus the project statuses no column shows.
+// Lanes splits the same issues by the board's swimlane grouping and is empty
+// when the board has none.
+type View struct {
+	Board    Board        `json:"board"`
+	Columns  []ViewColumn `json:"columns"`
+	Unmapped ViewStatuses `json:"unmapped"`
+	Lanes    []Lane       `json:"lanes,omitempty"`
+}
+
+type ViewColumn struct {
+	Column Column    `json:"column"`
+	WIP    WIPStatus `json:"wip"`
+	ViewStatuses
+}
+
+// ViewStatuses holds a set of statuses and their issues, ordered by
+// status_position across all of them.
+type ViewStatuses struct {
+	StatusIDs []string       `json:"status_ids"`
+	Issues    []issues.Issue `json:"issues"`
+}
+
 type CreateParams struct {
 	ProjectID   string
 	Name        string
@@ -60,6 +100,9 @@ func (params CreateParams) Validate() error {
 	if !validBoardTypes[params.Type] {
 		return errors.New("type must be 'kanban' or 'scrum'")
 	}
+	if _, err := parseFilter(params.FilterQuery); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -108,6 +151,30 @@ func List(ctx context.Context, db *sqlx.DB, projectID string) ([]Board, error) {
 	return listBoards(ctx, db, projectID)
 }
 
+// ListIssues returns the board's columns, each with the issues that match the
+// board filter query. viewerID resolves "me" in the query.
+func ListIssues(ctx context.Context, db *sqlx.DB, boardID, viewerID string) ([]ColumnIssues, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if boardID == "" {
+		return nil, errors.New("board_id is required")
+	}
+	return listBoardIssues(ctx, db, boardID, viewerID)
+}
+
+// GetView loads a board with its columns, status mappings and the issues that
+// match its filter query. viewerID resolves "me" in the query.
+func GetView(ctx context.Context, db *sqlx.DB, boardID, viewerID string) (View, error) {
+	if db == nil {
+		return View{}, errors.New("db is required")
+	}
+	if boardID == "" {
+		return View{}, errors.New("board_id is required")
+	}
+	return getView(ctx, db, boardID, viewerID)
+}
+
 func Archive(ctx context.Context, db *sqlx.DB, id string) error {
 	if db == nil {
 		return errors.New("db is required")
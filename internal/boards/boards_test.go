@@ -7,7 +7,11 @@ package boards
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/start-codex/tookly/internal/issues"
 )
 
 func TestCreateBoardParams_Validate(t *testing.T) {
@@ -46,6 +50,16 @@ func TestCreateBoardParams_Validate(t *testing.T) {
 			params:  CreateParams{ProjectID: "proj-1", Name: "Main Board", Type: ""},
 			wantErr: true,
 		},
+		{
+			name:    "valid filter query",
+			params:  CreateParams{ProjectID: "proj-1", Name: "Main Board", Type: "kanban", FilterQuery: "type=story priority!=low"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid filter query",
+			params:  CreateParams{ProjectID: "proj-1", Name: "Main Board", Type: "kanban", FilterQuery: "color=red"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -91,6 +105,205 @@ func TestAddColumnParams_Validate(t *testing.T) {
 	}
 }
 
+func TestSetLimitsParams_Validate(t *testing.T) {
+	one, three, zero := 1, 3, 0
+	tests := []struct {
+		name    string
+		params  SetLimitsParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  SetLimitsParams{ColumnID: "col-1", Min: &one, Max: &three, Mode: WIPBlock},
+			wantErr: false,
+		},
+		{
+			name:    "no limits",
+			params:  SetLimitsParams{ColumnID: "col-1"},
+			wantErr: false,
+		},
+		{
+			name:    "missing column_id",
+			params:  SetLimitsParams{Max: &three},
+			wantErr: true,
+		},
+		{
+			name:    "zero max",
+			params:  SetLimitsParams{ColumnID: "col-1", Max: &zero},
+			wantErr: true,
+		},
+		{
+			name:    "min above max",
+			params:  SetLimitsParams{ColumnID: "col-1", Min: &three, Max: &one},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			params:  SetLimitsParams{ColumnID: "col-1", Max: &three, Mode: "strict"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWIPStatus(t *testing.T) {
+	one, three := 1, 3
+	column := Column{WIPMin: &one, WIPMax: &three}
+	tests := []struct {
+		count int
+		want  WIPStatus
+	}{
+		{count: 0, want: WIPStatus{Count: 0, Under: true}},
+		{count: 3, want: WIPStatus{Count: 3}},
+		{count: 4, want: WIPStatus{Count: 4, Over: true}},
+	}
+	for _, tt := range tests {
+		if got := wipStatus(column, tt.count); got != tt.want {
+			t.Fatalf("wipStatus(%d) = %+v, want %+v", tt.count, got, tt.want)
+		}
+	}
+	if got := wipStatus(Column{}, 10); got != (WIPStatus{Count: 10}) {
+		t.Fatalf("wipStatus without limits = %+v", got)
+	}
+}
+
+func TestSetSwimlanesParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  SetSwimlanesParams
+		wantErr bool
+	}{
+		{name: "assignee", params: SetSwimlanesParams{BoardID: "b", By: SwimlaneAssignee}},
+		{name: "none", params: SetSwimlanesParams{BoardID: "b", By: SwimlaneNone}},
+		{name: "custom field", params: SetSwimlanesParams{BoardID: "b", By: SwimlaneCustomField, FieldID: "f"}},
+		{name: "missing board_id", params: SetSwimlanesParams{By: SwimlanePriority}, wantErr: true},
+		{name: "invalid grouping", params: SetSwimlanesParams{BoardID: "b", By: "reporter"}, wantErr: true},
+		{name: "custom field without field", params: SetSwimlanesParams{BoardID: "b", By: SwimlaneCustomField}, wantErr: true},
+		{name: "field without custom field", params: SetSwimlanesParams{BoardID: "b", By: SwimlanePriority, FieldID: "f"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaveLanesParams_Validate(t *testing.T) {
+	if err := (SaveLanesParams{BoardID: "b", Lanes: []LaneState{{Key: "high"}, {Key: ""}}}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := (SaveLanesParams{Lanes: []LaneState{{Key: "high"}}}).Validate(); err == nil {
+		t.Fatal("Validate() without board_id: want error")
+	}
+	if err := (SaveLanesParams{BoardID: "b", Lanes: []LaneState{{Key: "high"}, {Key: "high", Collapsed: true}}}).Validate(); err == nil {
+		t.Fatal("Validate() with duplicate keys: want error")
+	}
+}
+
+func TestReorderColumnsParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ReorderColumnsParams
+		wantErr bool
+	}{
+		{name: "valid", params: ReorderColumnsParams{BoardID: "b", ColumnIDs: []string{"c2", "c1"}}},
+		{name: "missing board_id", params: ReorderColumnsParams{ColumnIDs: []string{"c1"}}, wantErr: true},
+		{name: "missing column_ids", params: ReorderColumnsParams{BoardID: "b"}, wantErr: true},
+		{name: "empty id", params: ReorderColumnsParams{BoardID: "b", ColumnIDs: []string{"c1", ""}}, wantErr: true},
+		{name: "duplicate id", params: ReorderColumnsParams{BoardID: "b", ColumnIDs: []string{"c1", "c1"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateColumnParams_Validate(t *testing.T) {
+	name, empty := "Review", ""
+	pos, negative := 2, -1
+	tests := []struct {
+		name    string
+		params  UpdateColumnParams
+		wantErr bool
+	}{
+		{name: "nothing to change", params: UpdateColumnParams{ColumnID: "c"}},
+		{name: "all fields", params: UpdateColumnParams{ColumnID: "c", Name: &name, Position: &pos, StatusIDs: []string{"s1", "s2"}}},
+		{name: "clear statuses", params: UpdateColumnParams{ColumnID: "c", StatusIDs: []string{}}},
+		{name: "missing column_id", params: UpdateColumnParams{Name: &name}, wantErr: true},
+		{name: "empty name", params: UpdateColumnParams{ColumnID: "c", Name: &empty}, wantErr: true},
+		{name: "negative position", params: UpdateColumnParams{ColumnID: "c", Position: &negative}, wantErr: true},
+		{name: "duplicate status", params: UpdateColumnParams{ColumnID: "c", StatusIDs: []string{"s1", "s1"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildLanes(t *testing.T) {
+	columns := []ViewColumn{{Column: Column{ID: "open"}}, {Column: Column{ID: "closed"}}}
+	columnsByStatus := map[string][]string{"todo": {"open"}, "done": {"closed"}}
+	list := []issues.Issue{
+		{ID: "1", StatusID: "todo", Priority: "low"},
+		{ID: "2", StatusID: "done", Priority: "critical"},
+		{ID: "3", StatusID: "todo", Priority: "critical"},
+		{ID: "4", StatusID: "backlog", Priority: "medium"},
+	}
+
+	lanes := buildLanes(columns, list, SwimlanePriority, "", columnsByStatus, map[string]string{}, nil)
+	keys := []string{}
+	for _, lane := range lanes {
+		keys = append(keys, lane.Key)
+	}
+	if want := []string{"critical", "medium", "low"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("lane keys: got %v, want %v", keys, want)
+	}
+	critical := lanes[0]
+	if critical.Label != "critical" || len(critical.Columns) != 2 ||
+		len(critical.Columns[0].Issues) != 1 || critical.Columns[0].Issues[0].ID != "3" ||
+		len(critical.Columns[1].Issues) != 1 || critical.Columns[1].Issues[0].ID != "2" {
+		t.Fatalf("critical lane: got %+v", critical)
+	}
+	if len(lanes[1].Unmapped) != 1 || lanes[1].Unmapped[0].ID != "4" {
+		t.Fatalf("medium lane unmapped: got %+v", lanes[1].Unmapped)
+	}
+
+	saved := []LaneState{{Key: "low", Collapsed: true}, {Key: "gone"}}
+	lanes = buildLanes(columns, list, SwimlanePriority, "", columnsByStatus, map[string]string{}, saved)
+	if lanes[0].Key != "low" || !lanes[0].Collapsed || lanes[1].Key != "critical" || lanes[1].Collapsed {
+		t.Fatalf("saved lane order: got %+v", lanes)
+	}
+
+	assignee := "u1"
+	list = []issues.Issue{{ID: "1", StatusID: "todo"}, {ID: "2", StatusID: "todo", AssigneeID: &assignee}}
+	lanes = buildLanes(columns, list, SwimlaneAssignee, "", columnsByStatus, map[string]string{"": "Unassigned", "u1": "Ana"}, nil)
+	if len(lanes) != 2 || lanes[0].Label != "Ana" || lanes[1].Key != "" || lanes[1].Label != "Unassigned" {
+		t.Fatalf("assignee lanes: got %+v", lanes)
+	}
+}
+
 func TestCreateBoard_NilDB(t *testing.T) {
 	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p", Name: "B", Type: "kanban"})
 	if err == nil || err.Error() != "db is required" {
@@ -119,6 +332,53 @@ func TestArchiveBoard_NilDB(t *testing.T) {
 	}
 }
 
+func TestRestoreParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  RestoreParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  RestoreParams{BoardID: "board-1"},
+			wantErr: false,
+		},
+		{
+			name:    "valid with rename",
+			params:  RestoreParams{BoardID: "board-1", Name: "Sprint board (restored)"},
+			wantErr: false,
+		},
+		{
+			name:    "missing board_id",
+			params:  RestoreParams{Name: "Sprint board"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRestoreBoard_NilDB(t *testing.T) {
+	_, err := Restore(context.Background(), nil, RestoreParams{BoardID: "some-id"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("RestoreBoard() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListTrash_NilDB(t *testing.T) {
+	_, err := ListTrash(context.Background(), nil, "proj-1")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListTrash() error = %v, want %q", err, "db is required")
+	}
+}
+
 func TestAddColumn_NilDB(t *testing.T) {
 	_, err := AddColumn(context.Background(), nil, AddColumnParams{BoardID: "b", Name: "Col"})
 	if err == nil || err.Error() != "db is required" {
@@ -140,6 +400,41 @@ func TestArchiveColumn_NilDB(t *testing.T) {
 	}
 }
 
+func TestSetLimits_NilDB(t *testing.T) {
+	_, err := SetLimits(context.Background(), nil, SetLimitsParams{ColumnID: "c"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("SetLimits() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestSetSwimlanes_NilDB(t *testing.T) {
+	_, err := SetSwimlanes(context.Background(), nil, SetSwimlanesParams{BoardID: "b", By: SwimlaneAssignee})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("SetSwimlanes() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestSaveLanes_NilDB(t *testing.T) {
+	_, err := SaveLanes(context.Background(), nil, SaveLanesParams{BoardID: "b"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("SaveLanes() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestReorderColumns_NilDB(t *testing.T) {
+	_, err := ReorderColumns(context.Background(), nil, ReorderColumnsParams{BoardID: "b", ColumnIDs: []string{"c"}})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ReorderColumns() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestUpdateColumn_NilDB(t *testing.T) {
+	_, err := UpdateColumn(context.Background(), nil, UpdateColumnParams{ColumnID: "c"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("UpdateColumn() error = %v, want %q", err, "db is required")
+	}
+}
+
 func TestAssignStatus_NilDB(t *testing.T) {
 	err := AssignStatus(context.Background(), nil, "col-1", "status-1")
 	if err == nil || err.Error() != "db is required" {
@@ -153,3 +448,128 @@ func TestUnassignStatus_NilDB(t *testing.T) {
 		t.Fatalf("UnassignStatus() error = %v, want %q", err, "db is required")
 	}
 }
+
+func TestListIssues_NilDB(t *testing.T) {
+	_, err := ListIssues(context.Background(), nil, "b", "u")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListIssues() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    filter
+		wantErr bool
+	}{
+		{name: "empty", query: "  ", want: filter{}},
+		{
+			name:  "field terms",
+			query: `type=Story,bug priority!=low assignee=me due>=2026-01-01`,
+			want: filter{
+				{field: "type", op: "=", values: []string{"story", "bug"}},
+				{field: "priority", op: "!=", values: []string{"low"}},
+				{field: "assignee", op: "=", values: []string{"me"}},
+				{field: "due", op: ">=", values: []string{"2026-01-01"}},
+			},
+		},
+		{
+			name:  "quoted and bare text",
+			query: `type="User Story" text~"Login Error" crash`,
+			want: filter{
+				{field: "type", op: "=", values: []string{"user story"}},
+				{field: "text", op: "~", values: []string{"Login Error"}},
+				{field: "text", op: "~", values: []string{"crash"}},
+			},
+		},
+		{
+			name:  "labels",
+			query: `label=Backend,"UX Debt" label!=none`,
+			want: filter{
+				{field: "label", op: "=", values: []string{"backend", "ux debt"}},
+				{field: "label", op: "!=", values: []string{"none"}},
+			},
+		},
+		{name: "label none with names", query: "label=none,backend", wantErr: true},
+		{name: "unknown field", query: "color=red", wantErr: true},
+		{name: "unsupported operator", query: "priority<high", wantErr: true},
+		{name: "unknown priority", query: "priority=urgent", wantErr: true},
+		{name: "unknown category", query: "category=blocked", wantErr: true},
+		{name: "reporter none", query: "reporter=none", wantErr: true},
+		{name: "bad user", query: "assignee=bob", wantErr: true},
+		{name: "bad date", query: "due<tomorrow", wantErr: true},
+		{name: "range with list", query: "due<2026-01-01,2026-02-01", wantErr: true},
+		{name: "missing value", query: "type=", wantErr: true},
+		{name: "unterminated quote", query: `text~"oops`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Fatalf("parseFilter() error = %v, want ErrInvalidFilter", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterCondition(t *testing.T) {
+	f, err := parseFilter(`assignee!=me due=none 50%`)
+	if err != nil {
+		t.Fatalf("parseFilter() error = %v", err)
+	}
+	var args []any
+	bind := func(arg any) string {
+		args = append(args, arg)
+		return "$" + string(rune('0'+len(args)))
+	}
+
+	got := f.condition("user-1")(bind)
+	want := `NOT COALESCE((issues.assignee_id = ANY($1::uuid[])), FALSE) AND (issues.due_date IS NULL) AND ` +
+		`(issues.title ILIKE $2 OR issues.description ILIKE $2)`
+	if got != want {
+		t.Fatalf("condition() =\n%s\nwant\n%s", got, want)
+	}
+	if len(args) != 2 || args[1] != `%50\%%` {
+		t.Fatalf("args = %v", args)
+	}
+
+	if cond := (filter{}).condition("user-1"); cond != nil {
+		t.Fatal("empty filter should compile to a nil condition")
+	}
+}
+
+func TestActiveSprintOnly(t *testing.T) {
+	var args []any
+	bind := func(arg any) string {
+		args = append(args, arg)
+		return "$" + string(rune('0'+len(args)))
+	}
+	filter := func(bind func(arg any) string) string { return "issues.priority = " + bind("high") }
+
+	got := activeSprintOnly("project-1", filter)(bind)
+	want := `issues.sprint_id IN (SELECT sp.id FROM sprints sp WHERE sp.project_id = $1 AND sp.state = 'active') AND issues.priority = $2`
+	if got != want {
+		t.Fatalf("condition =\n%s\nwant\n%s", got, want)
+	}
+	if len(args) != 2 || args[0] != "project-1" || args[1] != "high" {
+		t.Fatalf("args = %v", args)
+	}
+}
+
+func TestGetView_NilDB(t *testing.T) {
+	_, err := GetView(context.Background(), nil, "b", "u")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("GetView() error = %v, want %q", err, "db is required")
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package boards
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/issues"
+)
+
+// A board filter query narrows the issues shown on a board. It is a list of
+// whitespace-separated terms that must all match:
+//
+//	type=story,bug       issue type name, any of
+//	priority!=low        low, medium, high or critical
+//	assignee=me          me, none, a user ID or an email
+//	reporter=me          me, a user ID or an email
+//	category=todo,doing  status category: todo, doing or done
+//	label=backend,ui     label name, any of; or label=none
+//	due<2026-05-01       due date with =, !=, <, <=, >, >=; or due=none
+//	text~"login error"   title or description contains
+//
+// A term without a field is a text term. Values with spaces are double-quoted,
+// and = / != accept comma-separated lists.
+
+var ErrInvalidFilter = errors.New("invalid filter_query")
+
+var reUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var filterOps = []string{"!=", "<=", ">=", "=", "<", ">", "~"}
+
+var filterFieldOps = map[string]map[string]bool{
+	"type":     {"=": true, "!=": true},
+	"priority": {"=": true, "!=": true},
+	"assignee": {"=": true, "!=": true},
+	"reporter": {"=": true, "!=": true},
+	"category": {"=": true, "!=": true},
+	"label":    {"=": true, "!=": true},
+	"due":      {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true},
+	"text":     {"~": true},
+}
+
+var (
+	filterPriorities = map[string]bool{"low": true, "medium": true, "high": true, "critical": true}
+	filterCategories = map[string]bool{"todo": true, "doing": true, "done": true}
+)
+
+type filterTerm struct {
+	field  string
+	op     string
+	values []string
+}
+
+type filter []filterTerm
+
+func parseFilter(query string) (filter, error) {
+	raw, err := splitOutsideQuotes(query, unicode.IsSpace)
+	if err != nil {
+		return nil, err
+	}
+	f := filter{}
+	for _, s := range raw {
+		term, err := parseFilterTerm(s)
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, term)
+	}
+	return f, nil
+}
+
+func parseFilterTerm(s string) (filterTerm, error) {
+	n := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && r != '_' })
+	if n <= 0 {
+		return textTerm(s)
+	}
+	var op string
+	for _, candidate := range filterOps {
+		if strings.HasPrefix(s[n:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return textTerm(s)
+	}
+
+	field := strings.ToLower(s[:n])
+	ops, ok := filterFieldOps[field]
+	if !ok {
+		return filterTerm{}, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, field)
+	}
+	if !ops[op] {
+		return filterTerm{}, fmt.Errorf("%w: operator %q is not supported for %s", ErrInvalidFilter, op, field)
+	}
+	values, err := splitOutsideQuotes(s[n+len(op):], func(r rune) bool { return r == ',' })
+	if err != nil {
+		return filterTerm{}, err
+	}
+	if len(values) == 0 {
+		return filterTerm{}, fmt.Errorf("%w: %s requires a value", ErrInvalidFilter, field)
+	}
+	if len(values) > 1 && op != "=" && op != "!=" && op != "~" {
+		return filterTerm{}, fmt.Errorf("%w: %s%s accepts a single value", ErrInvalidFilter, field, op)
+	}
+	for i := range values {
+		values[i] = strings.ReplaceAll(values[i], `"`, "")
+		if err := validateFilterValue(field, op, values[i]); err != nil {
+			return filterTerm{}, err
+		}
+		if field != "text" {
+			values[i] = strings.ToLower(values[i])
+		}
+	}
+	if field == "due" && len(values) > 1 && slices.Contains(values, "none") {
+		return filterTerm{}, fmt.Errorf("%w: due=none cannot be combined with dates", ErrInvalidFilter)
+	}
+	if field == "label" && len(values) > 1 && slices.Contains(values, "none") {
+		return filterTerm{}, fmt.Errorf("%w: label=none cannot be combined with labels", ErrInvalidFilter)
+	}
+	return filterTerm{field: field, op: op, values: values}, nil
+}
+
+func textTerm(s string) (filterTerm, error) {
+	value := strings.ReplaceAll(s, `"`, "")
+	if strings.TrimSpace(value) == "" {
+		return filterTerm{}, fmt.Errorf("%w: empty text term", ErrInvalidFilter)
+	}
+	return filterTerm{field: "text", op: "~", values: []string{value}}, nil
+}
+
+func validateFilterValue(field, op, value string) error {
+	v := strings.ToLower(value)
+	switch field {
+	case "priority":
+		if !filterPriorities[v] {
+			return fmt.Errorf("%w: unknown priority %q", ErrInvalidFilter, value)
+		}
+	case "category":
+		if !filterCategories[v] {
+			return fmt.Errorf("%w: unknown status category %q", ErrInvalidFilter, value)
+		}
+	case "assignee", "reporter":
+		if v == "me" || reUUID.MatchString(v) || strings.Contains(v, "@") {
+			return nil
+		}
+		if v == "none" && field == "assignee" {
+			return nil
+		}
+		return fmt.Errorf("%w: %s must be me, a user ID or an email, got %q", ErrInvalidFilter, field, value)
+	case "due":
+		if v == "none" {
+			if op != "=" && op != "!=" {
+				return fmt.Errorf("%w: due%snone is not supported", ErrInvalidFilter, op)
+			}
+			return nil
+		}
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return fmt.Errorf("%w: due date must be YYYY-MM-DD, got %q", ErrInvalidFilter, value)
+		}
+	}
+	if strings.TrimSpace(v) == "" {
+		return fmt.Errorf("%w: %s requires a value", ErrInvalidFilter, field)
+	}
+	return nil
+}
+
+// splitOutsideQuotes splits s at runes matching sep that are not inside
+// double quotes, dropping empty parts.
+func splitOutsideQuotes(s string, sep func(rune) bool) ([]string, error) {
+	parts := []string{}
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case !quoted && sep(r):
+			if cur.Len() > 0 {
+				parts = append(parts, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidFilter)
+	}
+	if cur.Len() > 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts, nil
+}
+
+// condition compiles the filter into a predicate for issues.List. viewerID
+// resolves "me"; without it, "me" matches nobody.
+func (f filter) condition(viewerID string) issues.Condition {
+	if len(f) == 0 {
+		return nil
+	}
+	return func(bind func(arg any) string) string {
+		parts := make([]string, 0, len(f))
+		for _, term := range f {
+			parts = append(parts, term.sql(bind, viewerID))
+		}
+		return strings.Join(parts, " AND ")
+	}
+}
+
+func (t filterTerm) sql(bind func(arg any) string, viewerID string) string {
+	var expr string
+	switch t.field {
+	case "type":
+		expr = `issues.issue_type_id IN (
+			SELECT it.id FROM issue_types it WHERE lower(it.name) = ANY(` + bind(pq.Array(t.values)) + `))`
+	case "priority":
+		expr = `issues.priority = ANY(` + bind(pq.Array(t.values)) + `)`
+	case "category":
+		expr = `issues.status_id IN (
+			SELECT s.id FROM statuses s WHERE s.category = ANY(` + bind(pq.Array(t.values)) + `))`
+	case "label":
+		if t.values[0] == "none" {
+			expr = `NOT EXISTS (SELECT 1 FROM issue_labels il WHERE il.issue_id = issues.id)`
+		} else {
+			expr = `EXISTS (
+				SELECT 1 FROM issue_labels il JOIN labels l ON l.id = il.label_id
+				WHERE il.issue_id = issues.id AND lower(l.name) = ANY(` + bind(pq.Array(t.values)) + `))`
+		}
+	case "assignee":
+		expr = userFilterSQL("issues.assignee_id", t.values, bind, viewerID)
+	case "reporter":
+		expr = userFilterSQL("issues.reporter_id", t.values, bind, viewerID)
+	case "due":
+		if t.values[0] == "none" {
+			expr = `issues.due_date IS NULL`
+		} else if t.op == "=" || t.op == "!=" {
+			expr = `issues.due_date = ANY(` + bind(pq.Array(t.values)) + `::date[])`
+		} else {
+			expr = `issues.due_date ` + t.op + ` ` + bind(t.values[0]) + `::date`
+		}
+	case "text":
+		matches := make([]string, 0, len(t.values))
+		for _, v := range t.values {
+			p := bind("%" + escapeLike(v) + "%")
+			matches = append(matches, `issues.title ILIKE `+p+` OR issues.description ILIKE `+p)
+		}
+		return "(" + strings.Join(matches, " OR ") + ")"
+	}
+	if t.op == "!=" {
+		// Negations must keep rows whose column is NULL (e.g. unassigned).
+		return "NOT COALESCE((" + expr + "), FALSE)"
+	}
+	return "(" + expr + ")"
+}
+
+func userFilterSQL(col string, values []string, bind func(arg any) string, viewerID string) string {
+	var ids, emails []string
+	none := false
+	for _, v := range values {
+		switch {
+		case v == "none":
+			none = true
+		case v == "me":
+			if viewerID != "" {
+				ids = append(ids, viewerID)
+			}
+		case strings.Contains(v, "@"):
+			emails = append(emails, v)
+		default:
+			ids = append(ids, v)
+		}
+	}
+	parts := []string{}
+	if len(ids) > 0 {
+		parts = append(parts, col+` = ANY(`+bind(pq.Array(ids))+`::uuid[])`)
+	}
+	if len(emails) > 0 {
+		parts = append(parts, col+` IN (SELECT u.id FROM app_users u WHERE lower(u.email) = ANY(`+bind(pq.Array(emails))+`))`)
+	}
+	if none {
+		parts = append(parts, col+` IS NULL`)
+	}
+	if len(parts) == 0 {
+		return "FALSE"
+	}
+	return strings.Join(parts, " OR ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -7,6 +7,7 @@ package boards
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/jmoiron/sqlx"
@@ -18,10 +19,19 @@ func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
 	mux.HandleFunc("POST /projects/{projectID}/boards", handleCreate(db))
 	mux.HandleFunc("GET /projects/{projectID}/boards", handleList(db))
 	mux.HandleFunc("GET /boards/{boardID}", handleGet(db))
+	mux.HandleFunc("GET /boards/{boardID}/issues", handleListIssues(db))
+	mux.HandleFunc("GET /boards/{boardID}/view", handleView(db))
 	mux.HandleFunc("DELETE /boards/{boardID}", handleArchive(db))
+	mux.HandleFunc("GET /projects/{projectID}/boards/trash", handleListTrash(db))
+	mux.HandleFunc("POST /boards/{boardID}/restore", handleRestore(db))
+	mux.HandleFunc("PUT /boards/{boardID}/swimlanes", handleSetSwimlanes(db))
+	mux.HandleFunc("PUT /boards/{boardID}/lanes", handleSaveLanes(db))
 	mux.HandleFunc("POST /boards/{boardID}/columns", handleAddColumn(db))
 	mux.HandleFunc("GET /boards/{boardID}/columns", handleListColumns(db))
+	mux.HandleFunc("PUT /boards/{boardID}/columns/order", handleReorderColumns(db))
+	mux.HandleFunc("PUT /columns/{columnID}", handleUpdateColumn(db))
 	mux.HandleFunc("DELETE /columns/{columnID}", handleArchiveColumn(db))
+	mux.HandleFunc("PUT /columns/{columnID}/limits", handleSetLimits(db))
 	mux.HandleFunc("POST /columns/{columnID}/statuses", handleAssignStatus(db))
 	mux.HandleFunc("DELETE /columns/{columnID}/statuses/{statusID}", handleUnassignStatus(db))
 }
@@ -41,6 +51,9 @@ func fail(w http.ResponseWriter, err error) {
 		respond.Error(w, http.StatusNotFound, err.Error())
 	case errors.Is(err, ErrDuplicateName), errors.Is(err, ErrDuplicateColumnName):
 		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidFilter), errors.Is(err, ErrInvalidSwimlaneField),
+		errors.Is(err, ErrInvalidOrder), errors.Is(err, ErrStatusNotFound):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
 	default:
 		respond.Error(w, http.StatusInternalServerError, "internal server error")
 	}
@@ -118,6 +131,48 @@ func handleGet(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleListIssues(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		if _, _, err := authz.RequireBoardAccess(r.Context(), db, boardID); err != nil {
+			fail(w, err)
+			return
+		}
+		viewerID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListIssues(r.Context(), db, boardID, viewerID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleView(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		if _, _, err := authz.RequireBoardAccess(r.Context(), db, boardID); err != nil {
+			fail(w, err)
+			return
+		}
+		viewerID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		view, err := GetView(r.Context(), db, boardID, viewerID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, view)
+	}
+}
+
 func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		boardID := r.PathValue("boardID")
@@ -138,6 +193,117 @@ func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleListTrash(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		if _, err := authz.RequireProjectMembership(r.Context(), db, projID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListTrash(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleRestore(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		wsID, _, err := authz.RequireBoardAccess(r.Context(), db, boardID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := respond.Decode(r, &body); err != nil && !errors.Is(err, io.EOF) {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := RestoreParams{BoardID: boardID, Name: body.Name}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		board, err := Restore(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, board)
+	}
+}
+
+func handleSetSwimlanes(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		wsID, _, err := authz.RequireBoardAccess(r.Context(), db, boardID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			SwimlaneBy      string `json:"swimlane_by"`
+			SwimlaneFieldID string `json:"swimlane_field_id"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := SetSwimlanesParams{BoardID: boardID, By: body.SwimlaneBy, FieldID: body.SwimlaneFieldID}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		board, err := SetSwimlanes(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, board)
+	}
+}
+
+func handleSaveLanes(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		if _, _, err := authz.RequireBoardAccess(r.Context(), db, boardID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Lanes []LaneState `json:"lanes"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := SaveLanesParams{BoardID: boardID, Lanes: body.Lanes}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		lanes, err := SaveLanes(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, lanes)
+	}
+}
+
 func handleAddColumn(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		boardID := r.PathValue("boardID")
@@ -207,6 +373,109 @@ func handleArchiveColumn(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleReorderColumns(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		wsID, _, err := authz.RequireBoardAccess(r.Context(), db, boardID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			ColumnIDs []string `json:"column_ids"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := ReorderColumnsParams{BoardID: boardID, ColumnIDs: body.ColumnIDs}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		cols, err := ReorderColumns(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, cols)
+	}
+}
+
+func handleUpdateColumn(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		colID := r.PathValue("columnID")
+		wsID, _, _, err := authz.RequireColumnAccess(r.Context(), db, colID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name      *string  `json:"name"`
+			Position  *int     `json:"position"`
+			StatusIDs []string `json:"status_ids"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateColumnParams{ColumnID: colID, Name: body.Name, Position: body.Position, StatusIDs: body.StatusIDs}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		col, err := UpdateColumn(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, col)
+	}
+}
+
+func handleSetLimits(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		colID := r.PathValue("columnID")
+		wsID, _, _, err := authz.RequireColumnAccess(r.Context(), db, colID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			WIPMin  *int   `json:"wip_min"`
+			WIPMax  *int   `json:"wip_max"`
+			WIPMode string `json:"wip_mode"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := SetLimitsParams{ColumnID: colID, Min: body.WIPMin, Max: body.WIPMax, Mode: body.WIPMode}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		col, err := SetLimits(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, col)
+	}
+}
+
 func handleAssignStatus(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		colID := r.PathValue("columnID")
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package boards
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/issues"
+)
+
+const (
+	SwimlaneNone        = "none"
+	SwimlaneAssignee    = "assignee"
+	SwimlaneParent      = "parent"
+	SwimlanePriority    = "priority"
+	SwimlaneIssueType   = "issue_type"
+	SwimlaneCustomField = "custom_field"
+)
+
+var ErrInvalidSwimlaneField = errors.New("swimlane field must be an active single-value custom field of the board's project")
+
+var validSwimlanes = map[string]bool{
+	SwimlaneNone: true, SwimlaneAssignee: true, SwimlaneParent: true,
+	SwimlanePriority: true, SwimlaneIssueType: true, SwimlaneCustomField: true,
+}
+
+// priorityLaneOrder lists priority lanes from most to least urgent.
+var priorityLaneOrder = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+// Lane is one swimlane of a board view: the issues sharing a grouping value,
+// split by column like the view itself. Key is the grouping value (a user,
+// parent issue or issue type ID, a priority or a field value) and is empty
+// for issues without one.
+type Lane struct {
+	Key       string         `json:"key"`
+	Label     string         `json:"label"`
+	Collapsed bool           `json:"collapsed"`
+	Columns   []LaneColumn   `json:"columns"`
+	Unmapped  []issues.Issue `json:"unmapped"`
+}
+
+type LaneColumn struct {
+	ColumnID string         `json:"column_id"`
+	Issues   []issues.Issue `json:"issues"`
+}
+
+// LaneState is the saved position and collapsed state of a lane.
+type LaneState struct {
+	Key       string `db:"lane_key"  json:"key"`
+	Collapsed bool   `db:"collapsed" json:"collapsed"`
+}
+
+// SetSwimlanesParams chooses how a board groups its issues into lanes.
+// FieldID names the custom field when By is custom_field.
+type SetSwimlanesParams struct {
+	BoardID string
+	By      string
+	FieldID string
+}
+
+func (params SetSwimlanesParams) Validate() error {
+	if params.BoardID == "" {
+		return errors.New("board_id is required")
+	}
+	if !validSwimlanes[params.By] {
+		return errors.New("swimlane_by must be 'none', 'assignee', 'parent', 'priority', 'issue_type' or 'custom_field'")
+	}
+	if (params.By == SwimlaneCustomField) != (params.FieldID != "") {
+		return errors.New("swimlane_field_id is required with custom_field and not allowed otherwise")
+	}
+	return nil
+}
+
+// SaveLanesParams replaces the saved lane state of a board. Lanes are shown
+// in the given order; lanes left out follow in their default order.
+type SaveLanesParams struct {
+	BoardID string
+	Lanes   []LaneState
+}
+
+func (params SaveLanesParams) Validate() error {
+	if params.BoardID == "" {
+		return errors.New("board_id is required")
+	}
+	seen := make(map[string]bool, len(params.Lanes))
+	for _, lane := range params.Lanes {
+		if seen[lane.Key] {
+			return errors.New("lanes must not contain duplicate keys")
+		}
+		seen[lane.Key] = true
+	}
+	return nil
+}
+
+// SetSwimlanes changes the swimlane grouping of a board. Changing it clears
+// the saved lane state, whose keys belong to the old grouping.
+func SetSwimlanes(ctx context.Context, db *sqlx.DB, params SetSwimlanesParams) (Board, error) {
+	if db == nil {
+		return Board{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Board{}, err
+	}
+	return setSwimlanes(ctx, db, params)
+}
+
+// SaveLanes stores the lane order and collapsed state of a board.
+func SaveLanes(ctx context.Context, db *sqlx.DB, params SaveLanesParams) ([]LaneState, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return saveLanes(ctx, db, params)
+}
+
+// laneKey returns the grouping value of an issue.
+func laneKey(by, fieldID string, issue issues.Issue) string {
+	switch by {
+	case SwimlaneAssignee:
+		return derefString(issue.AssigneeID)
+	case SwimlaneParent:
+		return derefString(issue.ParentIssueID)
+	case SwimlanePriority:
+		return issue.Priority
+	case SwimlaneIssueType:
+		return issue.IssueTypeID
+	case SwimlaneCustomField:
+		raw, ok := issue.Fields[fieldID]
+		if !ok {
+			return ""
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+		return string(raw)
+	}
+	return ""
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// buildLanes splits the view's issues, given in board order, into lanes
+// with the view's columns. Saved lanes come first in their saved order; the
+// rest follow by priority or label, with the lane for issues without a value
+// last.
+func buildLanes(columns []ViewColumn, list []issues.Issue, by, fieldID string, columnsByStatus map[string][]string, labels map[string]string, saved []LaneState) []Lane {
+	columnIndex := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column.Column.ID] = i
+	}
+	laneIndex := map[string]int{}
+	lanes := []Lane{}
+	laneFor := func(key string) *Lane {
+		if i, ok := laneIndex[key]; ok {
+			return &lanes[i]
+		}
+		label, ok := labels[key]
+		if !ok {
+			label = key
+		}
+		lane := Lane{Key: key, Label: label, Columns: make([]LaneColumn, len(columns)), Unmapped: []issues.Issue{}}
+		for i, column := range columns {
+			lane.Columns[i] = LaneColumn{ColumnID: column.Column.ID, Issues: []issues.Issue{}}
+		}
+		laneIndex[key] = len(lanes)
+		lanes = append(lanes, lane)
+		return &lanes[len(lanes)-1]
+	}
+
+	place := func(issue issues.Issue) {
+		lane := laneFor(laneKey(by, fieldID, issue))
+		columnIDs := columnsByStatus[issue.StatusID]
+		if len(columnIDs) == 0 {
+			lane.Unmapped = append(lane.Unmapped, issue)
+		}
+		for _, columnID := range columnIDs {
+			i := columnIndex[columnID]
+			lane.Columns[i].Issues = append(lane.Columns[i].Issues, issue)
+		}
+	}
+	for _, issue := range list {
+		place(issue)
+	}
+
+	savedPos := make(map[string]int, len(saved))
+	for i, state := range saved {
+		savedPos[state.Key] = i
+		if j, ok := laneIndex[state.Key]; ok {
+			lanes[j].Collapsed = state.Collapsed
+		}
+	}
+	slices.SortStableFunc(lanes, func(a, b Lane) int {
+		pa, aSaved := savedPos[a.Key]
+		pb, bSaved := savedPos[b.Key]
+		switch {
+		case aSaved && bSaved:
+			return cmp.Compare(pa, pb)
+		case aSaved != bSaved:
+			if aSaved {
+				return -1
+			}
+			return 1
+		case (a.Key == "") != (b.Key == ""):
+			if a.Key == "" {
+				return 1
+			}
+			return -1
+		case by == SwimlanePriority:
+			return cmp.Compare(priorityLaneOrder[a.Key], priorityLaneOrder[b.Key])
+		}
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(a.Label), strings.ToLower(b.Label)),
+			cmp.Compare(a.Key, b.Key),
+		)
+	})
+	return lanes
+}
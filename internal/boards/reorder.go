@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package boards
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrInvalidOrder   = errors.New("column_ids must list every active column of the board exactly once")
+	ErrStatusNotFound = errors.New("status not found in the board's project")
+)
+
+// ReorderColumnsParams gives the new order of all active columns of a board.
+type ReorderColumnsParams struct {
+	BoardID   string
+	ColumnIDs []string
+}
+
+func (params ReorderColumnsParams) Validate() error {
+	if params.BoardID == "" {
+		return errors.New("board_id is required")
+	}
+	if len(params.ColumnIDs) == 0 {
+		return errors.New("column_ids is required")
+	}
+	return validateIDs("column_ids", params.ColumnIDs)
+}
+
+// UpdateColumnParams changes a column in one go. Nil fields are left alone:
+// Name renames it, Position moves it (past-the-end positions append it) and
+// StatusIDs replaces the statuses it shows; an empty, non-nil StatusIDs
+// clears them.
+type UpdateColumnParams struct {
+	ColumnID  string
+	Name      *string
+	Position  *int
+	StatusIDs []string
+}
+
+func (params UpdateColumnParams) Validate() error {
+	if params.ColumnID == "" {
+		return errors.New("column_id is required")
+	}
+	if params.Name != nil && *params.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if params.Position != nil && *params.Position < 0 {
+		return errors.New("position must be >= 0")
+	}
+	return validateIDs("status_ids", params.StatusIDs)
+}
+
+func validateIDs(name string, ids []string) error {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return errors.New(name + " must not contain empty values")
+		}
+		if seen[id] {
+			return errors.New(name + " must not contain duplicates")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ReorderColumns puts the columns of a board in the given order and returns
+// them. It returns ErrInvalidOrder unless every active column is listed.
+func ReorderColumns(ctx context.Context, db *sqlx.DB, params ReorderColumnsParams) ([]Column, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return reorderColumns(ctx, db, params)
+}
+
+// UpdateColumn renames, moves and remaps a column in a single transaction.
+// Statuses must be active statuses of the board's project.
+func UpdateColumn(ctx context.Context, db *sqlx.DB, params UpdateColumnParams) (Column, error) {
+	if db == nil {
+		return Column{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Column{}, err
+	}
+	return updateColumn(ctx, db, params)
+}
@@ -6,17 +6,23 @@
 package boards
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/customfields"
+	"github.com/start-codex/tookly/internal/issues"
 	"github.com/start-codex/tookly/internal/pgutil"
 )
 
-const boardCols = `id, project_id, name, type, filter_query, created_at, updated_at, archived_at`
-const columnCols = `id, board_id, name, position, created_at, updated_at, archived_at`
+const boardCols = `id, project_id, name, type, filter_query, swimlane_by, swimlane_field_id, created_at, updated_at, archived_at`
+const columnCols = `id, board_id, name, position, wip_min, wip_max, wip_mode, created_at, updated_at, archived_at`
 
 func createBoard(ctx context.Context, db *sqlx.DB, params CreateParams) (Board, error) {
 	var board Board
@@ -76,6 +82,379 @@ func listBoards(ctx context.Context, db *sqlx.DB, projectID string) ([]Board, er
 	return boards, nil
 }
 
+type columnStatus struct {
+	ColumnID string `db:"board_column_id"`
+	StatusID string `db:"status_id"`
+}
+
+func listColumnStatuses(ctx context.Context, db *sqlx.DB, boardID string) ([]columnStatus, error) {
+	mappings := []columnStatus{}
+	err := db.SelectContext(
+		ctx,
+		&mappings,
+		`SELECT bcs.board_column_id, bcs.status_id
+		 FROM board_column_statuses bcs
+		 JOIN board_columns bc ON bc.id = bcs.board_column_id
+		 JOIN statuses s ON s.id = bcs.status_id
+		 WHERE bc.board_id = $1
+		   AND bc.archived_at IS NULL
+		   AND s.archived_at IS NULL`,
+		boardID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list board column statuses: %w", err)
+	}
+	return mappings, nil
+}
+
+func listBoardIssues(ctx context.Context, db *sqlx.DB, boardID, viewerID string) ([]ColumnIssues, error) {
+	view, err := getView(ctx, db, boardID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ColumnIssues, 0, len(view.Columns))
+	for _, column := range view.Columns {
+		result = append(result, ColumnIssues{Column: column.Column, Issues: column.Issues})
+	}
+	return result, nil
+}
+
+type projectStatus struct {
+	ID       string `db:"id"`
+	Position int    `db:"position"`
+}
+
+func listProjectStatuses(ctx context.Context, db *sqlx.DB, projectID string) ([]projectStatus, error) {
+	statuses := []projectStatus{}
+	err := db.SelectContext(
+		ctx,
+		&statuses,
+		`SELECT id, position
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY position ASC`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list project statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// activeSprintOnly narrows a scrum board to the issues of the project's
+// active sprint; without one the board is empty.
+func activeSprintOnly(projectID string, filter issues.Condition) issues.Condition {
+	return func(bind func(arg any) string) string {
+		cond := `issues.sprint_id IN (SELECT sp.id FROM sprints sp WHERE sp.project_id = ` + bind(projectID) + ` AND sp.state = 'active')`
+		if filter != nil {
+			if extra := filter(bind); extra != "" {
+				cond += " AND " + extra
+			}
+		}
+		return cond
+	}
+}
+
+func getView(ctx context.Context, db *sqlx.DB, boardID, viewerID string) (View, error) {
+	board, err := getBoard(ctx, db, boardID)
+	if err != nil {
+		return View{}, err
+	}
+	f, err := parseFilter(board.FilterQuery)
+	if err != nil {
+		return View{}, err
+	}
+	columns, err := listColumns(ctx, db, boardID)
+	if err != nil {
+		return View{}, err
+	}
+	mappings, err := listColumnStatuses(ctx, db, boardID)
+	if err != nil {
+		return View{}, err
+	}
+	statuses, err := listProjectStatuses(ctx, db, board.ProjectID)
+	if err != nil {
+		return View{}, err
+	}
+	counts, err := countColumnIssues(ctx, db, boardID)
+	if err != nil {
+		return View{}, err
+	}
+
+	statusOrder := make(map[string]int, len(statuses))
+	statusIDs := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		statusOrder[s.ID] = s.Position
+		statusIDs = append(statusIDs, s.ID)
+	}
+	columnsByStatus := map[string][]string{}
+	for _, m := range mappings {
+		columnsByStatus[m.StatusID] = append(columnsByStatus[m.StatusID], m.ColumnID)
+	}
+
+	condition := f.condition(viewerID)
+	if board.Type == "scrum" {
+		condition = activeSprintOnly(board.ProjectID, condition)
+	}
+	list, err := issues.List(ctx, db, issues.ListParams{
+		ProjectID: board.ProjectID,
+		StatusIDs: statusIDs,
+		Condition: condition,
+	})
+	if err != nil {
+		return View{}, err
+	}
+	slices.SortStableFunc(list, func(a, b issues.Issue) int {
+		return cmp.Or(
+			cmp.Compare(a.StatusPosition, b.StatusPosition),
+			cmp.Compare(statusOrder[a.StatusID], statusOrder[b.StatusID]),
+			cmp.Compare(a.Number, b.Number),
+		)
+	})
+
+	view := View{
+		Board:    board,
+		Columns:  make([]ViewColumn, 0, len(columns)),
+		Unmapped: ViewStatuses{StatusIDs: []string{}, Issues: []issues.Issue{}},
+	}
+	columnIndex := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column.ID] = i
+		view.Columns = append(view.Columns, ViewColumn{
+			Column:       column,
+			WIP:          wipStatus(column, counts[column.ID]),
+			ViewStatuses: ViewStatuses{StatusIDs: []string{}, Issues: []issues.Issue{}},
+		})
+	}
+	for _, s := range statuses {
+		columnIDs := columnsByStatus[s.ID]
+		if len(columnIDs) == 0 {
+			view.Unmapped.StatusIDs = append(view.Unmapped.StatusIDs, s.ID)
+		}
+		for _, columnID := range columnIDs {
+			i := columnIndex[columnID]
+			view.Columns[i].StatusIDs = append(view.Columns[i].StatusIDs, s.ID)
+		}
+	}
+	for _, issue := range list {
+		columnIDs := columnsByStatus[issue.StatusID]
+		if len(columnIDs) == 0 {
+			view.Unmapped.Issues = append(view.Unmapped.Issues, issue)
+		}
+		for _, columnID := range columnIDs {
+			i := columnIndex[columnID]
+			view.Columns[i].Issues = append(view.Columns[i].Issues, issue)
+		}
+	}
+
+	by, fieldID, fieldType, err := swimlaneGrouping(ctx, db, board)
+	if err != nil {
+		return View{}, err
+	}
+	if by == SwimlaneNone {
+		return view, nil
+	}
+	labels, err := laneLabels(ctx, db, by, fieldID, fieldType, list)
+	if err != nil {
+		return View{}, err
+	}
+	saved, err := listLaneStates(ctx, db, boardID)
+	if err != nil {
+		return View{}, err
+	}
+	view.Lanes = buildLanes(view.Columns, list, by, fieldID, columnsByStatus, labels, saved)
+	return view, nil
+}
+
+// swimlaneGrouping returns the board's lane grouping and, for custom field
+// lanes, the field and its type. Lanes fall back to none when the field was
+// archived or deleted since.
+func swimlaneGrouping(ctx context.Context, db *sqlx.DB, board Board) (by, fieldID, fieldType string, err error) {
+	if board.SwimlaneBy != SwimlaneCustomField {
+		return board.SwimlaneBy, "", "", nil
+	}
+	if board.SwimlaneFieldID == nil {
+		return SwimlaneNone, "", "", nil
+	}
+	err = db.GetContext(ctx, &fieldType,
+		`SELECT field_type FROM custom_fields WHERE id = $1 AND archived_at IS NULL`,
+		*board.SwimlaneFieldID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SwimlaneNone, "", "", nil
+	}
+	if err != nil {
+		return "", "", "", fmt.Errorf("load swimlane field: %w", err)
+	}
+	return SwimlaneCustomField, *board.SwimlaneFieldID, fieldType, nil
+}
+
+// laneLabels names the lanes of a grouping whose keys are IDs: users, parent
+// issues and issue types. Other keys are their own label. The empty key
+// labels issues without a value.
+func laneLabels(ctx context.Context, db *sqlx.DB, by, fieldID, fieldType string, list []issues.Issue) (map[string]string, error) {
+	labels := map[string]string{}
+	var query string
+	switch by {
+	case SwimlaneAssignee:
+		labels[""] = "Unassigned"
+		query = `SELECT id, name FROM app_users WHERE id = ANY($1::uuid[])`
+	case SwimlaneParent:
+		labels[""] = "No parent"
+		query = `SELECT i.id, p.key || '-' || i.number || ' ' || i.title AS name
+		 FROM issues i
+		 JOIN projects p ON p.id = i.project_id
+		 WHERE i.id = ANY($1::uuid[])`
+	case SwimlaneIssueType:
+		query = `SELECT id, name FROM issue_types WHERE id = ANY($1::uuid[])`
+	case SwimlaneCustomField:
+		labels[""] = "No value"
+		if fieldType == customfields.TypeUser {
+			query = `SELECT id, name FROM app_users WHERE id = ANY($1::uuid[])`
+		}
+	}
+	ids := []string{}
+	for _, issue := range list {
+		if key := laneKey(by, fieldID, issue); key != "" {
+			ids = append(ids, key)
+		}
+	}
+	if query == "" || len(ids) == 0 {
+		return labels, nil
+	}
+	var rows []struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}
+	if err := db.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("load lane labels: %w", err)
+	}
+	for _, row := range rows {
+		labels[row.ID] = row.Name
+	}
+	return labels, nil
+}
+
+func listLaneStates(ctx context.Context, db *sqlx.DB, boardID string) ([]LaneState, error) {
+	states := []LaneState{}
+	if err := db.SelectContext(ctx, &states,
+		`SELECT lane_key, collapsed
+		 FROM board_lanes
+		 WHERE board_id = $1
+		 ORDER BY position ASC`,
+		boardID,
+	); err != nil {
+		return nil, fmt.Errorf("list board lanes: %w", err)
+	}
+	return states, nil
+}
+
+func setSwimlanes(ctx context.Context, db *sqlx.DB, params SetSwimlanesParams) (Board, error) {
+	var board Board
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit board swimlanes", func(tx *sqlx.Tx) error {
+		var before struct {
+			ProjectID string  `db:"project_id"`
+			By        string  `db:"swimlane_by"`
+			FieldID   *string `db:"swimlane_field_id"`
+		}
+		if err := tx.GetContext(ctx, &before,
+			`SELECT project_id, swimlane_by, swimlane_field_id
+			 FROM boards
+			 WHERE id = $1
+			   AND archived_at IS NULL
+			 FOR UPDATE`,
+			params.BoardID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("lock board: %w", err)
+		}
+
+		var fieldID *string
+		if params.By == SwimlaneCustomField {
+			var fieldType string
+			err := tx.GetContext(ctx, &fieldType,
+				`SELECT field_type
+				 FROM custom_fields
+				 WHERE id = $1
+				   AND project_id = $2
+				   AND archived_at IS NULL`,
+				params.FieldID, before.ProjectID,
+			)
+			if errors.Is(err, sql.ErrNoRows) || fieldType == customfields.TypeMultiSelect {
+				return ErrInvalidSwimlaneField
+			}
+			if err != nil {
+				return fmt.Errorf("load swimlane field: %w", err)
+			}
+			fieldID = &params.FieldID
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE boards
+			 SET swimlane_by       = $2,
+			     swimlane_field_id = $3
+			 WHERE id = $1
+			 RETURNING `+boardCols,
+			params.BoardID, params.By, fieldID,
+		).StructScan(&board); err != nil {
+			return fmt.Errorf("update board swimlanes: %w", err)
+		}
+
+		if before.By == params.By && derefString(before.FieldID) == params.FieldID {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM board_lanes WHERE board_id = $1`, params.BoardID); err != nil {
+			return fmt.Errorf("clear board lanes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Board{}, err
+	}
+	return board, nil
+}
+
+func saveLanes(ctx context.Context, db *sqlx.DB, params SaveLanesParams) ([]LaneState, error) {
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit board lanes", func(tx *sqlx.Tx) error {
+		var id string
+		if err := tx.GetContext(ctx, &id,
+			`SELECT id FROM boards WHERE id = $1 AND archived_at IS NULL FOR UPDATE`,
+			params.BoardID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("lock board: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM board_lanes WHERE board_id = $1`, params.BoardID); err != nil {
+			return fmt.Errorf("clear board lanes: %w", err)
+		}
+		keys := make([]string, len(params.Lanes))
+		collapsed := make([]bool, len(params.Lanes))
+		for i, lane := range params.Lanes {
+			keys[i], collapsed[i] = lane.Key, lane.Collapsed
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO board_lanes (board_id, lane_key, position, collapsed)
+			 SELECT $1, lane.key, lane.ord - 1, lane.collapsed
+			 FROM unnest($2::text[], $3::boolean[]) WITH ORDINALITY AS lane(key, collapsed, ord)`,
+			params.BoardID, pq.Array(keys), pq.Array(collapsed),
+		); err != nil {
+			return fmt.Errorf("save board lanes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	states := make([]LaneState, len(params.Lanes))
+	copy(states, params.Lanes)
+	return states, nil
+}
+
 func archiveBoard(ctx context.Context, db *sqlx.DB, id string) error {
 	res, err := db.ExecContext(
 		ctx,
@@ -98,6 +477,59 @@ func archiveBoard(ctx context.Context, db *sqlx.DB, id string) error {
 	return nil
 }
 
+func listTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Board, error) {
+	boards := []Board{}
+	if err := db.SelectContext(ctx, &boards,
+		`SELECT `+boardCols+`
+		 FROM boards
+		 WHERE project_id = $1
+		   AND archived_at IS NOT NULL
+		 ORDER BY archived_at DESC, name ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list archived boards: %w", err)
+	}
+	return boards, nil
+}
+
+func restoreBoard(ctx context.Context, db *sqlx.DB, params RestoreParams) (Board, error) {
+	var board Board
+	err := db.QueryRowxContext(ctx,
+		`UPDATE boards
+		 SET archived_at = NULL,
+		     name        = COALESCE(NULLIF($2, ''), name)
+		 WHERE id = $1
+		   AND archived_at IS NOT NULL
+		 RETURNING `+boardCols,
+		params.BoardID, params.Name,
+	).StructScan(&board)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Board{}, ErrNotFound
+		}
+		if pgutil.IsUniqueViolation(err) {
+			return Board{}, ErrDuplicateName
+		}
+		return Board{}, fmt.Errorf("restore board: %w", err)
+	}
+	return board, nil
+}
+
+func purgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM boards WHERE archived_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge archived boards: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge archived boards rows affected: %w", err)
+	}
+	return n, nil
+}
+
 func addColumn(ctx context.Context, db *sqlx.DB, params AddColumnParams) (Column, error) {
 	var column Column
 	err := db.QueryRowxContext(
@@ -140,6 +572,204 @@ func listColumns(ctx context.Context, db *sqlx.DB, boardID string) ([]Column, er
 	return columns, nil
 }
 
+func reorderColumns(ctx context.Context, db *sqlx.DB, params ReorderColumnsParams) ([]Column, error) {
+	var columns []Column
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit column order", func(tx *sqlx.Tx) error {
+		var id string
+		if err := tx.GetContext(ctx, &id,
+			`SELECT id FROM boards WHERE id = $1 AND archived_at IS NULL FOR UPDATE`,
+			params.BoardID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("lock board: %w", err)
+		}
+		current, err := lockColumnOrder(ctx, tx, params.BoardID)
+		if err != nil {
+			return err
+		}
+		if len(current) != len(params.ColumnIDs) {
+			return ErrInvalidOrder
+		}
+		for _, columnID := range params.ColumnIDs {
+			if !slices.Contains(current, columnID) {
+				return ErrInvalidOrder
+			}
+		}
+		if err := placeColumns(ctx, tx, params.BoardID, params.ColumnIDs); err != nil {
+			return err
+		}
+		columns, err = listColumnsTx(ctx, tx, params.BoardID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func updateColumn(ctx context.Context, db *sqlx.DB, params UpdateColumnParams) (Column, error) {
+	var column Column
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit board column", func(tx *sqlx.Tx) error {
+		var boardID string
+		if err := tx.GetContext(ctx, &boardID,
+			`SELECT bc.board_id
+			 FROM board_columns bc
+			 JOIN boards b ON b.id = bc.board_id
+			 WHERE bc.id = $1
+			   AND bc.archived_at IS NULL
+			   AND b.archived_at IS NULL
+			 FOR UPDATE OF b`,
+			params.ColumnID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrColumnNotFound
+			}
+			return fmt.Errorf("lock board: %w", err)
+		}
+
+		if params.Name != nil {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE board_columns SET name = $2 WHERE id = $1`,
+				params.ColumnID, *params.Name,
+			); err != nil {
+				if pgutil.IsUniqueViolation(err) {
+					return ErrDuplicateColumnName
+				}
+				return fmt.Errorf("rename board column: %w", err)
+			}
+		}
+
+		if params.Position != nil {
+			current, err := lockColumnOrder(ctx, tx, boardID)
+			if err != nil {
+				return err
+			}
+			order := slices.DeleteFunc(current, func(id string) bool { return id == params.ColumnID })
+			pos := min(*params.Position, len(order))
+			order = slices.Insert(order, pos, params.ColumnID)
+			if err := placeColumns(ctx, tx, boardID, order); err != nil {
+				return err
+			}
+		}
+
+		if params.StatusIDs != nil {
+			if err := replaceColumnStatuses(ctx, tx, boardID, params.ColumnID, params.StatusIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.GetContext(ctx, &column,
+			`SELECT `+columnCols+` FROM board_columns WHERE id = $1`,
+			params.ColumnID,
+		); err != nil {
+			return fmt.Errorf("get board column: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Column{}, err
+	}
+	return column, nil
+}
+
+// lockColumnOrder locks the active columns of a board and returns their IDs
+// in board order. Callers hold the board row lock first.
+func lockColumnOrder(ctx context.Context, tx *sqlx.Tx, boardID string) ([]string, error) {
+	var ids []string
+	if err := tx.SelectContext(ctx, &ids,
+		`SELECT id
+		 FROM board_columns
+		 WHERE board_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY position ASC
+		 FOR UPDATE`,
+		boardID,
+	); err != nil {
+		return nil, fmt.Errorf("lock board columns: %w", err)
+	}
+	return ids, nil
+}
+
+// placeColumns gives the listed active columns of a board the positions 0..n
+// in order. Positions are first lifted past pgutil.ReorderOffset so no intermediate
+// state collides with the unique index.
+func placeColumns(ctx context.Context, tx *sqlx.Tx, boardID string, order []string) error {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE board_columns
+		 SET position = position + $1
+		 WHERE board_id = $2
+		   AND archived_at IS NULL`,
+		pgutil.ReorderOffset, boardID,
+	); err != nil {
+		return fmt.Errorf("phase 1 lift columns: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE board_columns bc
+		 SET position = o.ord - 1
+		 FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		 WHERE bc.id = o.id
+		   AND bc.board_id = $1`,
+		boardID, pq.Array(order),
+	); err != nil {
+		return fmt.Errorf("phase 2 place columns: %w", err)
+	}
+	return nil
+}
+
+// replaceColumnStatuses sets the statuses shown in a column. They must be
+// active statuses of the board's project.
+func replaceColumnStatuses(ctx context.Context, tx *sqlx.Tx, boardID, columnID string, statusIDs []string) error {
+	var found int
+	if err := tx.GetContext(ctx, &found,
+		`SELECT COUNT(*)
+		 FROM statuses s
+		 JOIN boards b ON b.project_id = s.project_id
+		 WHERE b.id = $1
+		   AND s.id = ANY($2::uuid[])
+		   AND s.archived_at IS NULL`,
+		boardID, pq.Array(statusIDs),
+	); err != nil {
+		return fmt.Errorf("check column statuses: %w", err)
+	}
+	if found != len(statusIDs) {
+		return ErrStatusNotFound
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM board_column_statuses
+		 WHERE board_column_id = $1
+		   AND NOT (status_id = ANY($2::uuid[]))`,
+		columnID, pq.Array(statusIDs),
+	); err != nil {
+		return fmt.Errorf("unassign column statuses: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO board_column_statuses (board_column_id, status_id)
+		 SELECT $1, unnest($2::uuid[])
+		 ON CONFLICT DO NOTHING`,
+		columnID, pq.Array(statusIDs),
+	); err != nil {
+		return fmt.Errorf("assign column statuses: %w", err)
+	}
+	return nil
+}
+
+func listColumnsTx(ctx context.Context, tx *sqlx.Tx, boardID string) ([]Column, error) {
+	columns := []Column{}
+	if err := tx.SelectContext(ctx, &columns,
+		`SELECT `+columnCols+`
+		 FROM board_columns
+		 WHERE board_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY position ASC`,
+		boardID,
+	); err != nil {
+		return nil, fmt.Errorf("list board columns: %w", err)
+	}
+	return columns, nil
+}
+
 func archiveColumn(ctx context.Context, db *sqlx.DB, id string) error {
 	res, err := db.ExecContext(
 		ctx,
@@ -162,6 +792,62 @@ func archiveColumn(ctx context.Context, db *sqlx.DB, id string) error {
 	return nil
 }
 
+func setLimits(ctx context.Context, db *sqlx.DB, params SetLimitsParams) (Column, error) {
+	var column Column
+	err := db.QueryRowxContext(
+		ctx,
+		`UPDATE board_columns
+		 SET wip_min  = $2,
+		     wip_max  = $3,
+		     wip_mode = $4
+		 WHERE id = $1
+		   AND archived_at IS NULL
+		 RETURNING `+columnCols,
+		params.ColumnID,
+		params.Min,
+		params.Max,
+		params.Mode,
+	).StructScan(&column)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Column{}, ErrColumnNotFound
+		}
+		return Column{}, fmt.Errorf("set column limits: %w", err)
+	}
+	return column, nil
+}
+
+// countColumnIssues counts the active issues in the active statuses of each
+// column of a board.
+func countColumnIssues(ctx context.Context, db *sqlx.DB, boardID string) (map[string]int, error) {
+	var rows []struct {
+		ColumnID string `db:"board_column_id"`
+		Count    int    `db:"count"`
+	}
+	err := db.SelectContext(
+		ctx,
+		&rows,
+		`SELECT bcs.board_column_id, COUNT(i.id) AS count
+		 FROM board_column_statuses bcs
+		 JOIN board_columns bc ON bc.id = bcs.board_column_id
+		 JOIN statuses s ON s.id = bcs.status_id
+		 JOIN issues i ON i.status_id = s.id AND i.archived_at IS NULL
+		 WHERE bc.board_id = $1
+		   AND bc.archived_at IS NULL
+		   AND s.archived_at IS NULL
+		 GROUP BY bcs.board_column_id`,
+		boardID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("count board column issues: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.ColumnID] = row.Count
+	}
+	return counts, nil
+}
+
 func assignStatus(ctx context.Context, db *sqlx.DB, boardColumnID, statusID string) error {
 	_, err := db.ExecContext(
 		ctx,
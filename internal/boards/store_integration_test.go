@@ -8,10 +8,13 @@ package boards
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/issues"
 	"github.com/start-codex/tookly/internal/testpg"
 )
 
@@ -197,6 +200,46 @@ func TestListBoards(t *testing.T) {
 	}
 }
 
+func TestRestoreBoard(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj := seedProject(t, db)
+	archived, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Team", Type: "kanban"})
+	if err != nil {
+		t.Fatalf("seed board: %v", err)
+	}
+	if err := Archive(ctx, db, archived.ID); err != nil {
+		t.Fatalf("archive board: %v", err)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Team", Type: "kanban"}); err != nil {
+		t.Fatalf("reuse archived board name: %v", err)
+	}
+
+	trash, err := ListTrash(ctx, db, proj)
+	if err != nil {
+		t.Fatalf("ListTrash() error = %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != archived.ID {
+		t.Fatalf("ListTrash(): got %+v, want the archived board", trash)
+	}
+
+	if _, err := Restore(ctx, db, RestoreParams{BoardID: archived.ID}); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("Restore() with a taken name error = %v, want %v", err, ErrDuplicateName)
+	}
+	restored, err := Restore(ctx, db, RestoreParams{BoardID: archived.ID, Name: "Team (old)"})
+	if err != nil {
+		t.Fatalf("Restore() with rename error = %v", err)
+	}
+	if restored.Name != "Team (old)" || restored.ArchivedAt != nil {
+		t.Fatalf("Restore(): got %+v, want an active board named %q", restored, "Team (old)")
+	}
+	if _, err := Restore(ctx, db, RestoreParams{BoardID: archived.ID}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore() of an active board error = %v, want %v", err, ErrNotFound)
+	}
+}
+
 func TestAddColumn(t *testing.T) {
 	db := testpg.Open(t)
 	testpg.EnsureMigrated(t, db)
@@ -418,3 +461,577 @@ func seedProjectWithStatus(t *testing.T, db *sqlx.DB) (projectID, statusID strin
 	}
 	return proj, statusID
 }
+
+func TestReorderColumns(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	board := seedBoard(t, db)
+	var ids []string
+	for _, name := range []string{"To Do", "Doing", "Done", "Old"} {
+		col, err := AddColumn(ctx, db, AddColumnParams{BoardID: board, Name: name})
+		if err != nil {
+			t.Fatalf("add column: %v", err)
+		}
+		ids = append(ids, col.ID)
+	}
+	if err := ArchiveColumn(ctx, db, ids[3]); err != nil {
+		t.Fatalf("archive column: %v", err)
+	}
+	active := ids[:3]
+
+	want := []string{active[2], active[0], active[1]}
+	cols, err := ReorderColumns(ctx, db, ReorderColumnsParams{BoardID: board, ColumnIDs: want})
+	if err != nil {
+		t.Fatalf("ReorderColumns() error = %v", err)
+	}
+	for i, col := range cols {
+		if col.ID != want[i] || col.Position != i {
+			t.Fatalf("cols[%d] = %s at %d, want %s at %d", i, col.ID, col.Position, want[i], i)
+		}
+	}
+
+	for _, order := range [][]string{
+		{active[0], active[1]},
+		{active[0], active[1], active[2], ids[3]},
+		{active[0], active[1], ids[3]},
+	} {
+		if _, err := ReorderColumns(ctx, db, ReorderColumnsParams{BoardID: board, ColumnIDs: order}); !errors.Is(err, ErrInvalidOrder) {
+			t.Fatalf("ReorderColumns(%v) error = %v, want ErrInvalidOrder", order, err)
+		}
+	}
+}
+
+func TestUpdateColumn(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var doing, review, other string
+	if err := db.GetContext(ctx, &doing,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Doing', 'doing', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &review,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Review', 'doing', 2) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	_, other = seedProjectWithStatus(t, db)
+
+	board, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Board", Type: "kanban"})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	var cols []Column
+	for _, name := range []string{"To Do", "Doing", "Done"} {
+		col, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: name})
+		if err != nil {
+			t.Fatalf("add column: %v", err)
+		}
+		cols = append(cols, col)
+	}
+	if err := AssignStatus(ctx, db, cols[1].ID, todo); err != nil {
+		t.Fatalf("assign status: %v", err)
+	}
+
+	name, first := "In review", 0
+	got, err := UpdateColumn(ctx, db, UpdateColumnParams{
+		ColumnID:  cols[1].ID,
+		Name:      &name,
+		Position:  &first,
+		StatusIDs: []string{doing, review},
+	})
+	if err != nil {
+		t.Fatalf("UpdateColumn() error = %v", err)
+	}
+	if got.Name != name || got.Position != 0 {
+		t.Fatalf("UpdateColumn() = %q at %d, want %q at 0", got.Name, got.Position, name)
+	}
+	list, err := ListColumns(ctx, db, board.ID)
+	if err != nil {
+		t.Fatalf("list columns: %v", err)
+	}
+	wantOrder := []string{cols[1].ID, cols[0].ID, cols[2].ID}
+	for i, col := range list {
+		if col.ID != wantOrder[i] || col.Position != i {
+			t.Fatalf("columns[%d] = %s at %d, want %s at %d", i, col.ID, col.Position, wantOrder[i], i)
+		}
+	}
+	var mapped []string
+	if err := db.SelectContext(ctx, &mapped,
+		`SELECT status_id FROM board_column_statuses WHERE board_column_id = $1`, cols[1].ID,
+	); err != nil {
+		t.Fatalf("list column statuses: %v", err)
+	}
+	slices.Sort(mapped)
+	wantMapped := []string{doing, review}
+	slices.Sort(wantMapped)
+	if !slices.Equal(mapped, wantMapped) {
+		t.Fatalf("column statuses = %v, want %v", mapped, wantMapped)
+	}
+
+	last := 99
+	got, err = UpdateColumn(ctx, db, UpdateColumnParams{ColumnID: cols[1].ID, Position: &last})
+	if err != nil {
+		t.Fatalf("UpdateColumn() past the end error = %v", err)
+	}
+	if got.Position != 2 || got.Name != name {
+		t.Fatalf("UpdateColumn() past the end = %q at %d, want %q at 2", got.Name, got.Position, name)
+	}
+
+	if _, err := UpdateColumn(ctx, db, UpdateColumnParams{ColumnID: cols[1].ID, StatusIDs: []string{doing, other}}); !errors.Is(err, ErrStatusNotFound) {
+		t.Fatalf("UpdateColumn() with foreign status error = %v, want ErrStatusNotFound", err)
+	}
+	dup := "To Do"
+	if _, err := UpdateColumn(ctx, db, UpdateColumnParams{ColumnID: cols[1].ID, Name: &dup}); !errors.Is(err, ErrDuplicateColumnName) {
+		t.Fatalf("UpdateColumn() with duplicate name error = %v, want ErrDuplicateColumnName", err)
+	}
+}
+
+func TestListBoardIssues(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var doing, story, bug string
+	if err := db.GetContext(ctx, &doing,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Doing', 'doing', 1) RETURNING id`,
+		proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &story,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Story', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed story type: %v", err)
+	}
+	if err := db.GetContext(ctx, &bug,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Bug', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed bug type: %v", err)
+	}
+	reporter := testpg.SeedUser(t, db)
+
+	insert := func(number int, typeID, statusID, title, priority string, position int) string {
+		t.Helper()
+		var id string
+		if err := db.GetContext(ctx, &id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position)
+			 VALUES ($1, $2, $3, $4, $5, '', $6, $7, $8)
+			 RETURNING id`,
+			proj, number, typeID, statusID, title, priority, reporter, position,
+		); err != nil {
+			t.Fatalf("insert issue: %v", err)
+		}
+		return id
+	}
+	loginStory := insert(1, story, todo, "Login page", "high", 0)
+	insert(2, bug, todo, "Login crash", "high", 1)
+	insert(3, story, todo, "Signup page", "low", 2)
+	doingStory := insert(4, story, doing, "Login audit", "critical", 0)
+
+	board, err := Create(ctx, db, CreateParams{
+		ProjectID:   proj,
+		Name:        "Stories",
+		Type:        "kanban",
+		FilterQuery: "type=story priority!=low login",
+	})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todoCol, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "To Do"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	doingCol, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "Doing"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	emptyCol, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "Done"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	if err := AssignStatus(ctx, db, todoCol.ID, todo); err != nil {
+		t.Fatalf("assign status: %v", err)
+	}
+	if err := AssignStatus(ctx, db, doingCol.ID, doing); err != nil {
+		t.Fatalf("assign status: %v", err)
+	}
+
+	got, err := ListIssues(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("columns: got %d, want 3", len(got))
+	}
+	want := map[string][]string{
+		todoCol.ID:  {loginStory},
+		doingCol.ID: {doingStory},
+		emptyCol.ID: {},
+	}
+	for _, col := range got {
+		ids := []string{}
+		for _, issue := range col.Issues {
+			ids = append(ids, issue.ID)
+		}
+		if len(ids) != len(want[col.Column.ID]) || (len(ids) > 0 && ids[0] != want[col.Column.ID][0]) {
+			t.Fatalf("column %q issues: got %v, want %v", col.Column.Name, ids, want[col.Column.ID])
+		}
+	}
+
+	if _, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Bad", Type: "kanban", FilterQuery: "color=red"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Fatalf("Create() with bad filter error = %v, want ErrInvalidFilter", err)
+	}
+}
+
+func TestGetBoardView(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var doing, done, issueType string
+	if err := db.GetContext(ctx, &doing,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Doing', 'doing', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &done,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Done', 'done', 2) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &issueType,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	reporter := testpg.SeedUser(t, db)
+
+	insert := func(number int, statusID string, position int) string {
+		t.Helper()
+		var id string
+		if err := db.GetContext(ctx, &id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position)
+			 VALUES ($1, $2, $3, $4, 'Issue', '', 'medium', $5, $6)
+			 RETURNING id`,
+			proj, number, issueType, statusID, reporter, position,
+		); err != nil {
+			t.Fatalf("insert issue: %v", err)
+		}
+		return id
+	}
+	todo0 := insert(1, todo, 0)
+	todo1 := insert(2, todo, 1)
+	doing0 := insert(3, doing, 0)
+	done0 := insert(4, done, 0)
+
+	board, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Board", Type: "kanban"})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	open, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "Open"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	for _, statusID := range []string{doing, todo} {
+		if err := AssignStatus(ctx, db, open.ID, statusID); err != nil {
+			t.Fatalf("assign status: %v", err)
+		}
+	}
+
+	view, err := GetView(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	if view.Board.ID != board.ID {
+		t.Fatalf("board id: got %q, want %q", view.Board.ID, board.ID)
+	}
+	if len(view.Columns) != 1 {
+		t.Fatalf("columns: got %d, want 1", len(view.Columns))
+	}
+	col := view.Columns[0]
+	if want := []string{todo, doing}; !slices.Equal(col.StatusIDs, want) {
+		t.Fatalf("column status_ids: got %v, want %v", col.StatusIDs, want)
+	}
+	gotIssues := []string{}
+	for _, issue := range col.Issues {
+		gotIssues = append(gotIssues, issue.ID)
+	}
+	if want := []string{todo0, doing0, todo1}; !slices.Equal(gotIssues, want) {
+		t.Fatalf("column issues: got %v, want %v", gotIssues, want)
+	}
+	if want := []string{done}; !slices.Equal(view.Unmapped.StatusIDs, want) {
+		t.Fatalf("unmapped status_ids: got %v, want %v", view.Unmapped.StatusIDs, want)
+	}
+	if len(view.Unmapped.Issues) != 1 || view.Unmapped.Issues[0].ID != done0 {
+		t.Fatalf("unmapped issues: got %+v, want [%s]", view.Unmapped.Issues, done0)
+	}
+}
+
+func TestGetBoardView_ScrumShowsActiveSprint(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var issueType, active, planned string
+	if err := db.GetContext(ctx, &issueType,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Story', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	if err := db.GetContext(ctx, &active,
+		`INSERT INTO sprints (project_id, name, state) VALUES ($1, 'Sprint 1', 'active') RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed active sprint: %v", err)
+	}
+	if err := db.GetContext(ctx, &planned,
+		`INSERT INTO sprints (project_id, name) VALUES ($1, 'Sprint 2') RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed planned sprint: %v", err)
+	}
+	reporter := testpg.SeedUser(t, db)
+
+	insert := func(number int, sprintID *string) string {
+		t.Helper()
+		var id string
+		if err := db.GetContext(ctx, &id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position, sprint_id)
+			 VALUES ($1, $2, $3, $4, 'Issue', '', 'medium', $5, $2, $6)
+			 RETURNING id`,
+			proj, number, issueType, todo, reporter, sprintID,
+		); err != nil {
+			t.Fatalf("insert issue: %v", err)
+		}
+		return id
+	}
+	inActive := insert(1, &active)
+	insert(2, &planned)
+	insert(3, nil)
+
+	board, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Sprint Board", Type: "scrum"})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	col, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "To Do"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	if err := AssignStatus(ctx, db, col.ID, todo); err != nil {
+		t.Fatalf("assign status: %v", err)
+	}
+
+	view, err := GetView(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	if got := view.Columns[0].Issues; len(got) != 1 || got[0].ID != inActive {
+		t.Fatalf("scrum column issues: got %+v, want only %s", got, inActive)
+	}
+}
+
+func TestColumnWIPLimits(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	reporter := testpg.SeedUser(t, db)
+	ctx := authz.WithUserID(context.Background(), reporter)
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var doing, review, issueType string
+	if err := db.GetContext(ctx, &doing,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Doing', 'doing', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &review,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Review', 'doing', 2) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &issueType,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	insert := func(number int, statusID string, position int) string {
+		t.Helper()
+		var id string
+		if err := db.GetContext(ctx, &id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position)
+			 VALUES ($1, $2, $3, $4, 'Issue', '', 'medium', $5, $6)
+			 RETURNING id`,
+			proj, number, issueType, statusID, reporter, position,
+		); err != nil {
+			t.Fatalf("insert issue: %v", err)
+		}
+		return id
+	}
+	a := insert(1, todo, 0)
+	b := insert(2, todo, 1)
+	c := insert(3, doing, 0)
+
+	board, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Board", Type: "kanban"})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	inProgress, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "In progress"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	for _, statusID := range []string{doing, review} {
+		if err := AssignStatus(ctx, db, inProgress.ID, statusID); err != nil {
+			t.Fatalf("assign status: %v", err)
+		}
+	}
+
+	one, two := 1, 2
+	col, err := SetLimits(ctx, db, SetLimitsParams{ColumnID: inProgress.ID, Min: &one, Max: &two})
+	if err != nil {
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	if col.WIPMode != WIPWarn || col.WIPMax == nil || *col.WIPMax != 2 {
+		t.Fatalf("SetLimits(): got %+v", col)
+	}
+
+	// Warning limits never block.
+	for _, id := range []string{a, b} {
+		if err := issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: id, TargetStatusID: review}); err != nil {
+			t.Fatalf("Move() into warning column error = %v", err)
+		}
+	}
+	view, err := GetView(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	if got := view.Columns[0].WIP; got != (WIPStatus{Count: 3, Over: true}) {
+		t.Fatalf("view wip: got %+v, want 3 issues over the limit", got)
+	}
+
+	if _, err := SetLimits(ctx, db, SetLimitsParams{ColumnID: inProgress.ID, Min: &one, Max: &two, Mode: WIPBlock}); err != nil {
+		t.Fatalf("SetLimits(block) error = %v", err)
+	}
+	// Moves between statuses of the same column keep its count.
+	if err := issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: a, TargetStatusID: doing}); err != nil {
+		t.Fatalf("Move() within column error = %v", err)
+	}
+	if err := issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: a, TargetStatusID: todo}); err != nil {
+		t.Fatalf("Move() out of column error = %v", err)
+	}
+	err = issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: a, TargetStatusID: doing})
+	var wipErr *issues.WIPLimitError
+	if !errors.As(err, &wipErr) || wipErr.Limit != "max" || wipErr.Column != "In progress" || wipErr.Count != 2 {
+		t.Fatalf("Move() over blocking max error = %v, want a max WIPLimitError", err)
+	}
+
+	if err := issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: b, TargetStatusID: todo}); err != nil {
+		t.Fatalf("Move() down to the minimum error = %v", err)
+	}
+	err = issues.Move(ctx, db, issues.MoveParams{ProjectID: proj, IssueID: c, TargetStatusID: todo})
+	if !errors.As(err, &wipErr) || wipErr.Limit != "min" || wipErr.Count != 1 {
+		t.Fatalf("Move() under blocking min error = %v, want a min WIPLimitError", err)
+	}
+}
+
+func TestGetBoardView_Swimlanes(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	proj, todo := seedProjectWithStatus(t, db)
+	var issueType, tagsField string
+	if err := db.GetContext(ctx, &issueType,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	if err := db.GetContext(ctx, &tagsField,
+		`INSERT INTO custom_fields (project_id, name, field_type, options) VALUES ($1, 'Tags', 'multi_select', '{a,b}') RETURNING id`, proj,
+	); err != nil {
+		t.Fatalf("seed custom field: %v", err)
+	}
+	reporter := testpg.SeedUser(t, db)
+	assignee := testpg.SeedUser(t, db)
+	var assigneeName string
+	if err := db.GetContext(ctx, &assigneeName, `SELECT name FROM app_users WHERE id = $1`, assignee); err != nil {
+		t.Fatalf("load assignee: %v", err)
+	}
+	insert := func(number int, assigneeID *string) string {
+		t.Helper()
+		var id string
+		if err := db.GetContext(ctx, &id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, assignee_id, status_position)
+			 VALUES ($1, $2, $3, $4, 'Issue', '', 'medium', $5, $6, $2)
+			 RETURNING id`,
+			proj, number, issueType, todo, reporter, assigneeID,
+		); err != nil {
+			t.Fatalf("insert issue: %v", err)
+		}
+		return id
+	}
+	unassigned := insert(1, nil)
+	assigned := insert(2, &assignee)
+
+	board, err := Create(ctx, db, CreateParams{ProjectID: proj, Name: "Board", Type: "kanban"})
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	open, err := AddColumn(ctx, db, AddColumnParams{BoardID: board.ID, Name: "Open"})
+	if err != nil {
+		t.Fatalf("add column: %v", err)
+	}
+	if err := AssignStatus(ctx, db, open.ID, todo); err != nil {
+		t.Fatalf("assign status: %v", err)
+	}
+
+	view, err := GetView(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	if view.Board.SwimlaneBy != SwimlaneNone || view.Lanes != nil {
+		t.Fatalf("default board: got swimlane_by %q and %d lanes, want none", view.Board.SwimlaneBy, len(view.Lanes))
+	}
+
+	if _, err := SetSwimlanes(ctx, db, SetSwimlanesParams{BoardID: board.ID, By: SwimlaneCustomField, FieldID: tagsField}); !errors.Is(err, ErrInvalidSwimlaneField) {
+		t.Fatalf("SetSwimlanes(multi select) error = %v, want %v", err, ErrInvalidSwimlaneField)
+	}
+	if _, err := SetSwimlanes(ctx, db, SetSwimlanesParams{BoardID: board.ID, By: SwimlaneAssignee}); err != nil {
+		t.Fatalf("SetSwimlanes(assignee) error = %v", err)
+	}
+	if _, err := SaveLanes(ctx, db, SaveLanesParams{BoardID: board.ID, Lanes: []LaneState{{Key: "", Collapsed: true}}}); err != nil {
+		t.Fatalf("SaveLanes() error = %v", err)
+	}
+
+	view, err = GetView(ctx, db, board.ID, reporter)
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	if len(view.Lanes) != 2 {
+		t.Fatalf("lanes: got %+v, want 2", view.Lanes)
+	}
+	first, second := view.Lanes[0], view.Lanes[1]
+	if first.Key != "" || first.Label != "Unassigned" || !first.Collapsed || first.Columns[0].Issues[0].ID != unassigned {
+		t.Fatalf("saved lane first: got %+v", first)
+	}
+	if second.Key != assignee || second.Label != assigneeName || second.Collapsed || second.Columns[0].Issues[0].ID != assigned {
+		t.Fatalf("assignee lane: got %+v", second)
+	}
+
+	// Switching the grouping forgets the saved lanes.
+	if _, err := SetSwimlanes(ctx, db, SetSwimlanesParams{BoardID: board.ID, By: SwimlanePriority}); err != nil {
+		t.Fatalf("SetSwimlanes(priority) error = %v", err)
+	}
+	var saved int
+	if err := db.GetContext(ctx, &saved, `SELECT COUNT(*) FROM board_lanes WHERE board_id = $1`, board.ID); err != nil {
+		t.Fatalf("count board lanes: %v", err)
+	}
+	if saved != 0 {
+		t.Fatalf("board lanes after regrouping: got %d, want 0", saved)
+	}
+}
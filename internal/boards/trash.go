@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package boards
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RestoreParams restores an archived board. Name renames it on the way back,
+// for when an active board has taken its name meanwhile.
+type RestoreParams struct {
+	BoardID string
+	Name    string
+}
+
+func (params RestoreParams) Validate() error {
+	if params.BoardID == "" {
+		return errors.New("board_id is required")
+	}
+	return nil
+}
+
+// ListTrash returns the archived boards of a project, most recently archived first.
+func ListTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Board, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listTrash(ctx, db, projectID)
+}
+
+// Restore brings an archived board back with its columns and mappings. It
+// returns ErrDuplicateName when an active board already uses the name.
+func Restore(ctx context.Context, db *sqlx.DB, params RestoreParams) (Board, error) {
+	if db == nil {
+		return Board{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Board{}, err
+	}
+	return restoreBoard(ctx, db, params)
+}
+
+// PurgeArchived permanently deletes boards archived before the given time
+// and returns how many were deleted.
+func PurgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	if db == nil {
+		return 0, errors.New("db is required")
+	}
+	return purgeArchived(ctx, db, before)
+}
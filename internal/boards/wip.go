@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package boards
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	WIPWarn  = "warn"
+	WIPBlock = "block"
+)
+
+var validWIPModes = map[string]bool{WIPWarn: true, WIPBlock: true}
+
+// WIPStatus reports a column's issue count against its limits. The count
+// covers every active issue in the column's statuses, whatever the board
+// filter shows.
+type WIPStatus struct {
+	Count int  `json:"count"`
+	Over  bool `json:"over"`
+	Under bool `json:"under"`
+}
+
+func wipStatus(column Column, count int) WIPStatus {
+	return WIPStatus{
+		Count: count,
+		Over:  column.WIPMax != nil && count > *column.WIPMax,
+		Under: column.WIPMin != nil && count < *column.WIPMin,
+	}
+}
+
+// SetLimitsParams replaces the WIP limits of a column. A nil Min or Max
+// removes that limit; an empty Mode means warn.
+type SetLimitsParams struct {
+	ColumnID string
+	Min      *int
+	Max      *int
+	Mode     string
+}
+
+func (params SetLimitsParams) Validate() error {
+	if params.ColumnID == "" {
+		return errors.New("column_id is required")
+	}
+	if params.Min != nil && *params.Min < 0 {
+		return errors.New("wip_min must be >= 0")
+	}
+	if params.Max != nil && *params.Max <= 0 {
+		return errors.New("wip_max must be > 0")
+	}
+	if params.Min != nil && params.Max != nil && *params.Min > *params.Max {
+		return errors.New("wip_min must not exceed wip_max")
+	}
+	if params.Mode != "" && !validWIPModes[params.Mode] {
+		return errors.New("wip_mode must be 'warn' or 'block'")
+	}
+	return nil
+}
+
+// SetLimits sets the WIP limits of a column. Limits already broken when they
+// are set are reported by the board view; blocking limits then only reject
+// moves that make things worse.
+func SetLimits(ctx context.Context, db *sqlx.DB, params SetLimitsParams) (Column, error) {
+	if db == nil {
+		return Column{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Column{}, err
+	}
+	if params.Mode == "" {
+		params.Mode = WIPWarn
+	}
+	return setLimits(ctx, db, params)
+}
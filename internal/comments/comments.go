@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package comments
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound       = errors.New("comment not found")
+	ErrIssueNotFound  = errors.New("issue not found")
+	ErrParentNotFound = errors.New("parent comment not found")
+	ErrNotAuthor      = errors.New("only the author can change this comment")
+)
+
+var reMention = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+type Comment struct {
+	ID              string         `db:"id"                json:"id"`
+	IssueID         string         `db:"issue_id"          json:"issue_id"`
+	ParentCommentID *string        `db:"parent_comment_id" json:"parent_comment_id,omitempty"`
+	AuthorID        string         `db:"author_id"         json:"author_id"`
+	Body            string         `db:"body"              json:"body"`
+	MentionIDs      pq.StringArray `db:"mention_ids"       json:"mention_ids"`
+	EditedAt        *time.Time     `db:"edited_at"         json:"edited_at,omitempty"`
+	CreatedAt       time.Time      `db:"created_at"        json:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"        json:"updated_at"`
+	DeletedAt       *time.Time     `db:"deleted_at"        json:"deleted_at,omitempty"`
+}
+
+// Revision is a previous body of a comment, kept each time the comment is edited.
+type Revision struct {
+	ID        string    `db:"id"         json:"id"`
+	CommentID string    `db:"comment_id" json:"comment_id"`
+	Body      string    `db:"body"       json:"body"`
+	EditedBy  string    `db:"edited_by"  json:"edited_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type CreateParams struct {
+	ProjectID       string
+	IssueID         string
+	ParentCommentID string
+	AuthorID        string
+	Body            string
+}
+
+func (params CreateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.AuthorID == "" {
+		return errors.New("author_id is required")
+	}
+	if strings.TrimSpace(params.Body) == "" {
+		return errors.New("body is required")
+	}
+	return nil
+}
+
+type UpdateParams struct {
+	ProjectID string
+	IssueID   string
+	CommentID string
+	EditorID  string
+	Body      string
+}
+
+func (params UpdateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.CommentID == "" {
+		return errors.New("comment_id is required")
+	}
+	if params.EditorID == "" {
+		return errors.New("editor_id is required")
+	}
+	if strings.TrimSpace(params.Body) == "" {
+		return errors.New("body is required")
+	}
+	return nil
+}
+
+type DeleteParams struct {
+	ProjectID string
+	IssueID   string
+	CommentID string
+	ActorID   string
+	// Moderator lets workspace admins delete comments written by others.
+	Moderator bool
+}
+
+func (params DeleteParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.CommentID == "" {
+		return errors.New("comment_id is required")
+	}
+	if params.ActorID == "" {
+		return errors.New("actor_id is required")
+	}
+	return nil
+}
+
+func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Comment, error) {
+	if db == nil {
+		return Comment{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Comment{}, err
+	}
+	return createComment(ctx, db, params)
+}
+
+// List returns every comment on an issue in creation order. Threads are
+// expressed through ParentCommentID; deleted comments keep their place in the
+// thread with an empty body.
+func List(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Comment, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return nil, errors.New("issue_id is required")
+	}
+	return listComments(ctx, db, projectID, issueID)
+}
+
+func Update(ctx context.Context, db *sqlx.DB, params UpdateParams) (Comment, error) {
+	if db == nil {
+		return Comment{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Comment{}, err
+	}
+	return updateComment(ctx, db, params)
+}
+
+func Delete(ctx context.Context, db *sqlx.DB, params DeleteParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	return deleteComment(ctx, db, params)
+}
+
+func ListRevisions(ctx context.Context, db *sqlx.DB, projectID, issueID, commentID string) ([]Revision, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return nil, errors.New("issue_id is required")
+	}
+	if commentID == "" {
+		return nil, errors.New("comment_id is required")
+	}
+	return listRevisions(ctx, db, projectID, issueID, commentID)
+}
+
+// parseMentions extracts the distinct lowercased @handles from a comment body.
+// A handle matches a workspace member whose name without spaces, or whose
+// email local part, equals it case-insensitively.
+func parseMentions(body string) []string {
+	seen := map[string]bool{}
+	handles := []string{}
+	for _, m := range reMention.FindAllStringSubmatch(body, -1) {
+		handle := strings.ToLower(strings.TrimRight(m[1], "._-"))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
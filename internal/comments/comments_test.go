@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package comments
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestCreateCommentParams_Validate(t *testing.T) {
+	valid := CreateParams{ProjectID: "p", IssueID: "i", AuthorID: "u", Body: "Looks good"}
+
+	tests := []struct {
+		name    string
+		params  CreateParams
+		wantErr bool
+	}{
+		{name: "valid", params: valid, wantErr: false},
+		{name: "valid reply", params: func() CreateParams { c := valid; c.ParentCommentID = "c"; return c }(), wantErr: false},
+		{name: "missing project_id", params: func() CreateParams { c := valid; c.ProjectID = ""; return c }(), wantErr: true},
+		{name: "missing issue_id", params: func() CreateParams { c := valid; c.IssueID = ""; return c }(), wantErr: true},
+		{name: "missing author_id", params: func() CreateParams { c := valid; c.AuthorID = ""; return c }(), wantErr: true},
+		{name: "blank body", params: func() CreateParams { c := valid; c.Body = "  \n"; return c }(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateCommentParams_Validate(t *testing.T) {
+	valid := UpdateParams{ProjectID: "p", IssueID: "i", CommentID: "c", EditorID: "u", Body: "Edited"}
+
+	tests := []struct {
+		name    string
+		params  UpdateParams
+		wantErr bool
+	}{
+		{name: "valid", params: valid, wantErr: false},
+		{name: "missing comment_id", params: func() UpdateParams { c := valid; c.CommentID = ""; return c }(), wantErr: true},
+		{name: "missing editor_id", params: func() UpdateParams { c := valid; c.EditorID = ""; return c }(), wantErr: true},
+		{name: "blank body", params: func() UpdateParams { c := valid; c.Body = ""; return c }(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "no mentions", body: "plain text", want: []string{}},
+		{name: "single mention", body: "@ana please review", want: []string{"ana"}},
+		{name: "lowercases and dedupes", body: "@Ana and @ana and @JohnDoe", want: []string{"ana", "johndoe"}},
+		{name: "trailing punctuation", body: "thanks @bob.", want: []string{"bob"}},
+		{name: "ignores email addresses", body: "mail ana@example.com", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMentions(tt.body)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseMentions(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateComment_NilDB(t *testing.T) {
+	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p", IssueID: "i", AuthorID: "u", Body: "b"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Create() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListComments_NilDB(t *testing.T) {
+	_, err := List(context.Background(), nil, "p", "i")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("List() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestUpdateComment_NilDB(t *testing.T) {
+	_, err := Update(context.Background(), nil, UpdateParams{ProjectID: "p", IssueID: "i", CommentID: "c", EditorID: "u", Body: "b"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Update() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestDeleteComment_NilDB(t *testing.T) {
+	err := Delete(context.Background(), nil, DeleteParams{ProjectID: "p", IssueID: "i", CommentID: "c", ActorID: "u"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Delete() error = %v, want %q", err, "db is required")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package comments
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/respond"
+)
+
+func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/comments", handleCreate(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/comments", handleList(db))
+	mux.HandleFunc("PUT /projects/{projectID}/issues/{issueID}/comments/{commentID}", handleUpdate(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/issues/{issueID}/comments/{commentID}", handleDelete(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/comments/{commentID}/revisions", handleListRevisions(db))
+}
+
+func fail(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		respond.Error(w, http.StatusUnauthorized, "authentication required")
+	case errors.Is(err, authz.ErrForbidden), errors.Is(err, ErrNotAuthor):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, authz.ErrWorkspaceNotFound),
+		errors.Is(err, authz.ErrProjectNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrIssueNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrParentNotFound):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		slog.Error("comments handler error", "error", err)
+		respond.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func handleCreate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		authedUserID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			ParentCommentID string `json:"parent_comment_id"`
+			Body            string `json:"body"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CreateParams{
+			ProjectID:       r.PathValue("projectID"),
+			IssueID:         r.PathValue("issueID"),
+			ParentCommentID: body.ParentCommentID,
+			AuthorID:        authedUserID,
+			Body:            body.Body,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		comment, err := Create(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, comment)
+	}
+}
+
+func handleList(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := List(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleUpdate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		authedUserID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Body string `json:"body"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateParams{
+			ProjectID: r.PathValue("projectID"),
+			IssueID:   r.PathValue("issueID"),
+			CommentID: r.PathValue("commentID"),
+			EditorID:  authedUserID,
+			Body:      body.Body,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		comment, err := Update(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, comment)
+	}
+}
+
+func handleDelete(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		authedUserID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		moderator := true
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			if !errors.Is(err, authz.ErrForbidden) {
+				fail(w, err)
+				return
+			}
+			moderator = false
+		}
+		params := DeleteParams{
+			ProjectID: r.PathValue("projectID"),
+			IssueID:   r.PathValue("issueID"),
+			CommentID: r.PathValue("commentID"),
+			ActorID:   authedUserID,
+			Moderator: moderator,
+		}
+		if err := Delete(r.Context(), db, params); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleListRevisions(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListRevisions(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"), r.PathValue("commentID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
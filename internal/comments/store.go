@@ -0,0 +1,300 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/issues"
+	"github.com/start-codex/tookly/internal/pgutil"
+)
+
+const commentCols = `id, issue_id, parent_comment_id, author_id, body, edited_at, created_at, updated_at, deleted_at`
+
+const mentionIDsCol = `ARRAY(
+		SELECT m.user_id::text FROM issue_comment_mentions m
+		WHERE m.comment_id = c.id
+		ORDER BY m.user_id
+	) AS mention_ids`
+
+const revisionCols = `id, comment_id, body, edited_by, created_at`
+
+func createComment(ctx context.Context, db *sqlx.DB, params CreateParams) (Comment, error) {
+	var comment Comment
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit create comment", func(tx *sqlx.Tx) error {
+		var issueExists bool
+		if err := tx.GetContext(ctx, &issueExists,
+			`SELECT EXISTS(
+				SELECT 1 FROM issues
+				WHERE id = $1 AND project_id = $2 AND archived_at IS NULL
+			)`,
+			params.IssueID, params.ProjectID,
+		); err != nil {
+			return fmt.Errorf("check issue exists: %w", err)
+		}
+		if !issueExists {
+			return ErrIssueNotFound
+		}
+
+		var parentID *string
+		if params.ParentCommentID != "" {
+			var parentExists bool
+			if err := tx.GetContext(ctx, &parentExists,
+				`SELECT EXISTS(
+					SELECT 1 FROM issue_comments
+					WHERE id = $1 AND issue_id = $2 AND deleted_at IS NULL
+				)`,
+				params.ParentCommentID, params.IssueID,
+			); err != nil {
+				return fmt.Errorf("check parent comment exists: %w", err)
+			}
+			if !parentExists {
+				return ErrParentNotFound
+			}
+			parentID = &params.ParentCommentID
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`INSERT INTO issue_comments (issue_id, parent_comment_id, author_id, body)
+			 VALUES ($1, $2, $3, $4)
+			 RETURNING `+commentCols,
+			params.IssueID, parentID, params.AuthorID, params.Body,
+		).StructScan(&comment); err != nil {
+			return fmt.Errorf("insert comment: %w", err)
+		}
+
+		mentionIDs, err := saveMentions(ctx, tx, comment.ID, params.ProjectID, parseMentions(params.Body))
+		if err != nil {
+			return err
+		}
+		comment.MentionIDs = mentionIDs
+
+		return issues.RecordEvent(ctx, tx, params.IssueID, params.AuthorID, issues.EventCommented, map[string]any{
+			"action":     "created",
+			"comment_id": comment.ID,
+		})
+	}); err != nil {
+		return Comment{}, err
+	}
+	return comment, nil
+}
+
+func listComments(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Comment, error) {
+	var issueExists bool
+	if err := db.GetContext(ctx, &issueExists,
+		`SELECT EXISTS(SELECT 1 FROM issues WHERE id = $1 AND project_id = $2)`,
+		issueID, projectID,
+	); err != nil {
+		return nil, fmt.Errorf("check issue exists: %w", err)
+	}
+	if !issueExists {
+		return nil, ErrIssueNotFound
+	}
+
+	comments := []Comment{}
+	if err := db.SelectContext(ctx, &comments,
+		`SELECT `+commentCols+`, `+mentionIDsCol+`
+		 FROM issue_comments c
+		 WHERE issue_id = $1
+		 ORDER BY created_at ASC, id ASC`,
+		issueID,
+	); err != nil {
+		return nil, fmt.Errorf("list comments: %w", err)
+	}
+	for i := range comments {
+		if comments[i].DeletedAt != nil {
+			comments[i].Body = ""
+		}
+		if comments[i].MentionIDs == nil {
+			comments[i].MentionIDs = pq.StringArray{}
+		}
+	}
+	return comments, nil
+}
+
+type lockedComment struct {
+	AuthorID string `db:"author_id"`
+	Body     string `db:"body"`
+}
+
+// lockComment loads an active comment for update, scoped to its issue and project.
+func lockComment(ctx context.Context, tx *sqlx.Tx, projectID, issueID, commentID string) (lockedComment, error) {
+	var locked lockedComment
+	err := tx.GetContext(ctx, &locked,
+		`SELECT c.author_id, c.body
+		 FROM issue_comments c
+		 JOIN issues i ON i.id = c.issue_id
+		 WHERE c.id = $1
+		   AND c.issue_id = $2
+		   AND i.project_id = $3
+		   AND c.deleted_at IS NULL
+		 FOR UPDATE OF c`,
+		commentID, issueID, projectID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return lockedComment{}, ErrNotFound
+		}
+		return lockedComment{}, fmt.Errorf("load comment for update: %w", err)
+	}
+	return locked, nil
+}
+
+func updateComment(ctx context.Context, db *sqlx.DB, params UpdateParams) (Comment, error) {
+	var comment Comment
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit update comment", func(tx *sqlx.Tx) error {
+		current, err := lockComment(ctx, tx, params.ProjectID, params.IssueID, params.CommentID)
+		if err != nil {
+			return err
+		}
+		if current.AuthorID != params.EditorID {
+			return ErrNotAuthor
+		}
+
+		if current.Body == params.Body {
+			if err := tx.GetContext(ctx, &comment,
+				`SELECT `+commentCols+`, `+mentionIDsCol+`
+				 FROM issue_comments c
+				 WHERE id = $1`,
+				params.CommentID,
+			); err != nil {
+				return fmt.Errorf("get comment: %w", err)
+			}
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO issue_comment_revisions (comment_id, body, edited_by)
+			 VALUES ($1, $2, $3)`,
+			params.CommentID, current.Body, params.EditorID,
+		); err != nil {
+			return fmt.Errorf("insert comment revision: %w", err)
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE issue_comments
+			 SET body      = $1,
+			     edited_at = NOW()
+			 WHERE id = $2
+			 RETURNING `+commentCols,
+			params.Body, params.CommentID,
+		).StructScan(&comment); err != nil {
+			return fmt.Errorf("update comment: %w", err)
+		}
+
+		mentionIDs, err := saveMentions(ctx, tx, comment.ID, params.ProjectID, parseMentions(params.Body))
+		if err != nil {
+			return err
+		}
+		comment.MentionIDs = mentionIDs
+
+		return issues.RecordEvent(ctx, tx, params.IssueID, params.EditorID, issues.EventCommented, map[string]any{
+			"action":     "edited",
+			"comment_id": comment.ID,
+		})
+	}); err != nil {
+		return Comment{}, err
+	}
+	if comment.MentionIDs == nil {
+		comment.MentionIDs = pq.StringArray{}
+	}
+	return comment, nil
+}
+
+func deleteComment(ctx context.Context, db *sqlx.DB, params DeleteParams) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit delete comment", func(tx *sqlx.Tx) error {
+		current, err := lockComment(ctx, tx, params.ProjectID, params.IssueID, params.CommentID)
+		if err != nil {
+			return err
+		}
+		if current.AuthorID != params.ActorID && !params.Moderator {
+			return ErrNotAuthor
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE issue_comments SET deleted_at = NOW() WHERE id = $1`,
+			params.CommentID,
+		); err != nil {
+			return fmt.Errorf("delete comment: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM issue_comment_mentions WHERE comment_id = $1`,
+			params.CommentID,
+		); err != nil {
+			return fmt.Errorf("clear comment mentions: %w", err)
+		}
+
+		return issues.RecordEvent(ctx, tx, params.IssueID, params.ActorID, issues.EventCommented, map[string]any{
+			"action":     "deleted",
+			"comment_id": params.CommentID,
+		})
+	})
+}
+
+func listRevisions(ctx context.Context, db *sqlx.DB, projectID, issueID, commentID string) ([]Revision, error) {
+	var commentExists bool
+	if err := db.GetContext(ctx, &commentExists,
+		`SELECT EXISTS(
+			SELECT 1 FROM issue_comments c
+			JOIN issues i ON i.id = c.issue_id
+			WHERE c.id = $1 AND c.issue_id = $2 AND i.project_id = $3 AND c.deleted_at IS NULL
+		)`,
+		commentID, issueID, projectID,
+	); err != nil {
+		return nil, fmt.Errorf("check comment exists: %w", err)
+	}
+	if !commentExists {
+		return nil, ErrNotFound
+	}
+
+	revisions := []Revision{}
+	if err := db.SelectContext(ctx, &revisions,
+		`SELECT `+revisionCols+`
+		 FROM issue_comment_revisions
+		 WHERE comment_id = $1
+		 ORDER BY created_at DESC, id DESC`,
+		commentID,
+	); err != nil {
+		return nil, fmt.Errorf("list comment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// saveMentions replaces the mentions of a comment with the active workspace
+// members matching handles, and returns their user IDs.
+func saveMentions(ctx context.Context, tx *sqlx.Tx, commentID, projectID string, handles []string) (pq.StringArray, error) {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM issue_comment_mentions WHERE comment_id = $1`,
+		commentID,
+	); err != nil {
+		return nil, fmt.Errorf("clear comment mentions: %w", err)
+	}
+	mentionIDs := []string{}
+	if len(handles) == 0 {
+		return mentionIDs, nil
+	}
+	if err := tx.SelectContext(ctx, &mentionIDs,
+		`INSERT INTO issue_comment_mentions (comment_id, user_id)
+		 SELECT DISTINCT $1::uuid, u.id
+		 FROM app_users u
+		 JOIN workspace_members wm ON wm.user_id = u.id AND wm.archived_at IS NULL
+		 JOIN projects p ON p.workspace_id = wm.workspace_id
+		 WHERE p.id = $2
+		   AND u.archived_at IS NULL
+		   AND (lower(replace(u.name, ' ', '')) = ANY($3)
+		        OR lower(split_part(u.email, '@', 1)) = ANY($3))
+		 RETURNING user_id::text`,
+		commentID, projectID, pq.Array(handles),
+	); err != nil {
+		return nil, fmt.Errorf("insert comment mentions: %w", err)
+	}
+	return mentionIDs, nil
+}
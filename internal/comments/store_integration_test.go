@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package comments
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+type commentSeed struct {
+	projectID string
+	issueID   string
+	authorID  string
+	otherID   string
+	otherName string
+}
+
+// seedIssue creates a workspace with two members, a project and one issue.
+func seedIssue(t *testing.T, db *sqlx.DB) commentSeed {
+	t.Helper()
+	ctx := context.Background()
+	ws := testpg.SeedWorkspace(t, db)
+	out := commentSeed{
+		authorID: testpg.SeedUser(t, db),
+		otherID:  testpg.SeedUser(t, db),
+	}
+	for _, userID := range []string{out.authorID, out.otherID} {
+		if _, err := db.ExecContext(ctx,
+			`INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, 'member')`,
+			ws, userID,
+		); err != nil {
+			t.Fatalf("seed member: %v", err)
+		}
+	}
+	if err := db.GetContext(ctx, &out.otherName, `SELECT name FROM app_users WHERE id = $1`, out.otherID); err != nil {
+		t.Fatalf("load user name: %v", err)
+	}
+	out.projectID = testpg.SeedProject(t, db, ws, "CMT")
+
+	var typeID, statusID string
+	if err := db.GetContext(ctx, &typeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, out.projectID); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	if err := db.GetContext(ctx, &statusID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'To Do', 'todo', 0) RETURNING id`, out.projectID); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.GetContext(ctx, &out.issueID,
+		`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, reporter_id)
+		 VALUES ($1, 1, $2, $3, 'Issue', $4) RETURNING id`,
+		out.projectID, typeID, statusID, out.authorID,
+	); err != nil {
+		t.Fatalf("seed issue: %v", err)
+	}
+	return out
+}
+
+func TestCreateComment(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+	seed := seedIssue(t, db)
+
+	// Mention handles are the member name without spaces ("Test User abcd1234" -> "testuserabcd1234").
+	handle := "@" + strings.ReplaceAll(seed.otherName, " ", "")
+	root, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, AuthorID: seed.authorID,
+		Body: "Can you check this " + handle + "?",
+	})
+	if err != nil {
+		t.Fatalf("create root: %v", err)
+	}
+	if len(root.MentionIDs) != 1 || root.MentionIDs[0] != seed.otherID {
+		t.Fatalf("mention_ids: got %v, want [%s]", root.MentionIDs, seed.otherID)
+	}
+
+	reply, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, AuthorID: seed.otherID,
+		ParentCommentID: root.ID, Body: "On it",
+	})
+	if err != nil {
+		t.Fatalf("create reply: %v", err)
+	}
+	if reply.ParentCommentID == nil || *reply.ParentCommentID != root.ID {
+		t.Fatalf("parent_comment_id: got %v, want %s", reply.ParentCommentID, root.ID)
+	}
+
+	_, err = Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, AuthorID: seed.authorID,
+		ParentCommentID: "00000000-0000-0000-0000-000000000000", Body: "orphan",
+	})
+	if !errors.Is(err, ErrParentNotFound) {
+		t.Fatalf("unknown parent: error = %v, want %v", err, ErrParentNotFound)
+	}
+
+	var commented int
+	if err := db.GetContext(ctx, &commented,
+		`SELECT COUNT(*) FROM issue_events WHERE issue_id = $1 AND event_type = 'commented'`,
+		seed.issueID,
+	); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if commented != 2 {
+		t.Fatalf("commented events: got %d, want 2", commented)
+	}
+}
+
+func TestUpdateAndDeleteComment(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+	seed := seedIssue(t, db)
+
+	comment, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, AuthorID: seed.authorID, Body: "first",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	_, err = Update(ctx, db, UpdateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, CommentID: comment.ID, EditorID: seed.otherID, Body: "hijack",
+	})
+	if !errors.Is(err, ErrNotAuthor) {
+		t.Fatalf("edit by non-author: error = %v, want %v", err, ErrNotAuthor)
+	}
+
+	edited, err := Update(ctx, db, UpdateParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, CommentID: comment.ID, EditorID: seed.authorID, Body: "second",
+	})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if edited.Body != "second" || edited.EditedAt == nil {
+		t.Fatalf("edited comment: body=%q edited_at=%v", edited.Body, edited.EditedAt)
+	}
+
+	revisions, err := ListRevisions(ctx, db, seed.projectID, seed.issueID, comment.ID)
+	if err != nil {
+		t.Fatalf("list revisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Body != "first" {
+		t.Fatalf("revisions: got %v, want one revision with body %q", revisions, "first")
+	}
+
+	err = Delete(ctx, db, DeleteParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, CommentID: comment.ID, ActorID: seed.otherID,
+	})
+	if !errors.Is(err, ErrNotAuthor) {
+		t.Fatalf("delete by non-author: error = %v, want %v", err, ErrNotAuthor)
+	}
+	if err := Delete(ctx, db, DeleteParams{
+		ProjectID: seed.projectID, IssueID: seed.issueID, CommentID: comment.ID, ActorID: seed.otherID, Moderator: true,
+	}); err != nil {
+		t.Fatalf("delete as moderator: %v", err)
+	}
+
+	list, err := List(ctx, db, seed.projectID, seed.issueID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 1 || list[0].DeletedAt == nil || list[0].Body != "" {
+		t.Fatalf("deleted comment should stay in the thread with an empty body, got %+v", list)
+	}
+}
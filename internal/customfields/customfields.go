@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package customfields
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	TypeText         = "text"
+	TypeNumber       = "number"
+	TypeDate         = "date"
+	TypeSingleSelect = "single_select"
+	TypeMultiSelect  = "multi_select"
+	TypeUser         = "user"
+)
+
+var (
+	ErrNotFound          = errors.New("custom field not found")
+	ErrDuplicate         = errors.New("custom field name already exists in project")
+	ErrIssueTypeNotFound = errors.New("issue type not found in project")
+	ErrOptionInUse       = errors.New("option is still set on issues")
+	ErrInvalidOptions    = errors.New("options are required for select fields and not allowed on other fields")
+	ErrInvalidValue      = errors.New("invalid custom field value")
+)
+
+var validTypes = map[string]bool{
+	TypeText: true, TypeNumber: true, TypeDate: true,
+	TypeSingleSelect: true, TypeMultiSelect: true, TypeUser: true,
+}
+
+// Field is a custom field definition. A field with no IssueTypeIDs applies to
+// every issue type of its project.
+type Field struct {
+	ID           string         `db:"id"             json:"id"`
+	ProjectID    string         `db:"project_id"     json:"project_id"`
+	Name         string         `db:"name"           json:"name"`
+	Type         string         `db:"field_type"     json:"type"`
+	Options      pq.StringArray `db:"options"        json:"options"`
+	IssueTypeIDs pq.StringArray `db:"issue_type_ids" json:"issue_type_ids"`
+	CreatedAt    time.Time      `db:"created_at"     json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"     json:"updated_at"`
+	ArchivedAt   *time.Time     `db:"archived_at"    json:"archived_at,omitempty"`
+}
+
+// AppliesTo reports whether issues of the given type can carry the field.
+func (f Field) AppliesTo(issueTypeID string) bool {
+	return len(f.IssueTypeIDs) == 0 || slices.Contains(f.IssueTypeIDs, issueTypeID)
+}
+
+type CreateParams struct {
+	ProjectID    string
+	Name         string
+	Type         string
+	Options      []string
+	IssueTypeIDs []string
+}
+
+func (params CreateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return errors.New("name is required")
+	}
+	if !validTypes[params.Type] {
+		return errors.New("type must be 'text', 'number', 'date', 'single_select', 'multi_select' or 'user'")
+	}
+	if isSelect(params.Type) != (len(params.Options) > 0) {
+		return ErrInvalidOptions
+	}
+	return validateOptions(params.Options, params.IssueTypeIDs)
+}
+
+// UpdateParams replaces the name, options and issue types of a field. The
+// type of a field cannot change once issues may hold values for it.
+type UpdateParams struct {
+	FieldID      string
+	ProjectID    string
+	Name         string
+	Options      []string
+	IssueTypeIDs []string
+}
+
+func (params UpdateParams) Validate() error {
+	if params.FieldID == "" {
+		return errors.New("field_id is required")
+	}
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return errors.New("name is required")
+	}
+	return validateOptions(params.Options, params.IssueTypeIDs)
+}
+
+func isSelect(fieldType string) bool {
+	return fieldType == TypeSingleSelect || fieldType == TypeMultiSelect
+}
+
+func validateOptions(options, issueTypeIDs []string) error {
+	seen := map[string]bool{}
+	for _, option := range options {
+		if strings.TrimSpace(option) == "" {
+			return errors.New("options must not be blank")
+		}
+		if seen[option] {
+			return errors.New("options must be unique")
+		}
+		seen[option] = true
+	}
+	for _, id := range issueTypeIDs {
+		if id == "" {
+			return errors.New("issue_type_ids must not contain empty values")
+		}
+	}
+	return nil
+}
+
+func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Field, error) {
+	if db == nil {
+		return Field{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Field{}, err
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	return createField(ctx, db, params)
+}
+
+func List(ctx context.Context, db *sqlx.DB, projectID string) ([]Field, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listFields(ctx, db, projectID)
+}
+
+// Update changes a field definition. Options still set on issues cannot be
+// removed (ErrOptionInUse).
+func Update(ctx context.Context, db *sqlx.DB, params UpdateParams) (Field, error) {
+	if db == nil {
+		return Field{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Field{}, err
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	return updateField(ctx, db, params)
+}
+
+// Archive hides a field and its values from issues. Values are kept.
+func Archive(ctx context.Context, db *sqlx.DB, projectID, fieldID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if fieldID == "" {
+		return errors.New("field_id is required")
+	}
+	return archiveField(ctx, db, projectID, fieldID)
+}
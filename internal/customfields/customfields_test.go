@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package customfields
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCreateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CreateParams
+		wantErr bool
+	}{
+		{name: "valid text", params: CreateParams{ProjectID: "p", Name: "Customer", Type: TypeText}, wantErr: false},
+		{name: "valid select", params: CreateParams{ProjectID: "p", Name: "Env", Type: TypeSingleSelect, Options: []string{"prod", "staging"}}, wantErr: false},
+		{name: "valid with issue types", params: CreateParams{ProjectID: "p", Name: "Points", Type: TypeNumber, IssueTypeIDs: []string{"t"}}, wantErr: false},
+		{name: "missing project_id", params: CreateParams{Name: "Customer", Type: TypeText}, wantErr: true},
+		{name: "blank name", params: CreateParams{ProjectID: "p", Name: " ", Type: TypeText}, wantErr: true},
+		{name: "unknown type", params: CreateParams{ProjectID: "p", Name: "Customer", Type: "checkbox"}, wantErr: true},
+		{name: "select without options", params: CreateParams{ProjectID: "p", Name: "Env", Type: TypeMultiSelect}, wantErr: true},
+		{name: "options on text", params: CreateParams{ProjectID: "p", Name: "Customer", Type: TypeText, Options: []string{"a"}}, wantErr: true},
+		{name: "duplicate option", params: CreateParams{ProjectID: "p", Name: "Env", Type: TypeSingleSelect, Options: []string{"prod", "prod"}}, wantErr: true},
+		{name: "blank option", params: CreateParams{ProjectID: "p", Name: "Env", Type: TypeSingleSelect, Options: []string{""}}, wantErr: true},
+		{name: "empty issue type", params: CreateParams{ProjectID: "p", Name: "Customer", Type: TypeText, IssueTypeIDs: []string{""}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  UpdateParams
+		wantErr bool
+	}{
+		{name: "valid", params: UpdateParams{FieldID: "f", ProjectID: "p", Name: "Env", Options: []string{"prod"}}, wantErr: false},
+		{name: "missing field_id", params: UpdateParams{ProjectID: "p", Name: "Env"}, wantErr: true},
+		{name: "missing project_id", params: UpdateParams{FieldID: "f", Name: "Env"}, wantErr: true},
+		{name: "blank name", params: UpdateParams{FieldID: "f", ProjectID: "p"}, wantErr: true},
+		{name: "duplicate option", params: UpdateParams{FieldID: "f", ProjectID: "p", Name: "Env", Options: []string{"a", "a"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestField_AppliesTo(t *testing.T) {
+	all := Field{}
+	if !all.AppliesTo("t1") {
+		t.Fatal("field without issue types should apply to every type")
+	}
+	limited := Field{IssueTypeIDs: []string{"t1"}}
+	if !limited.AppliesTo("t1") || limited.AppliesTo("t2") {
+		t.Fatalf("AppliesTo() mismatch for %v", limited.IssueTypeIDs)
+	}
+}
+
+func TestField_Normalize(t *testing.T) {
+	selectOptions := []string{"low", "mid", "high"}
+	tests := []struct {
+		name    string
+		field   Field
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "null clears", field: Field{Type: TypeText}, raw: `null`, want: ""},
+		{name: "text", field: Field{Type: TypeText}, raw: `"Acme"`, want: `"Acme"`},
+		{name: "blank text clears", field: Field{Type: TypeText}, raw: `"  "`, want: ""},
+		{name: "text not a string", field: Field{Type: TypeText}, raw: `12`, wantErr: true},
+		{name: "number", field: Field{Type: TypeNumber}, raw: `3.50`, want: `3.50`},
+		{name: "quoted number", field: Field{Type: TypeNumber}, raw: `"3"`, wantErr: true},
+		{name: "date", field: Field{Type: TypeDate}, raw: `"2025-03-01"`, want: `"2025-03-01"`},
+		{name: "bad date", field: Field{Type: TypeDate}, raw: `"01/03/2025"`, wantErr: true},
+		{name: "single select", field: Field{Type: TypeSingleSelect, Options: selectOptions}, raw: `"mid"`, want: `"mid"`},
+		{name: "unknown option", field: Field{Type: TypeSingleSelect, Options: selectOptions}, raw: `"max"`, wantErr: true},
+		{name: "multi select ordered", field: Field{Type: TypeMultiSelect, Options: selectOptions}, raw: `["high","low","high"]`, want: `["low","high"]`},
+		{name: "empty multi select clears", field: Field{Type: TypeMultiSelect, Options: selectOptions}, raw: `[]`, want: ""},
+		{name: "multi select unknown option", field: Field{Type: TypeMultiSelect, Options: selectOptions}, raw: `["max"]`, wantErr: true},
+		{name: "user", field: Field{Type: TypeUser}, raw: `"3F2504E0-4F89-11D3-9A0C-0305E82C3301"`, want: `"3f2504e0-4f89-11d3-9a0c-0305e82c3301"`},
+		{name: "user not an id", field: Field{Type: TypeUser}, raw: `"alice"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.Normalize(json.RawMessage(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidValue) {
+					t.Fatalf("Normalize() error = %v, want ErrInvalidValue", err)
+				}
+				return
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Normalize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_ParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   Field
+		values  []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "text", field: Field{Type: TypeText}, values: []string{"acme"}, want: []string{"acme"}},
+		{name: "blank text", field: Field{Type: TypeText}, values: []string{" "}, wantErr: true},
+		{name: "number", field: Field{Type: TypeNumber}, values: []string{"3", "5.5"}, want: []string{"3", "5.5"}},
+		{name: "bad number", field: Field{Type: TypeNumber}, values: []string{"three"}, wantErr: true},
+		{name: "bad date", field: Field{Type: TypeDate}, values: []string{"2025-13-01"}, wantErr: true},
+		{name: "select option", field: Field{Type: TypeMultiSelect, Options: []string{"a", "b"}}, values: []string{"b"}, want: []string{"b"}},
+		{name: "unknown option", field: Field{Type: TypeSingleSelect, Options: []string{"a"}}, values: []string{"c"}, wantErr: true},
+		{name: "user lowercased", field: Field{Type: TypeUser}, values: []string{"3F2504E0-4F89-11D3-9A0C-0305E82C3301"}, want: []string{"3f2504e0-4f89-11d3-9a0c-0305e82c3301"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.ParseFilter(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !slices.Equal(got, tt.want) {
+				t.Fatalf("ParseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreate_NilDB(t *testing.T) {
+	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p", Name: "Customer", Type: TypeText})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Create() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestList_NilDB(t *testing.T) {
+	_, err := List(context.Background(), nil, "p")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("List() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestUpdate_NilDB(t *testing.T) {
+	_, err := Update(context.Background(), nil, UpdateParams{FieldID: "f", ProjectID: "p", Name: "Customer"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Update() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestArchive_NilDB(t *testing.T) {
+	err := Archive(context.Background(), nil, "p", "f")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Archive() error = %v, want %q", err, "db is required")
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package customfields
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/pgutil"
+)
+
+// FieldCols selects a Field from custom_fields aliased as f. It is exported
+// so that the issues package can load definitions inside its transactions.
+const FieldCols = `f.id, f.project_id, f.name, f.field_type, f.options,
+	ARRAY(SELECT ft.issue_type_id::text
+	      FROM custom_field_issue_types ft
+	      WHERE ft.field_id = f.id
+	      ORDER BY ft.issue_type_id) AS issue_type_ids,
+	f.created_at, f.updated_at, f.archived_at`
+
+func createField(ctx context.Context, db *sqlx.DB, params CreateParams) (Field, error) {
+	var field Field
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit create custom field", func(tx *sqlx.Tx) error {
+		var fieldID string
+		if err := tx.GetContext(ctx, &fieldID,
+			`INSERT INTO custom_fields (project_id, name, field_type, options)
+			 VALUES ($1, $2, $3, $4)
+			 RETURNING id`,
+			params.ProjectID, params.Name, params.Type, pq.Array(nonNil(params.Options)),
+		); err != nil {
+			if pgutil.IsUniqueViolation(err) {
+				return ErrDuplicate
+			}
+			return fmt.Errorf("create custom field: %w", err)
+		}
+		if err := setIssueTypes(ctx, tx, params.ProjectID, fieldID, params.IssueTypeIDs); err != nil {
+			return err
+		}
+		var err error
+		field, err = getField(ctx, tx, params.ProjectID, fieldID, false)
+		return err
+	}); err != nil {
+		return Field{}, err
+	}
+	return field, nil
+}
+
+func listFields(ctx context.Context, db *sqlx.DB, projectID string) ([]Field, error) {
+	fields := []Field{}
+	if err := db.SelectContext(ctx, &fields,
+		`SELECT `+FieldCols+`
+		 FROM custom_fields f
+		 WHERE f.project_id = $1
+		   AND f.archived_at IS NULL
+		 ORDER BY lower(f.name) ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list custom fields: %w", err)
+	}
+	return fields, nil
+}
+
+func getField(ctx context.Context, tx *sqlx.Tx, projectID, fieldID string, forUpdate bool) (Field, error) {
+	query := `SELECT ` + FieldCols + `
+		 FROM custom_fields f
+		 WHERE f.id = $1
+		   AND f.project_id = $2
+		   AND f.archived_at IS NULL`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	var field Field
+	if err := tx.GetContext(ctx, &field, query, fieldID, projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Field{}, ErrNotFound
+		}
+		return Field{}, fmt.Errorf("get custom field: %w", err)
+	}
+	return field, nil
+}
+
+func updateField(ctx context.Context, db *sqlx.DB, params UpdateParams) (Field, error) {
+	var field Field
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit update custom field", func(tx *sqlx.Tx) error {
+		current, err := getField(ctx, tx, params.ProjectID, params.FieldID, true)
+		if err != nil {
+			return err
+		}
+		if isSelect(current.Type) != (len(params.Options) > 0) {
+			return ErrInvalidOptions
+		}
+		removed := []string{}
+		for _, option := range current.Options {
+			if !slices.Contains(params.Options, option) {
+				removed = append(removed, option)
+			}
+		}
+		if len(removed) > 0 {
+			inUse, err := optionsInUse(ctx, tx, current, removed)
+			if err != nil {
+				return err
+			}
+			if inUse {
+				return ErrOptionInUse
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE custom_fields
+			 SET name    = $1,
+			     options = $2
+			 WHERE id = $3`,
+			params.Name, pq.Array(nonNil(params.Options)), params.FieldID,
+		); err != nil {
+			if pgutil.IsUniqueViolation(err) {
+				return ErrDuplicate
+			}
+			return fmt.Errorf("update custom field: %w", err)
+		}
+		if err := setIssueTypes(ctx, tx, params.ProjectID, params.FieldID, params.IssueTypeIDs); err != nil {
+			return err
+		}
+		field, err = getField(ctx, tx, params.ProjectID, params.FieldID, false)
+		return err
+	}); err != nil {
+		return Field{}, err
+	}
+	return field, nil
+}
+
+func optionsInUse(ctx context.Context, tx *sqlx.Tx, field Field, options []string) (bool, error) {
+	match := `v.value #>> '{}' = ANY($2)`
+	if field.Type == TypeMultiSelect {
+		match = `EXISTS (SELECT 1 FROM jsonb_array_elements_text(v.value) e WHERE e = ANY($2))`
+	}
+	var inUse bool
+	if err := tx.GetContext(ctx, &inUse,
+		`SELECT EXISTS(
+			SELECT 1
+			FROM issue_field_values v
+			WHERE v.field_id = $1
+			  AND `+match+`
+		)`,
+		field.ID, pq.Array(options),
+	); err != nil {
+		return false, fmt.Errorf("check custom field options in use: %w", err)
+	}
+	return inUse, nil
+}
+
+// setIssueTypes replaces the issue types a field is limited to.
+func setIssueTypes(ctx context.Context, tx *sqlx.Tx, projectID, fieldID string, issueTypeIDs []string) error {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM custom_field_issue_types WHERE field_id = $1`,
+		fieldID,
+	); err != nil {
+		return fmt.Errorf("clear custom field issue types: %w", err)
+	}
+	if len(issueTypeIDs) == 0 {
+		return nil
+	}
+	ids := slices.Compact(slices.Sorted(slices.Values(issueTypeIDs)))
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO custom_field_issue_types (field_id, issue_type_id)
+		 SELECT $1, it.id
+		 FROM issue_types it
+		 WHERE it.project_id = $2
+		   AND it.archived_at IS NULL
+		   AND it.id = ANY($3::uuid[])`,
+		fieldID, projectID, pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("set custom field issue types: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set custom field issue types rows affected: %w", err)
+	}
+	if n != int64(len(ids)) {
+		return ErrIssueTypeNotFound
+	}
+	return nil
+}
+
+func archiveField(ctx context.Context, db *sqlx.DB, projectID, fieldID string) error {
+	res, err := db.ExecContext(ctx,
+		`UPDATE custom_fields
+		 SET archived_at = NOW()
+		 WHERE id         = $1
+		   AND project_id = $2
+		   AND archived_at IS NULL`,
+		fieldID, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("archive custom field: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("archive custom field rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
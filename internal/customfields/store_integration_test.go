@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package customfields
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+func TestCustomFields(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := testpg.SeedWorkspace(t, db)
+	projectID := testpg.SeedProject(t, db, ws, "CFD")
+	otherProjectID := testpg.SeedProject(t, db, ws, "CFO")
+	reporterID := testpg.SeedUser(t, db)
+	var statusID, typeID, otherTypeID string
+	if err := db.Get(&statusID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'To Do', 'todo', 0) RETURNING id`, projectID); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.Get(&typeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Bug', 1) RETURNING id`, projectID); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	if err := db.Get(&otherTypeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Bug', 1) RETURNING id`, otherProjectID); err != nil {
+		t.Fatalf("seed other issue type: %v", err)
+	}
+
+	env, err := Create(ctx, db, CreateParams{
+		ProjectID: projectID, Name: " Environment ", Type: TypeSingleSelect,
+		Options: []string{"prod", "staging"}, IssueTypeIDs: []string{typeID},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if env.Name != "Environment" || !slices.Equal(env.IssueTypeIDs, []string{typeID}) {
+		t.Fatalf("created field: got %+v", env)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "environment", Type: TypeText}); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Create() duplicate error = %v, want ErrDuplicate", err)
+	}
+	_, err = Create(ctx, db, CreateParams{ProjectID: projectID, Name: "Severity", Type: TypeNumber, IssueTypeIDs: []string{otherTypeID}})
+	if !errors.Is(err, ErrIssueTypeNotFound) {
+		t.Fatalf("Create() with foreign issue type error = %v, want ErrIssueTypeNotFound", err)
+	}
+
+	var issueID string
+	if err := db.Get(&issueID,
+		`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position)
+		 VALUES ($1, 1, $2, $3, 'Bug', '', 'medium', $4, 0) RETURNING id`,
+		projectID, typeID, statusID, reporterID,
+	); err != nil {
+		t.Fatalf("seed issue: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issue_field_values (issue_id, field_id, value) VALUES ($1, $2, '"staging"')`, issueID, env.ID); err != nil {
+		t.Fatalf("seed field value: %v", err)
+	}
+
+	_, err = Update(ctx, db, UpdateParams{FieldID: env.ID, ProjectID: projectID, Name: "Environment", Options: []string{"prod"}})
+	if !errors.Is(err, ErrOptionInUse) {
+		t.Fatalf("Update() removing used option error = %v, want ErrOptionInUse", err)
+	}
+	_, err = Update(ctx, db, UpdateParams{FieldID: env.ID, ProjectID: projectID, Name: "Environment"})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("Update() without options error = %v, want ErrInvalidOptions", err)
+	}
+	updated, err := Update(ctx, db, UpdateParams{FieldID: env.ID, ProjectID: projectID, Name: "Env", Options: []string{"staging", "dev"}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Name != "Env" || len(updated.IssueTypeIDs) != 0 || !slices.Equal(updated.Options, []string{"staging", "dev"}) {
+		t.Fatalf("updated field: got %+v", updated)
+	}
+
+	if err := Archive(ctx, db, projectID, env.ID); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if err := Archive(ctx, db, projectID, env.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Archive() twice error = %v, want ErrNotFound", err)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "Env", Type: TypeText}); err != nil {
+		t.Fatalf("Create() reusing archived name error = %v", err)
+	}
+	list, err := List(ctx, db, projectID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].Type != TypeText {
+		t.Fatalf("List(): got %+v", list)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package customfields
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var reUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Normalize checks a JSON value against the field type and returns it in its
+// stored form. A nil result means the value clears the field: JSON null, a
+// blank text or an empty multi select.
+func (f Field) Normalize(raw json.RawMessage) (json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	switch f.Type {
+	case TypeNumber:
+		if raw[0] == '"' {
+			return nil, f.invalid("must be a number")
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var n json.Number
+		if err := dec.Decode(&n); err != nil {
+			return nil, f.invalid("must be a number")
+		}
+		v, err := n.Float64()
+		if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, f.invalid("must be a number")
+		}
+		return json.RawMessage(n.String()), nil
+	case TypeMultiSelect:
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, f.invalid("must be a list of options")
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		for _, v := range values {
+			if !slices.Contains(f.Options, v) {
+				return nil, f.invalid(fmt.Sprintf("has no option %q", v))
+			}
+		}
+		// Keep the options in their defined order, once each.
+		selected := []string{}
+		for _, option := range f.Options {
+			if slices.Contains(values, option) {
+				selected = append(selected, option)
+			}
+		}
+		return json.Marshal(selected)
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, f.invalid("must be a string")
+	}
+	switch f.Type {
+	case TypeText:
+		if strings.TrimSpace(s) == "" {
+			return nil, nil
+		}
+	case TypeDate:
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, f.invalid("must be YYYY-MM-DD format")
+		}
+	case TypeSingleSelect:
+		if !slices.Contains(f.Options, s) {
+			return nil, f.invalid(fmt.Sprintf("has no option %q", s))
+		}
+	case TypeUser:
+		if !reUUID.MatchString(s) {
+			return nil, f.invalid("must be a user ID")
+		}
+		s = strings.ToLower(s)
+	}
+	return json.Marshal(s)
+}
+
+// ParseFilter checks list filter values for the field. Text filters match by
+// substring; the other types match one of the given values exactly.
+func (f Field) ParseFilter(values []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		switch f.Type {
+		case TypeText:
+			if strings.TrimSpace(v) == "" {
+				return nil, f.invalid("filter must not be blank")
+			}
+		case TypeNumber:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, f.invalid(fmt.Sprintf("filter %q is not a number", v))
+			}
+		case TypeDate:
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return nil, f.invalid(fmt.Sprintf("filter %q must be YYYY-MM-DD format", v))
+			}
+		case TypeSingleSelect, TypeMultiSelect:
+			if !slices.Contains(f.Options, v) {
+				return nil, f.invalid(fmt.Sprintf("has no option %q", v))
+			}
+		case TypeUser:
+			if !reUUID.MatchString(v) {
+				return nil, f.invalid(fmt.Sprintf("filter %q is not a user ID", v))
+			}
+			v = strings.ToLower(v)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (f Field) invalid(reason string) error {
+	return fmt.Errorf("%w: %s %s", ErrInvalidValue, f.Name, reason)
+}
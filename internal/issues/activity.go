@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/activity"
+)
+
+const (
+	EventCreated     = activity.Created
+	EventUpdated     = activity.Updated
+	EventMoved       = activity.Moved
+	EventArchived    = activity.Archived
+	EventRestored    = activity.Restored
+	EventTransferred = activity.Transferred
+	EventCommented   = activity.Commented
+)
+
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+)
+
+// Event is an entry of an issue's activity log. ActorID is nil and ActorName
+// empty for events recorded by the system rather than a user.
+type Event struct {
+	ID        string          `db:"id"           json:"id"`
+	IssueID   string          `db:"issue_id"     json:"issue_id"`
+	ActorID   *string         `db:"actor_id"     json:"actor_id"`
+	ActorName string          `db:"actor_name"   json:"actor_name"`
+	EventType string          `db:"event_type"   json:"event_type"`
+	Payload   json.RawMessage `db:"payload_json" json:"payload"`
+	CreatedAt time.Time       `db:"created_at"   json:"created_at"`
+}
+
+// FieldChange is one entry of the field-level diff stored on "updated" events.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type ActivityParams struct {
+	ProjectID string
+	IssueID   string
+	Limit     int
+	Offset    int
+}
+
+func (params ActivityParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.Limit < 0 || params.Limit > maxActivityLimit {
+		return errors.New("limit must be between 0 and 200")
+	}
+	if params.Offset < 0 {
+		return errors.New("offset must be >= 0")
+	}
+	return nil
+}
+
+// ListActivity returns the events of an issue, newest first.
+// A zero Limit falls back to the default page size.
+func ListActivity(ctx context.Context, db *sqlx.DB, params ActivityParams) ([]Event, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultActivityLimit
+	}
+	return listEvents(ctx, db, params)
+}
+
+// RecordEvent appends an event to an issue's activity log inside tx, so that
+// other domains can log alongside their own writes in the same transaction.
+// An empty actorID records a system event.
+func RecordEvent(ctx context.Context, tx *sqlx.Tx, issueID, actorID, eventType string, payload any) error {
+	if tx == nil {
+		return errors.New("tx is required")
+	}
+	if issueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if eventType == "" {
+		return errors.New("event_type is required")
+	}
+	return recordEvent(ctx, tx, issueID, actorID, eventType, payload)
+}
+
+// eventActor resolves the user recorded on an issue event; see activity.Actor.
+func eventActor(ctx context.Context, fallback string) string {
+	return activity.Actor(ctx, fallback)
+}
+
+// diffIssues returns the user-editable fields that differ between two
+// snapshots of the same issue, keyed by their JSON name.
+func diffIssues(before, after Issue) map[string]FieldChange {
+	changes := map[string]FieldChange{}
+	if before.Title != after.Title {
+		changes["title"] = FieldChange{From: before.Title, To: after.Title}
+	}
+	if before.Description != after.Description {
+		changes["description"] = FieldChange{From: before.Description, To: after.Description}
+	}
+	if before.Priority != after.Priority {
+		changes["priority"] = FieldChange{From: before.Priority, To: after.Priority}
+	}
+	if from, to := optionalString(before.AssigneeID), optionalString(after.AssigneeID); from != to {
+		changes["assignee_id"] = FieldChange{From: from, To: to}
+	}
+	if from, to := optionalDate(before.DueDate), optionalDate(after.DueDate); from != to {
+		changes["due_date"] = FieldChange{From: from, To: to}
+	}
+	if from, to := optionalString(before.ParentIssueID), optionalString(after.ParentIssueID); from != to {
+		changes["parent_issue_id"] = FieldChange{From: from, To: to}
+	}
+	return changes
+}
+
+// optionalString and optionalDate normalize nullable columns so that
+// diff values compare by content and serialize as JSON null when unset.
+func optionalString(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func optionalDate(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
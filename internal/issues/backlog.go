@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrRankReferenceNotFound = errors.New("reference issue not found")
+
+// RankParams moves an issue in the project-wide backlog order so that it sits
+// directly before or after another issue. Exactly one reference is required.
+type RankParams struct {
+	ProjectID     string
+	IssueID       string
+	BeforeIssueID string
+	AfterIssueID  string
+}
+
+func (params RankParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if (params.BeforeIssueID == "") == (params.AfterIssueID == "") {
+		return errors.New("exactly one of before_issue_id or after_issue_id is required")
+	}
+	if params.BeforeIssueID == params.IssueID || params.AfterIssueID == params.IssueID {
+		return errors.New("an issue cannot be ranked relative to itself")
+	}
+	return nil
+}
+
+// ListBacklog returns the project's open issues that are not planned into a
+// sprint, in backlog rank order. Issues in a "done" status are left out.
+func ListBacklog(ctx context.Context, db *sqlx.DB, projectID string) ([]Issue, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listBacklog(ctx, db, projectID)
+}
+
+// Rank reorders an issue in the backlog. Backlog rank is independent of
+// status, so the same order applies across the whole project.
+func Rank(ctx context.Context, db *sqlx.DB, params RankParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	return rankIssue(ctx, db, params)
+}
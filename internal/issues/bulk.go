@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	BulkAssign      = "assign"
+	BulkSetPriority = "set_priority"
+	BulkMove        = "move"
+	BulkArchive     = "archive"
+
+	BulkApplied   = "applied"
+	BulkUnchanged = "unchanged"
+	BulkFailed    = "failed"
+
+	maxBulkIssues = 100
+)
+
+var (
+	ErrInvalidBulkOperation = errors.New("operation must be 'assign', 'set_priority', 'move' or 'archive'")
+	ErrStatusNotFound       = errors.New("status not found in project")
+	ErrAssigneeNotMember    = errors.New("assignee must be an active member of the workspace")
+)
+
+var validBulkOperations = map[string]bool{
+	BulkAssign: true, BulkSetPriority: true, BulkMove: true, BulkArchive: true,
+}
+
+// BulkParams applies one operation to several issues of a project. Only the
+// field of the chosen operation is read: AssigneeID for assign (nil
+// unassigns), Priority for set_priority and StatusID for move.
+type BulkParams struct {
+	ProjectID  string
+	IssueIDs   []string
+	Operation  string
+	AssigneeID *string
+	Priority   string
+	StatusID   string
+}
+
+func (params BulkParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if len(params.IssueIDs) == 0 {
+		return errors.New("issue_ids is required")
+	}
+	if len(params.IssueIDs) > maxBulkIssues {
+		return errors.New("issue_ids must have at most 100 entries")
+	}
+	seen := make(map[string]bool, len(params.IssueIDs))
+	for _, id := range params.IssueIDs {
+		if id == "" {
+			return errors.New("issue_ids must not contain empty values")
+		}
+		if seen[id] {
+			return errors.New("issue_ids must not contain duplicates")
+		}
+		seen[id] = true
+	}
+	switch params.Operation {
+	case BulkAssign:
+		if params.AssigneeID != nil && *params.AssigneeID == "" {
+			return errors.New("assignee_id must be a user ID or null")
+		}
+	case BulkSetPriority:
+		if !validPriorities[params.Priority] {
+			return ErrInvalidPriority
+		}
+	case BulkMove:
+		if params.StatusID == "" {
+			return errors.New("status_id is required")
+		}
+	case BulkArchive:
+	default:
+		return ErrInvalidBulkOperation
+	}
+	return nil
+}
+
+// BulkResult reports what happened to one issue of a bulk request.
+type BulkResult struct {
+	IssueID string `json:"issue_id"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Bulk applies params.Operation to each issue in a single transaction and
+// returns one result per issue, in request order. Issues that cannot be
+// changed are reported as failed without undoing the others; moved issues
+// are appended to the target status in request order.
+func Bulk(ctx context.Context, db *sqlx.DB, params BulkParams) ([]BulkResult, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return bulkIssues(ctx, db, params)
+}
@@ -9,10 +9,14 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/customfields"
 	"github.com/start-codex/tookly/internal/respond"
 )
 
@@ -27,17 +31,91 @@ func parseDueDate(s *string) (*time.Time, error) {
 	return &t, nil
 }
 
+// parseDateQuery reads an optional YYYY-MM-DD query parameter.
+func parseDateQuery(r *http.Request, name string) (*time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, errors.New(name + " must be YYYY-MM-DD format")
+	}
+	return &t, nil
+}
+
+// parseBoolQuery reads an optional boolean query parameter, returning false when absent.
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New(name + " must be true or false")
+	}
+	return b, nil
+}
+
+// parseIntQuery reads a non-negative integer query parameter, returning 0 when absent.
+func parseIntQuery(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, errors.New(name + " must be a non-negative integer")
+	}
+	return n, nil
+}
+
 func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
 	mux.HandleFunc("POST /projects/{projectID}/issues", handleCreate(db))
 	mux.HandleFunc("GET /projects/{projectID}/issues", handleList(db))
 	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}", handleGet(db))
+	mux.HandleFunc("GET /workspaces/{workspaceID}/issues/{key}", handleGetByKey(db))
 	mux.HandleFunc("PUT /projects/{projectID}/issues/{issueID}", handleUpdate(db))
 	mux.HandleFunc("DELETE /projects/{projectID}/issues/{issueID}", handleArchive(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/trash", handleListTrash(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/restore", handleRestore(db))
 	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/move", handleMove(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/bulk", handleBulk(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/transfer", handleTransfer(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/activity", handleActivity(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/rank", handleRank(db))
+	mux.HandleFunc("GET /projects/{projectID}/backlog", handleBacklog(db))
+	mux.HandleFunc("PUT /projects/{projectID}/issues/{issueID}/parent", handleSetParent(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/children", handleChildren(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/ancestors", handleAncestors(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/links", handleCreateLink(db))
+	mux.HandleFunc("GET /projects/{projectID}/issues/{issueID}/links", handleListLinks(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/issues/{issueID}/links/{linkID}", handleDeleteLink(db))
+}
+
+// fieldFilters collects custom field filters given as field.<id>=value query
+// parameters.
+func fieldFilters(q url.Values) map[string][]string {
+	filters := map[string][]string{}
+	for key, values := range q {
+		if id, ok := strings.CutPrefix(key, "field."); ok {
+			filters[id] = values
+		}
+	}
+	return filters
 }
 
 func fail(w http.ResponseWriter, err error) {
+	var transitionErr *TransitionError
+	var conditionsErr *ConditionsError
+	var wipErr *WIPLimitError
 	switch {
+	case errors.As(err, &transitionErr):
+		respond.ErrorData(w, http.StatusUnprocessableEntity, transitionErr.Error(), transitionErr)
+	case errors.As(err, &conditionsErr):
+		respond.ErrorData(w, http.StatusUnprocessableEntity, conditionsErr.Error(), conditionsErr)
+	case errors.As(err, &wipErr):
+		respond.ErrorData(w, http.StatusUnprocessableEntity, wipErr.Error(), wipErr)
 	case errors.Is(err, authz.ErrUnauthenticated):
 		respond.Error(w, http.StatusUnauthorized, "authentication required")
 	case errors.Is(err, authz.ErrForbidden):
@@ -45,9 +123,19 @@ func fail(w http.ResponseWriter, err error) {
 	case errors.Is(err, authz.ErrWorkspaceNotFound),
 		errors.Is(err, authz.ErrProjectNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrNotFound):
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrLinkNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrInvalidPriority):
+	case errors.Is(err, ErrLinkExists), errors.Is(err, ErrStatusArchived),
+		errors.Is(err, ErrIssueTypeArchived):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidPriority), errors.Is(err, ErrRankReferenceNotFound),
+		errors.Is(err, ErrIntegrity), errors.Is(err, ErrParentNotFound),
+		errors.Is(err, ErrInvalidLinkType), errors.Is(err, ErrLinkTargetNotFound),
+		errors.Is(err, customfields.ErrInvalidValue), errors.Is(err, ErrInvalidCursor),
+		errors.Is(err, ErrInvalidKey), errors.Is(err, ErrInvalidBulkOperation),
+		errors.Is(err, ErrStatusNotFound), errors.Is(err, ErrAssigneeNotMember),
+		errors.Is(err, ErrStatusRequired),
+		errors.Is(err, ErrTargetProjectNotFound), errors.Is(err, ErrUnmapped):
 		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
 	default:
 		slog.Error("issues handler error", "error", err)
@@ -75,6 +163,7 @@ func handleCreate(db *sqlx.DB) http.HandlerFunc {
 			Priority      string  `json:"priority"`
 			AssigneeID    string  `json:"assignee_id"`
 			DueDate       *string `json:"due_date"`
+			Fields        Fields  `json:"fields"`
 		}
 		if err := respond.Decode(r, &body); err != nil {
 			respond.Error(w, http.StatusBadRequest, "invalid JSON")
@@ -96,6 +185,7 @@ func handleCreate(db *sqlx.DB) http.HandlerFunc {
 			AssigneeID:    body.AssigneeID,
 			ReporterID:    authedUserID,
 			DueDate:       dueDate,
+			Fields:        body.Fields,
 		}
 		if err := params.Validate(); err != nil {
 			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
@@ -117,16 +207,58 @@ func handleList(db *sqlx.DB) http.HandlerFunc {
 			return
 		}
 		q := r.URL.Query()
-		list, err := List(r.Context(), db, ListParams{
-			ProjectID:  r.PathValue("projectID"),
-			StatusID:   q.Get("status_id"),
-			AssigneeID: q.Get("assignee_id"),
-		})
+		params := ListParams{
+			ProjectID:      r.PathValue("projectID"),
+			StatusID:       q.Get("status_id"),
+			AssigneeID:     q.Get("assignee_id"),
+			IssueTypeIDs:   q["issue_type_id"],
+			Priorities:     q["priority"],
+			ReporterIDs:    q["reporter_id"],
+			ParentIssueIDs: q["parent_issue_id"],
+			Labels:         q["label"],
+			Fields:         fieldFilters(q),
+			Sort:           q.Get("sort"),
+			Cursor:         q.Get("cursor"),
+		}
+		var err error
+		if params.DueBefore, err = parseDateQuery(r, "due_before"); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if params.DueAfter, err = parseDateQuery(r, "due_after"); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if params.Unassigned, err = parseBoolQuery(r, "unassigned"); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if params.IncludeArchived, err = parseBoolQuery(r, "include_archived"); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		switch q.Get("order") {
+		case "", "asc":
+		case "desc":
+			params.Desc = true
+		default:
+			respond.Error(w, http.StatusUnprocessableEntity, "order must be 'asc' or 'desc'")
+			return
+		}
+		if params.Limit, err = parseIntQuery(r, "limit"); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		page, err := ListPage(r.Context(), db, params)
 		if err != nil {
 			fail(w, err)
 			return
 		}
-		respond.JSON(w, http.StatusOK, list)
+		respond.JSON(w, http.StatusOK, page)
 	}
 }
 
@@ -145,6 +277,22 @@ func handleGet(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleGetByKey(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceMembership(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		issue, err := GetByKey(r.Context(), db, wsID, r.PathValue("key"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, issue)
+	}
+}
+
 func handleUpdate(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
@@ -157,6 +305,7 @@ func handleUpdate(db *sqlx.DB) http.HandlerFunc {
 			Priority    string  `json:"priority"`
 			AssigneeID  *string `json:"assignee_id"`
 			DueDate     *string `json:"due_date"`
+			Fields      Fields  `json:"fields"`
 		}
 		if err := respond.Decode(r, &body); err != nil {
 			respond.Error(w, http.StatusBadRequest, "invalid JSON")
@@ -175,6 +324,7 @@ func handleUpdate(db *sqlx.DB) http.HandlerFunc {
 			Priority:    body.Priority,
 			AssigneeID:  body.AssigneeID,
 			DueDate:     dueDate,
+			Fields:      body.Fields,
 		}
 		if err := params.Validate(); err != nil {
 			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
@@ -203,6 +353,36 @@ func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleListTrash(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		issues, err := ListTrash(r.Context(), db, r.PathValue("projectID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, issues)
+	}
+}
+
+func handleRestore(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		issue, err := Restore(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, issue)
+	}
+}
+
 func handleMove(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
@@ -234,3 +414,298 @@ func handleMove(db *sqlx.DB) http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func handleRank(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			BeforeIssueID string `json:"before_issue_id"`
+			AfterIssueID  string `json:"after_issue_id"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := RankParams{
+			ProjectID:     r.PathValue("projectID"),
+			IssueID:       r.PathValue("issueID"),
+			BeforeIssueID: body.BeforeIssueID,
+			AfterIssueID:  body.AfterIssueID,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := Rank(r.Context(), db, params); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleBacklog(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListBacklog(r.Context(), db, r.PathValue("projectID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleSetParent(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			ParentIssueID *string `json:"parent_issue_id"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := ParentParams{
+			ProjectID: r.PathValue("projectID"),
+			IssueID:   r.PathValue("issueID"),
+		}
+		if body.ParentIssueID != nil {
+			params.ParentIssueID = *body.ParentIssueID
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		issue, err := SetParent(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, issue)
+	}
+}
+
+func handleChildren(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListChildren(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleAncestors(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListAncestors(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleCreateLink(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		authedUserID, err := authz.UserIDFromContext(r.Context())
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			TargetIssueID string `json:"target_issue_id"`
+			Type          string `json:"type"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CreateLinkParams{
+			ProjectID:     r.PathValue("projectID"),
+			IssueID:       r.PathValue("issueID"),
+			TargetIssueID: body.TargetIssueID,
+			Type:          body.Type,
+			CreatedBy:     authedUserID,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		// The target may belong to another project, which needs its own access check.
+		if _, _, err := authz.RequireIssueAccess(r.Context(), db, params.TargetIssueID); err != nil {
+			if errors.Is(err, authz.ErrIssueNotFound) {
+				err = ErrLinkTargetNotFound
+			}
+			fail(w, err)
+			return
+		}
+		link, err := CreateLink(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, link)
+	}
+}
+
+func handleListLinks(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		links, err := ListLinks(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, links)
+	}
+}
+
+func handleDeleteLink(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := DeleteLink(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"), r.PathValue("linkID")); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleTransfer(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The target must be in the same workspace, so membership here covers it.
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			TargetProjectID string            `json:"target_project_id"`
+			TypeMap         map[string]string `json:"type_map"`
+			StatusMap       map[string]string `json:"status_map"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := TransferParams{
+			ProjectID:       r.PathValue("projectID"),
+			IssueID:         r.PathValue("issueID"),
+			TargetProjectID: body.TargetProjectID,
+			TypeMap:         body.TypeMap,
+			StatusMap:       body.StatusMap,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		issue, err := Transfer(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, issue)
+	}
+}
+
+func handleBulk(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			IssueIDs   []string `json:"issue_ids"`
+			Operation  string   `json:"operation"`
+			AssigneeID *string  `json:"assignee_id"`
+			Priority   string   `json:"priority"`
+			StatusID   string   `json:"status_id"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := BulkParams{
+			ProjectID:  r.PathValue("projectID"),
+			IssueIDs:   body.IssueIDs,
+			Operation:  body.Operation,
+			AssigneeID: body.AssigneeID,
+			Priority:   body.Priority,
+			StatusID:   body.StatusID,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		results, err := Bulk(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, results)
+	}
+}
+
+func handleActivity(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		limit, err := parseIntQuery(r, "limit")
+		if err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		offset, err := parseIntQuery(r, "offset")
+		if err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		params := ActivityParams{
+			ProjectID: r.PathValue("projectID"),
+			IssueID:   r.PathValue("issueID"),
+			Limit:     limit,
+			Offset:    offset,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		events, err := ListActivity(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, events)
+	}
+}
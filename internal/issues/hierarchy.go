@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Rollup counts the active child issues of an issue by status category.
+// It is returned on the issue detail, so epics show the progress of their stories.
+type Rollup struct {
+	Total int `db:"total" json:"total"`
+	Todo  int `db:"todo"  json:"todo"`
+	Doing int `db:"doing" json:"doing"`
+	Done  int `db:"done"  json:"done"`
+}
+
+// ParentParams re-parents an issue. An empty ParentIssueID detaches it.
+// Project, level and cycle rules are enforced by the database and surface as ErrIntegrity.
+type ParentParams struct {
+	ProjectID     string
+	IssueID       string
+	ParentIssueID string
+}
+
+func (params ParentParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.ParentIssueID == params.IssueID {
+		return errors.New("an issue cannot be its own parent")
+	}
+	return nil
+}
+
+func SetParent(ctx context.Context, db *sqlx.DB, params ParentParams) (Issue, error) {
+	if db == nil {
+		return Issue{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Issue{}, err
+	}
+	return setParent(ctx, db, params)
+}
+
+// ListChildren returns the active direct children of an issue.
+func ListChildren(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Issue, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return nil, errors.New("issue_id is required")
+	}
+	return listChildren(ctx, db, projectID, issueID)
+}
+
+// ListAncestors returns the parent chain of an issue, nearest parent first.
+func ListAncestors(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Issue, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return nil, errors.New("issue_id is required")
+	}
+	return listAncestors(ctx, db, projectID, issueID)
+}
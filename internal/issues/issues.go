@@ -7,7 +7,9 @@ package issues
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -16,6 +18,9 @@ import (
 var (
 	ErrNotFound        = errors.New("issue not found")
 	ErrInvalidPriority = errors.New("priority must be 'low', 'medium', 'high' or 'critical'")
+	ErrIntegrity       = errors.New("issue violates project or hierarchy rules")
+	ErrParentNotFound  = errors.New("parent issue not found")
+	ErrStatusRequired  = errors.New("status_id is required: the issue type has no active default status")
 )
 
 var validPriorities = map[string]bool{
@@ -26,6 +31,7 @@ type Issue struct {
 	ID             string     `db:"id"              json:"id"`
 	ProjectID      string     `db:"project_id"      json:"project_id"`
 	Number         int        `db:"number"          json:"number"`
+	Key            string     `db:"issue_key"       json:"key"`
 	IssueTypeID    string     `db:"issue_type_id"   json:"issue_type_id"`
 	StatusID       string     `db:"status_id"       json:"status_id"`
 	ParentIssueID  *string    `db:"parent_issue_id" json:"parent_issue_id,omitempty"`
@@ -36,11 +42,23 @@ type Issue struct {
 	ReporterID     string     `db:"reporter_id"     json:"reporter_id"`
 	DueDate        *time.Time `db:"due_date"        json:"due_date,omitempty"`
 	StatusPosition int        `db:"status_position" json:"status_position"`
+	BacklogRank    int        `db:"backlog_rank"    json:"backlog_rank"`
+	SprintID       *string    `db:"sprint_id"       json:"sprint_id,omitempty"`
+	Rollup         *Rollup    `db:"-"               json:"rollup,omitempty"`
+	Links          []Link     `db:"-"               json:"links,omitempty"`
+	Labels         []Label    `db:"-"               json:"labels,omitempty"`
+	Fields         Fields     `db:"-"               json:"fields,omitempty"`
 	CreatedAt      time.Time  `db:"created_at"      json:"created_at"`
 	UpdatedAt      time.Time  `db:"updated_at"      json:"updated_at"`
 	ArchivedAt     *time.Time `db:"archived_at"     json:"archived_at,omitempty"`
 }
 
+// Fields holds custom field values keyed by field ID. Field definitions are
+// managed by the customfields package.
+type Fields map[string]json.RawMessage
+
+// CreateParams creates an issue. StatusID, Priority and Description may be
+// left empty to take the issue type's defaults; Priority falls back to medium.
 type CreateParams struct {
 	ProjectID     string
 	IssueTypeID   string
@@ -52,6 +70,9 @@ type CreateParams struct {
 	AssigneeID    string
 	ReporterID    string
 	DueDate       *time.Time
+	// Fields sets custom field values keyed by field ID. Values are checked
+	// against the field definitions when the issue is stored.
+	Fields Fields
 }
 
 func (params CreateParams) Validate() error {
@@ -61,23 +82,16 @@ func (params CreateParams) Validate() error {
 	if params.IssueTypeID == "" {
 		return errors.New("issue_type_id is required")
 	}
-	if params.StatusID == "" {
-		return errors.New("status_id is required")
-	}
 	if params.Title == "" {
 		return errors.New("title is required")
 	}
 	if params.ReporterID == "" {
 		return errors.New("reporter_id is required")
 	}
-	priority := params.Priority
-	if priority == "" {
-		priority = "medium"
-	}
-	if !validPriorities[priority] {
+	if params.Priority != "" && !validPriorities[params.Priority] {
 		return ErrInvalidPriority
 	}
-	return nil
+	return validateFieldIDs(params.Fields)
 }
 
 type UpdateParams struct {
@@ -88,6 +102,9 @@ type UpdateParams struct {
 	Priority    string
 	AssigneeID  *string
 	DueDate     *time.Time
+	// Fields sets the given custom field values and leaves the others as they
+	// are; a JSON null clears a value. Nil leaves all custom fields unchanged.
+	Fields Fields
 }
 
 func (params UpdateParams) Validate() error {
@@ -103,15 +120,99 @@ func (params UpdateParams) Validate() error {
 	if !validPriorities[params.Priority] {
 		return ErrInvalidPriority
 	}
+	return validateFieldIDs(params.Fields)
+}
+
+func validateFieldIDs(fields Fields) error {
+	for id := range fields {
+		if id == "" {
+			return errors.New("fields must be keyed by custom field ID")
+		}
+	}
 	return nil
 }
 
+// ListParams filters issues. Multi-value filters match any of their values;
+// different filters must all match.
 type ListParams struct {
-	ProjectID  string
-	StatusID   string
-	AssigneeID string
+	ProjectID string
+	StatusID  string
+	// StatusIDs, when non-nil, limits results to these statuses.
+	StatusIDs      []string
+	AssigneeID     string
+	Unassigned     bool
+	IssueTypeIDs   []string
+	Priorities     []string
+	ReporterIDs    []string
+	ParentIssueIDs []string
+	// DueBefore and DueAfter are inclusive; issues without a due date never
+	// match them.
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	// Labels limits results to issues carrying any of these labels, given
+	// by ID or case-insensitive name.
+	Labels []string
+	// Fields limits results by custom field, keyed by field ID. An issue
+	// matches when its value matches any of the given values; text fields
+	// match by substring.
+	Fields          map[string][]string
+	IncludeArchived bool
+	Condition       Condition
+	// Sort orders results by one of the Sort* keys, ties broken by number.
+	// List keeps board order (status, then position) when Sort is empty.
+	Sort string
+	Desc bool
+	// Limit and Cursor page through ListPage results. List ignores them.
+	Limit  int
+	Cursor string
+}
+
+func (params ListParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.Unassigned && params.AssigneeID != "" {
+		return errors.New("assignee_id and unassigned cannot be combined")
+	}
+	for _, priority := range params.Priorities {
+		if !validPriorities[priority] {
+			return ErrInvalidPriority
+		}
+	}
+	for name, ids := range map[string][]string{
+		"issue_type_id":   params.IssueTypeIDs,
+		"reporter_id":     params.ReporterIDs,
+		"parent_issue_id": params.ParentIssueIDs,
+	} {
+		if slices.Contains(ids, "") {
+			return errors.New(name + " must not be empty")
+		}
+	}
+	if params.Sort != "" && !validSorts[params.Sort] {
+		return ErrInvalidSort
+	}
+	if params.Limit < 0 || params.Limit > maxListLimit {
+		return errors.New("limit must be between 0 and 200")
+	}
+	return nil
 }
 
+// Label is a project label as shown on the issues that carry it. Labels are
+// managed by the labels package.
+type Label struct {
+	ID    string `db:"id"    json:"id"`
+	Name  string `db:"name"  json:"name"`
+	Color string `db:"color" json:"color"`
+}
+
+// Condition is an extra SQL predicate over the issues table, appended to List
+// queries by other domains (e.g. board filters). bind registers an argument
+// and returns its placeholder; outer columns should be qualified as issues.col.
+type Condition func(bind func(arg any) string) string
+
+// Create adds an issue to a project, filling in the issue type's defaults. It
+// returns ErrStatusRequired when no status is given and the type has no
+// active default status.
 func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Issue, error) {
 	if db == nil {
 		return Issue{}, errors.New("db is required")
@@ -119,9 +220,6 @@ func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Issue, error
 	if err := params.Validate(); err != nil {
 		return Issue{}, err
 	}
-	if params.Priority == "" {
-		params.Priority = "medium"
-	}
 	return createIssue(ctx, db, params)
 }
 
@@ -135,15 +233,16 @@ func Get(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issue, er
 	if issueID == "" {
 		return Issue{}, errors.New("issue_id is required")
 	}
-	return getIssue(ctx, db, projectID, issueID)
+	return getIssueDetail(ctx, db, projectID, issueID)
 }
 
+// List returns every issue matching params. Use ListPage for large results.
 func List(ctx context.Context, db *sqlx.DB, params ListParams) ([]Issue, error) {
 	if db == nil {
 		return nil, errors.New("db is required")
 	}
-	if params.ProjectID == "" {
-		return nil, errors.New("project_id is required")
+	if err := params.Validate(); err != nil {
+		return nil, err
 	}
 	return listIssues(ctx, db, params)
 }
@@ -188,6 +287,10 @@ func (params MoveParams) Validate() error {
 	return nil
 }
 
+// Move places an issue at a position of a status. Moves between statuses must
+// follow the project's workflow rules and the blocking WIP limits of its board
+// columns; otherwise it returns a *TransitionError, *ConditionsError or
+// *WIPLimitError.
 func Move(ctx context.Context, db *sqlx.DB, params MoveParams) error {
 	if db == nil {
 		return errors.New("db is required")
@@ -7,6 +7,9 @@ package issues
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -81,10 +84,12 @@ func TestCreateIssueParams_Validate(t *testing.T) {
 		{name: "valid with due date", params: func() CreateParams { c := valid; c.DueDate = &due; return c }(), wantErr: false},
 		{name: "missing project_id", params: func() CreateParams { c := valid; c.ProjectID = ""; return c }(), wantErr: true},
 		{name: "missing issue_type_id", params: func() CreateParams { c := valid; c.IssueTypeID = ""; return c }(), wantErr: true},
-		{name: "missing status_id", params: func() CreateParams { c := valid; c.StatusID = ""; return c }(), wantErr: true},
+		{name: "missing status_id uses the type default", params: func() CreateParams { c := valid; c.StatusID = ""; return c }()},
 		{name: "missing title", params: func() CreateParams { c := valid; c.Title = ""; return c }(), wantErr: true},
 		{name: "missing reporter_id", params: func() CreateParams { c := valid; c.ReporterID = ""; return c }(), wantErr: true},
 		{name: "invalid priority", params: func() CreateParams { c := valid; c.Priority = "urgent"; return c }(), wantErr: true},
+		{name: "valid with fields", params: func() CreateParams { c := valid; c.Fields = Fields{"f": json.RawMessage(`"x"`)}; return c }(), wantErr: false},
+		{name: "field without id", params: func() CreateParams { c := valid; c.Fields = Fields{"": json.RawMessage(`"x"`)}; return c }(), wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +121,7 @@ func TestUpdateIssueParams_Validate(t *testing.T) {
 		{name: "missing title", params: func() UpdateParams { c := valid; c.Title = ""; return c }(), wantErr: true},
 		{name: "invalid priority", params: func() UpdateParams { c := valid; c.Priority = "asap"; return c }(), wantErr: true},
 		{name: "empty priority invalid", params: func() UpdateParams { c := valid; c.Priority = ""; return c }(), wantErr: true},
+		{name: "field without id", params: func() UpdateParams { c := valid; c.Fields = Fields{"": nil}; return c }(), wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +157,86 @@ func TestListIssues_NilDB(t *testing.T) {
 	}
 }
 
+func TestListParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ListParams
+		wantErr bool
+	}{
+		{name: "valid", params: ListParams{ProjectID: "p"}, wantErr: false},
+		{name: "valid with filters", params: ListParams{ProjectID: "p", Priorities: []string{"high", "critical"}, ReporterIDs: []string{"u"}, Sort: SortDue, Desc: true, Limit: 200}, wantErr: false},
+		{name: "missing project_id", params: ListParams{}, wantErr: true},
+		{name: "unassigned with assignee", params: ListParams{ProjectID: "p", AssigneeID: "u", Unassigned: true}, wantErr: true},
+		{name: "invalid priority", params: ListParams{ProjectID: "p", Priorities: []string{"urgent"}}, wantErr: true},
+		{name: "empty issue type", params: ListParams{ProjectID: "p", IssueTypeIDs: []string{""}}, wantErr: true},
+		{name: "invalid sort", params: ListParams{ProjectID: "p", Sort: "title"}, wantErr: true},
+		{name: "limit too large", params: ListParams{ProjectID: "p", Limit: 201}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	last := Issue{Number: 7, Priority: "high", DueDate: &due, CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 678900000, time.UTC)}
+	tests := []struct {
+		sort      string
+		desc      bool
+		issue     Issue
+		wantValue string
+	}{
+		{sort: SortCreated, issue: last, wantValue: "2025-01-02T03:04:05.6789Z"},
+		{sort: SortPriority, desc: true, issue: last, wantValue: "2"},
+		{sort: SortDue, issue: last, wantValue: "2025-03-01"},
+		{sort: SortDue, issue: Issue{Number: 7}, wantValue: "infinity"},
+		{sort: SortDue, desc: true, issue: Issue{Number: 7}, wantValue: "-infinity"},
+		{sort: SortNumber, issue: last, wantValue: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort+"/"+tt.wantValue, func(t *testing.T) {
+			got, err := decodeCursor(encodeCursor(tt.sort, tt.desc, tt.issue), tt.sort, tt.desc)
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if got.Value != tt.wantValue || got.Number != 7 {
+				t.Fatalf("decodeCursor() = %+v, want value %q number 7", got, tt.wantValue)
+			}
+		})
+	}
+
+	encoded := encodeCursor(SortCreated, false, last)
+	for _, tt := range []struct {
+		name string
+		s    string
+		sort string
+		desc bool
+	}{
+		{name: "other sort", s: encoded, sort: SortUpdated},
+		{name: "other direction", s: encoded, sort: SortCreated, desc: true},
+		{name: "not base64", s: "%%%", sort: SortCreated},
+		{name: "not json", s: "bm9wZQ", sort: SortCreated},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.s, tt.sort, tt.desc); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("decodeCursor() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestListPage_NilDB(t *testing.T) {
+	_, err := ListPage(context.Background(), nil, ListParams{ProjectID: "p"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListPage() error = %v, want %q", err, "db is required")
+	}
+}
+
 func TestUpdateIssue_NilDB(t *testing.T) {
 	_, err := Update(context.Background(), nil, UpdateParams{
 		IssueID: "i", ProjectID: "p", Title: "T", Priority: "medium",
@@ -166,3 +252,354 @@ func TestArchiveIssue_NilDB(t *testing.T) {
 		t.Fatalf("Archive() error = %v, want %q", err, "db is required")
 	}
 }
+
+func TestRestoreIssue_NilDB(t *testing.T) {
+	_, err := Restore(context.Background(), nil, "p", "i")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Restore() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListTrash_NilDB(t *testing.T) {
+	_, err := ListTrash(context.Background(), nil, "p")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListTrash() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestActivityParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ActivityParams
+		wantErr bool
+	}{
+		{name: "valid", params: ActivityParams{ProjectID: "p", IssueID: "i"}, wantErr: false},
+		{name: "valid with page", params: ActivityParams{ProjectID: "p", IssueID: "i", Limit: 20, Offset: 40}, wantErr: false},
+		{name: "missing project_id", params: ActivityParams{IssueID: "i"}, wantErr: true},
+		{name: "missing issue_id", params: ActivityParams{ProjectID: "p"}, wantErr: true},
+		{name: "limit too large", params: ActivityParams{ProjectID: "p", IssueID: "i", Limit: 201}, wantErr: true},
+		{name: "negative offset", params: ActivityParams{ProjectID: "p", IssueID: "i", Offset: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListActivity_NilDB(t *testing.T) {
+	_, err := ListActivity(context.Background(), nil, ActivityParams{ProjectID: "p", IssueID: "i"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListActivity() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestDiffIssues(t *testing.T) {
+	assignee := "user-1"
+	due := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
+	before := Issue{Title: "Old", Description: "d", Priority: "low"}
+	after := Issue{Title: "New", Description: "d", Priority: "low", AssigneeID: &assignee, DueDate: &due}
+
+	got := diffIssues(before, after)
+	if len(got) != 3 {
+		t.Fatalf("len: got %d, want 3 (%v)", len(got), got)
+	}
+	if got["title"] != (FieldChange{From: "Old", To: "New"}) {
+		t.Fatalf("title change = %v", got["title"])
+	}
+	if got["assignee_id"] != (FieldChange{From: nil, To: "user-1"}) {
+		t.Fatalf("assignee_id change = %v", got["assignee_id"])
+	}
+	if got["due_date"] != (FieldChange{From: nil, To: "2026-04-15"}) {
+		t.Fatalf("due_date change = %v", got["due_date"])
+	}
+
+	if unchanged := diffIssues(after, after); len(unchanged) != 0 {
+		t.Fatalf("diff of identical issues: got %v, want empty", unchanged)
+	}
+}
+
+func TestRankParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  RankParams
+		wantErr bool
+	}{
+		{name: "before", params: RankParams{ProjectID: "p", IssueID: "i", BeforeIssueID: "j"}, wantErr: false},
+		{name: "after", params: RankParams{ProjectID: "p", IssueID: "i", AfterIssueID: "j"}, wantErr: false},
+		{name: "missing project_id", params: RankParams{IssueID: "i", BeforeIssueID: "j"}, wantErr: true},
+		{name: "missing issue_id", params: RankParams{ProjectID: "p", BeforeIssueID: "j"}, wantErr: true},
+		{name: "no reference", params: RankParams{ProjectID: "p", IssueID: "i"}, wantErr: true},
+		{name: "both references", params: RankParams{ProjectID: "p", IssueID: "i", BeforeIssueID: "j", AfterIssueID: "k"}, wantErr: true},
+		{name: "relative to itself", params: RankParams{ProjectID: "p", IssueID: "i", AfterIssueID: "i"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkParams_Validate(t *testing.T) {
+	assignee := "u"
+	empty := ""
+	tooMany := make([]string, maxBulkIssues+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("i%d", i)
+	}
+	tests := []struct {
+		name    string
+		params  BulkParams
+		wantErr bool
+	}{
+		{name: "assign", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i", "j"}, Operation: BulkAssign, AssigneeID: &assignee}, wantErr: false},
+		{name: "unassign", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkAssign}, wantErr: false},
+		{name: "set priority", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkSetPriority, Priority: "high"}, wantErr: false},
+		{name: "move", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkMove, StatusID: "s"}, wantErr: false},
+		{name: "archive", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkArchive}, wantErr: false},
+		{name: "missing project_id", params: BulkParams{IssueIDs: []string{"i"}, Operation: BulkArchive}, wantErr: true},
+		{name: "no issues", params: BulkParams{ProjectID: "p", Operation: BulkArchive}, wantErr: true},
+		{name: "too many issues", params: BulkParams{ProjectID: "p", IssueIDs: tooMany, Operation: BulkArchive}, wantErr: true},
+		{name: "empty issue id", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i", ""}, Operation: BulkArchive}, wantErr: true},
+		{name: "duplicate issue id", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i", "i"}, Operation: BulkArchive}, wantErr: true},
+		{name: "unknown operation", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: "delete"}, wantErr: true},
+		{name: "empty assignee", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkAssign, AssigneeID: &empty}, wantErr: true},
+		{name: "invalid priority", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkSetPriority, Priority: "urgent"}, wantErr: true},
+		{name: "move without status", params: BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkMove}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulk_NilDB(t *testing.T) {
+	_, err := Bulk(context.Background(), nil, BulkParams{ProjectID: "p", IssueIDs: []string{"i"}, Operation: BulkArchive})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Bulk() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestTransitionError(t *testing.T) {
+	err := error(&TransitionError{From: "Todo", To: "Done", Allowed: []string{"Doing", "Review"}})
+	if !errors.Is(err, ErrTransitionNotAllowed) {
+		t.Fatalf("errors.Is(%v, ErrTransitionNotAllowed) = false", err)
+	}
+	if want := `moving from "Todo" to "Done" is not allowed; allowed from "Todo": "Doing", "Review"`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+	err = &TransitionError{From: "Done", To: "Todo", Allowed: []string{}}
+	if want := `moving from "Done" to "Todo" is not allowed; no transitions are allowed from "Done"`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestConditionsError(t *testing.T) {
+	err := error(&ConditionsError{From: "Review", To: "Done", Unmet: []UnmetCondition{
+		{Validator: "assignee_required", Message: "the issue must have an assignee"},
+		{Validator: "comment_required", Message: "a comment is missing"},
+	}})
+	if !errors.Is(err, ErrConditionsUnmet) || errors.Is(err, ErrTransitionNotAllowed) {
+		t.Fatalf("ConditionsError must match ErrConditionsUnmet only, got %v", err)
+	}
+	if want := `moving from "Review" to "Done" requires: the issue must have an assignee; a comment is missing`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestWIPLimitError(t *testing.T) {
+	err := error(&WIPLimitError{Board: "Team", Column: "Doing", Limit: "max", Value: 3, Count: 3})
+	if !errors.Is(err, ErrWIPLimit) {
+		t.Fatalf("errors.Is(%v, ErrWIPLimit) = false", err)
+	}
+	if want := `moving the issue would take column "Doing" of board "Team" over its WIP limit of 3 (currently 3)`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+	err = &WIPLimitError{Board: "Team", Column: "Review", Limit: "min", Value: 1, Count: 1}
+	if want := `moving the issue would take column "Review" of board "Team" under its WIP minimum of 1 (currently 1)`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestListBacklog_NilDB(t *testing.T) {
+	_, err := ListBacklog(context.Background(), nil, "p")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListBacklog() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestRank_NilDB(t *testing.T) {
+	err := Rank(context.Background(), nil, RankParams{ProjectID: "p", IssueID: "i", BeforeIssueID: "j"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Rank() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestParentParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ParentParams
+		wantErr bool
+	}{
+		{name: "set parent", params: ParentParams{ProjectID: "p", IssueID: "i", ParentIssueID: "e"}, wantErr: false},
+		{name: "detach", params: ParentParams{ProjectID: "p", IssueID: "i"}, wantErr: false},
+		{name: "missing project_id", params: ParentParams{IssueID: "i", ParentIssueID: "e"}, wantErr: true},
+		{name: "missing issue_id", params: ParentParams{ProjectID: "p", ParentIssueID: "e"}, wantErr: true},
+		{name: "own parent", params: ParentParams{ProjectID: "p", IssueID: "i", ParentIssueID: "i"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetParent_NilDB(t *testing.T) {
+	_, err := SetParent(context.Background(), nil, ParentParams{ProjectID: "p", IssueID: "i"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("SetParent() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListChildren_NilDB(t *testing.T) {
+	_, err := ListChildren(context.Background(), nil, "p", "i")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListChildren() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListAncestors_NilDB(t *testing.T) {
+	_, err := ListAncestors(context.Background(), nil, "p", "i")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListAncestors() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestCreateLinkParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CreateLinkParams
+		wantErr bool
+	}{
+		{name: "outward type", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j", Type: "blocks"}, wantErr: false},
+		{name: "inward type", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j", Type: "is_blocked_by"}, wantErr: false},
+		{name: "relates to", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j", Type: "relates_to"}, wantErr: false},
+		{name: "missing project_id", params: CreateLinkParams{IssueID: "i", TargetIssueID: "j", Type: "blocks"}, wantErr: true},
+		{name: "missing issue_id", params: CreateLinkParams{ProjectID: "p", TargetIssueID: "j", Type: "blocks"}, wantErr: true},
+		{name: "missing target_issue_id", params: CreateLinkParams{ProjectID: "p", IssueID: "i", Type: "blocks"}, wantErr: true},
+		{name: "self link", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "i", Type: "blocks"}, wantErr: true},
+		{name: "unknown type", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j", Type: "causes"}, wantErr: true},
+		{name: "missing type", params: CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStoredLinkType(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantStored string
+		wantInward bool
+	}{
+		{name: "blocks", wantStored: LinkBlocks},
+		{name: "is_blocked_by", wantStored: LinkBlocks, wantInward: true},
+		{name: "is_duplicated_by", wantStored: LinkDuplicates, wantInward: true},
+		{name: "relates_to", wantStored: LinkRelatesTo},
+		{name: "is_cloned_by", wantStored: LinkClones, wantInward: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, inward, ok := storedLinkType(tt.name)
+			if !ok || stored != tt.wantStored || inward != tt.wantInward {
+				t.Fatalf("storedLinkType(%q) = %q, %v, %v", tt.name, stored, inward, ok)
+			}
+		})
+	}
+}
+
+func TestCreateLink_NilDB(t *testing.T) {
+	_, err := CreateLink(context.Background(), nil, CreateLinkParams{ProjectID: "p", IssueID: "i", TargetIssueID: "j", Type: LinkBlocks})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("CreateLink() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListLinks_NilDB(t *testing.T) {
+	_, err := ListLinks(context.Background(), nil, "p", "i")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListLinks() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestDeleteLink_NilDB(t *testing.T) {
+	err := DeleteLink(context.Background(), nil, "p", "i", "l")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("DeleteLink() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key        string
+		wantKey    string
+		wantNumber int
+		wantErr    bool
+	}{
+		{key: "ENG-42", wantKey: "ENG", wantNumber: 42},
+		{key: "eng-7", wantKey: "ENG", wantNumber: 7},
+		{key: "ENG-042", wantErr: true},
+		{key: "ENG-0", wantErr: true},
+		{key: "ENG42", wantErr: true},
+		{key: "E-1", wantErr: true},
+		{key: "ENG-", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			key, number, err := ParseKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if key != tt.wantKey || number != tt.wantNumber {
+				t.Fatalf("ParseKey() = %q, %d; want %q, %d", key, number, tt.wantKey, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestGetByKey_NilDB(t *testing.T) {
+	_, err := GetByKey(context.Background(), nil, "w", "ENG-1")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("GetByKey() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestRecordEvent_NilTx(t *testing.T) {
+	err := RecordEvent(context.Background(), nil, "i-1", "", EventCommented, nil)
+	if err == nil || err.Error() != "tx is required" {
+		t.Fatalf("RecordEvent() error = %v, want %q", err, "tx is required")
+	}
+}
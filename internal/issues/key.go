@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidKey = errors.New("issue key must look like ENG-42")
+
+var reIssueKey = regexp.MustCompile(`^([A-Za-z]{2,10})-([1-9][0-9]{0,8})$`)
+
+// ParseKey splits an issue key such as "ENG-42" into the project key and the
+// issue number. Project keys are matched case-insensitively.
+func ParseKey(key string) (string, int, error) {
+	m := reIssueKey.FindStringSubmatch(strings.TrimSpace(key))
+	if m == nil {
+		return "", 0, ErrInvalidKey
+	}
+	number, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", 0, ErrInvalidKey
+	}
+	return strings.ToUpper(m[1]), number, nil
+}
+
+// GetByKey resolves an issue key within a workspace and returns the issue
+// with its detail extras, as Get does.
+func GetByKey(ctx context.Context, db *sqlx.DB, workspaceID, key string) (Issue, error) {
+	if db == nil {
+		return Issue{}, errors.New("db is required")
+	}
+	if workspaceID == "" {
+		return Issue{}, errors.New("workspace_id is required")
+	}
+	projectKey, number, err := ParseKey(key)
+	if err != nil {
+		return Issue{}, err
+	}
+	projectID, issueID, err := resolveKey(ctx, db, workspaceID, projectKey, number)
+	if err != nil {
+		return Issue{}, err
+	}
+	return getIssueDetail(ctx, db, projectID, issueID)
+}
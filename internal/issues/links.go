@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	LinkBlocks     = "blocks"
+	LinkDuplicates = "duplicates"
+	LinkRelatesTo  = "relates_to"
+	LinkClones     = "clones"
+)
+
+var (
+	ErrLinkNotFound       = errors.New("issue link not found")
+	ErrLinkExists         = errors.New("issues are already linked with this type")
+	ErrLinkTargetNotFound = errors.New("linked issue not found in this workspace")
+	ErrInvalidLinkType    = errors.New("type must be 'blocks', 'is_blocked_by', 'duplicates', 'is_duplicated_by', 'relates_to', 'clones' or 'is_cloned_by'")
+)
+
+// inwardLinkTypes names each stored link type as seen from its target issue.
+// Links are stored outward; "relates_to" reads the same from both sides.
+var inwardLinkTypes = map[string]string{
+	LinkBlocks:     "is_blocked_by",
+	LinkDuplicates: "is_duplicated_by",
+	LinkRelatesTo:  LinkRelatesTo,
+	LinkClones:     "is_cloned_by",
+}
+
+// Link is a relation between two issues, described from the perspective of
+// the issue it is listed on: Type is outward ("blocks") or inward
+// ("is_blocked_by") accordingly, and Issue is the other end.
+type Link struct {
+	ID        string      `db:"id"         json:"id"`
+	Type      string      `db:"-"          json:"type"`
+	Issue     LinkedIssue `db:"issue"      json:"issue"`
+	CreatedBy *string     `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+}
+
+// LinkedIssue summarizes the other end of a link. It may belong to another
+// project of the same workspace, so it carries its key and status inline.
+type LinkedIssue struct {
+	ID             string `db:"id"              json:"id"`
+	ProjectID      string `db:"project_id"      json:"project_id"`
+	Key            string `db:"key"             json:"key"`
+	Number         int    `db:"number"          json:"number"`
+	Title          string `db:"title"           json:"title"`
+	Priority       string `db:"priority"        json:"priority"`
+	StatusID       string `db:"status_id"       json:"status_id"`
+	StatusName     string `db:"status_name"     json:"status_name"`
+	StatusCategory string `db:"status_category" json:"status_category"`
+}
+
+// CreateLinkParams links IssueID to TargetIssueID. Type may be given in
+// either direction, e.g. "is_blocked_by" stores "target blocks issue".
+type CreateLinkParams struct {
+	ProjectID     string
+	IssueID       string
+	TargetIssueID string
+	Type          string
+	CreatedBy     string
+}
+
+func (params CreateLinkParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.TargetIssueID == "" {
+		return errors.New("target_issue_id is required")
+	}
+	if params.TargetIssueID == params.IssueID {
+		return errors.New("an issue cannot be linked to itself")
+	}
+	if _, _, ok := storedLinkType(params.Type); !ok {
+		return ErrInvalidLinkType
+	}
+	return nil
+}
+
+// storedLinkType resolves a link type name to the stored outward type and
+// reports whether the name was the inward form.
+func storedLinkType(name string) (string, bool, bool) {
+	if _, ok := inwardLinkTypes[name]; ok {
+		return name, false, true
+	}
+	for stored, inward := range inwardLinkTypes {
+		if inward == name {
+			return stored, true, true
+		}
+	}
+	return "", false, false
+}
+
+// CreateLink links two active issues. The target may live in any project of
+// the same workspace; callers must check access to the target project.
+func CreateLink(ctx context.Context, db *sqlx.DB, params CreateLinkParams) (Link, error) {
+	if db == nil {
+		return Link{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Link{}, err
+	}
+	return createLink(ctx, db, params)
+}
+
+// ListLinks returns the links of an issue whose other end is not archived.
+func ListLinks(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Link, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return nil, errors.New("issue_id is required")
+	}
+	if _, err := getIssue(ctx, db, projectID, issueID); err != nil {
+		return nil, err
+	}
+	return listLinks(ctx, db, issueID, "")
+}
+
+// DeleteLink removes a link from either of its ends.
+func DeleteLink(ctx context.Context, db *sqlx.DB, projectID, issueID, linkID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if linkID == "" {
+		return errors.New("link_id is required")
+	}
+	return deleteLink(ctx, db, projectID, issueID, linkID)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	SortCreated  = "created"
+	SortUpdated  = "updated"
+	SortPriority = "priority"
+	SortDue      = "due"
+	SortNumber   = "number"
+
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+var (
+	ErrInvalidSort   = errors.New("sort must be 'created', 'updated', 'priority', 'due' or 'number'")
+	ErrInvalidCursor = errors.New("cursor is invalid or belongs to another sort order")
+)
+
+var validSorts = map[string]bool{
+	SortCreated: true, SortUpdated: true, SortPriority: true, SortDue: true, SortNumber: true,
+}
+
+var priorityRanks = map[string]int{"low": 0, "medium": 1, "high": 2, "critical": 3}
+
+// Page is one page of ListPage results. Total counts every matching issue;
+// NextCursor is empty on the last page.
+type Page struct {
+	Issues     []Issue `json:"issues"`
+	Total      int     `json:"total"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// cursor marks the last issue of a page by its sort value and number, which
+// is unique within a project and breaks ties.
+type cursor struct {
+	Sort   string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  string `json:"v,omitempty"`
+	Number int    `json:"n"`
+}
+
+func encodeCursor(sort string, desc bool, last Issue) string {
+	c := cursor{Sort: sort, Desc: desc, Number: last.Number}
+	switch sort {
+	case SortCreated:
+		c.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case SortUpdated:
+		c.Value = last.UpdatedAt.Format(time.RFC3339Nano)
+	case SortPriority:
+		c.Value = strconv.Itoa(priorityRanks[last.Priority])
+	case SortDue:
+		c.Value = dueSortValue(last.DueDate, desc)
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s, sort string, desc bool) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Sort != sort || c.Desc != desc || c.Number <= 0 {
+		return cursor{}, ErrInvalidCursor
+	}
+	switch sort {
+	case SortCreated, SortUpdated:
+		if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+			return cursor{}, ErrInvalidCursor
+		}
+	case SortPriority:
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return cursor{}, ErrInvalidCursor
+		}
+	case SortDue:
+		if c.Value != "infinity" && c.Value != "-infinity" {
+			if _, err := time.Parse("2006-01-02", c.Value); err != nil {
+				return cursor{}, ErrInvalidCursor
+			}
+		}
+	}
+	return c, nil
+}
+
+// dueSortValue mirrors the SQL sort expression for due dates, which puts
+// issues without one last in either direction.
+func dueSortValue(due *time.Time, desc bool) string {
+	switch {
+	case due != nil:
+		return due.Format("2006-01-02")
+	case desc:
+		return "-infinity"
+	default:
+		return "infinity"
+	}
+}
+
+// ListPage returns one page of issues matching params, ordered by Sort
+// (created by default). Pass the returned NextCursor to get the next page.
+// A zero Limit falls back to the default page size.
+func ListPage(ctx context.Context, db *sqlx.DB, params ListParams) (Page, error) {
+	if db == nil {
+		return Page{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Page{}, err
+	}
+	if params.Sort == "" {
+		params.Sort = SortCreated
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultListLimit
+	}
+	var after *cursor
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, params.Sort, params.Desc)
+		if err != nil {
+			return Page{}, err
+		}
+		after = &c
+	}
+	return listIssuePage(ctx, db, params, after)
+}
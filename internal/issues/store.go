@@ -6,24 +6,40 @@
 package issues
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/activity"
+	"github.com/start-codex/tookly/internal/customfields"
+	"github.com/start-codex/tookly/internal/issuetypes"
 	"github.com/start-codex/tookly/internal/pgutil"
+	"github.com/start-codex/tookly/internal/statuses"
 )
 
-const reorderOffset = 1000000
-
 const issueCols = `id, project_id, number, issue_type_id, status_id, parent_issue_id,
 	title, description, priority, assignee_id, reporter_id, due_date,
-	status_position, created_at, updated_at, archived_at`
+	status_position, backlog_rank, sprint_id, created_at, updated_at, archived_at,
+	(SELECT p.key FROM projects p WHERE p.id = issues.project_id) || '-' || issues.number AS issue_key`
 
 func createIssue(ctx context.Context, db *sqlx.DB, params CreateParams) (Issue, error) {
 	var issue Issue
 	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit create issue", func(tx *sqlx.Tx) error {
+		if err := applyTypeDefaults(ctx, tx, &params); err != nil {
+			return err
+		}
+
 		var number int
 		if err := tx.QueryRowxContext(ctx,
 			`INSERT INTO project_issue_counters (project_id, last_number)
@@ -61,13 +77,77 @@ func createIssue(ctx context.Context, db *sqlx.DB, params CreateParams) (Issue,
 			params.ProjectID, number, params.IssueTypeID, params.StatusID, parentIssueID,
 			params.Title, params.Description, params.Priority, assigneeID, params.ReporterID, params.DueDate,
 		).StructScan(&issue); err != nil {
-			return fmt.Errorf("insert issue: %w", err)
+			return integrityError(fmt.Errorf("insert issue: %w", err))
 		}
-		return nil
+		if _, err := saveFieldValues(ctx, tx, issue, params.Fields); err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, tx, issue.ID, eventActor(ctx, params.ReporterID), EventCreated, map[string]any{
+			"number":        issue.Number,
+			"title":         issue.Title,
+			"issue_type_id": issue.IssueTypeID,
+			"status_id":     issue.StatusID,
+		})
 	}); err != nil {
 		return Issue{}, err
 	}
-	return issue, nil
+	return withExtras(ctx, db, issue)
+}
+
+// applyTypeDefaults fills in the status, priority and description left out
+// of params from the issue type. A default status only counts while active;
+// the description template gets its placeholders rendered.
+func applyTypeDefaults(ctx context.Context, tx *sqlx.Tx, params *CreateParams) error {
+	var defaults struct {
+		StatusID     *string `db:"status_id"`
+		Priority     *string `db:"default_priority"`
+		Template     string  `db:"description_template"`
+		TypeName     string  `db:"type_name"`
+		ProjectName  string  `db:"project_name"`
+		ReporterName string  `db:"reporter_name"`
+	}
+	err := tx.GetContext(ctx, &defaults,
+		`SELECT s.id AS status_id, t.default_priority, t.description_template,
+		        t.name AS type_name, p.name AS project_name,
+		        COALESCE(u.name, '') AS reporter_name
+		 FROM issue_types t
+		 JOIN projects p ON p.id = t.project_id
+		 LEFT JOIN statuses s ON s.id = t.default_status_id AND s.archived_at IS NULL
+		 LEFT JOIN app_users u ON u.id = $3
+		 WHERE t.id = $1
+		   AND t.project_id = $2`,
+		params.IssueTypeID, params.ProjectID, params.ReporterID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// The type belongs to another project.
+			return ErrIntegrity
+		}
+		return fmt.Errorf("load issue type defaults: %w", err)
+	}
+
+	if params.StatusID == "" {
+		if defaults.StatusID == nil {
+			return ErrStatusRequired
+		}
+		params.StatusID = *defaults.StatusID
+	}
+	if params.Priority == "" {
+		params.Priority = "medium"
+		if defaults.Priority != nil {
+			params.Priority = *defaults.Priority
+		}
+	}
+	if params.Description == "" && defaults.Template != "" {
+		params.Description = issuetypes.RenderTemplate(defaults.Template, issuetypes.TemplateData{
+			Reporter:  defaults.ReporterName,
+			Date:      time.Now().UTC().Format(time.DateOnly),
+			Project:   defaults.ProjectName,
+			IssueType: defaults.TypeName,
+		})
+	}
+	return nil
 }
 
 func getIssue(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issue, error) {
@@ -87,92 +167,1271 @@ func getIssue(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issu
 	return issue, nil
 }
 
+// resolveKey finds the active issue behind a key in the workspace's active
+// projects, following the redirect left by a transfer when the key is an
+// old one.
+func resolveKey(ctx context.Context, db *sqlx.DB, workspaceID, projectKey string, number int) (string, string, error) {
+	var row struct {
+		ProjectID string `db:"project_id"`
+		IssueID   string `db:"id"`
+	}
+	if err := db.GetContext(ctx, &row,
+		`SELECT i.project_id, i.id
+		 FROM projects p
+		 JOIN issues i ON i.id = COALESCE(
+		   (SELECT r.issue_id FROM issue_key_redirects r WHERE r.project_id = p.id AND r.number = $3),
+		   (SELECT o.id FROM issues o WHERE o.project_id = p.id AND o.number = $3)
+		 )
+		 JOIN projects cur ON cur.id = i.project_id
+		 WHERE p.workspace_id = $1
+		   AND p.key = $2
+		   AND cur.archived_at IS NULL
+		   AND i.archived_at IS NULL`,
+		workspaceID, projectKey, number,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", ErrNotFound
+		}
+		return "", "", fmt.Errorf("resolve issue key: %w", err)
+	}
+	return row.ProjectID, row.IssueID, nil
+}
+
+// getIssueDetail loads an issue with the extras shown on its detail view.
+func getIssueDetail(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issue, error) {
+	issue, err := getIssue(ctx, db, projectID, issueID)
+	if err != nil {
+		return Issue{}, err
+	}
+	rollup, err := getRollup(ctx, db, issue.ID)
+	if err != nil {
+		return Issue{}, err
+	}
+	issue.Rollup = &rollup
+	if issue.Links, err = listLinks(ctx, db, issue.ID, ""); err != nil {
+		return Issue{}, err
+	}
+	return withExtras(ctx, db, issue)
+}
+
 func listIssues(ctx context.Context, db *sqlx.DB, params ListParams) ([]Issue, error) {
-	query := `SELECT ` + issueCols + `
-		 FROM issues
-		 WHERE project_id = $1
-		   AND archived_at IS NULL`
-	args := []any{params.ProjectID}
+	args := []any{}
+	bind := func(arg any) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where, err := listWhere(ctx, db, params, bind)
+	if err != nil {
+		return nil, err
+	}
+	order := ` ORDER BY status_id, status_position ASC`
+	if params.Sort != "" {
+		expr, _ := sortExpr(params.Sort, params.Desc)
+		order = orderBy(expr, params.Desc)
+	}
+
+	issues := []Issue{}
+	if err := db.SelectContext(ctx, &issues, `SELECT `+issueCols+` FROM issues`+where+order, args...); err != nil {
+		return nil, fmt.Errorf("list issues: %w", err)
+	}
+	if err := attachExtras(ctx, db, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
 
+func listIssuePage(ctx context.Context, db *sqlx.DB, params ListParams, after *cursor) (Page, error) {
+	args := []any{}
+	bind := func(arg any) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where, err := listWhere(ctx, db, params, bind)
+	if err != nil {
+		return Page{}, err
+	}
+	page := Page{Issues: []Issue{}}
+	if err := db.GetContext(ctx, &page.Total, `SELECT COUNT(*) FROM issues`+where, args...); err != nil {
+		return Page{}, fmt.Errorf("count issues: %w", err)
+	}
+
+	expr, cast := sortExpr(params.Sort, params.Desc)
+	if after != nil {
+		op := ">"
+		if params.Desc {
+			op = "<"
+		}
+		if expr == "" {
+			where += " AND number " + op + " " + bind(after.Number)
+		} else {
+			where += " AND (" + expr + ", number) " + op + " (" + bind(after.Value) + "::" + cast + ", " + bind(after.Number) + ")"
+		}
+	}
+	// One extra row tells whether another page follows.
+	query := `SELECT ` + issueCols + ` FROM issues` + where + orderBy(expr, params.Desc) + ` LIMIT ` + bind(params.Limit+1)
+	if err := db.SelectContext(ctx, &page.Issues, query, args...); err != nil {
+		return Page{}, fmt.Errorf("list issues: %w", err)
+	}
+	if len(page.Issues) > params.Limit {
+		page.Issues = page.Issues[:params.Limit]
+		page.NextCursor = encodeCursor(params.Sort, params.Desc, page.Issues[params.Limit-1])
+	}
+	if err := attachExtras(ctx, db, page.Issues); err != nil {
+		return Page{}, err
+	}
+	return page, nil
+}
+
+// listWhere builds the WHERE clause shared by listIssues and listIssuePage.
+func listWhere(ctx context.Context, db *sqlx.DB, params ListParams, bind func(arg any) string) (string, error) {
+	where := ` WHERE project_id = ` + bind(params.ProjectID)
+	if !params.IncludeArchived {
+		where += ` AND archived_at IS NULL`
+	}
 	if params.StatusID != "" {
-		args = append(args, params.StatusID)
-		query += fmt.Sprintf(" AND status_id = $%d", len(args))
+		where += " AND status_id = " + bind(params.StatusID)
+	}
+	if params.StatusIDs != nil {
+		where += " AND status_id = ANY(" + bind(pq.Array(params.StatusIDs)) + "::uuid[])"
 	}
 	if params.AssigneeID != "" {
-		args = append(args, params.AssigneeID)
-		query += fmt.Sprintf(" AND assignee_id = $%d", len(args))
+		where += " AND assignee_id = " + bind(params.AssigneeID)
+	}
+	if params.Unassigned {
+		where += " AND assignee_id IS NULL"
+	}
+	if len(params.IssueTypeIDs) > 0 {
+		where += " AND issue_type_id = ANY(" + bind(pq.Array(params.IssueTypeIDs)) + "::uuid[])"
+	}
+	if len(params.Priorities) > 0 {
+		where += " AND priority = ANY(" + bind(pq.Array(params.Priorities)) + ")"
+	}
+	if len(params.ReporterIDs) > 0 {
+		where += " AND reporter_id = ANY(" + bind(pq.Array(params.ReporterIDs)) + "::uuid[])"
+	}
+	if len(params.ParentIssueIDs) > 0 {
+		where += " AND parent_issue_id = ANY(" + bind(pq.Array(params.ParentIssueIDs)) + "::uuid[])"
+	}
+	if params.DueBefore != nil {
+		where += " AND due_date <= " + bind(*params.DueBefore)
+	}
+	if params.DueAfter != nil {
+		where += " AND due_date >= " + bind(*params.DueAfter)
+	}
+	if len(params.Labels) > 0 {
+		names := make([]string, len(params.Labels))
+		for i, label := range params.Labels {
+			names[i] = strings.ToLower(strings.TrimSpace(label))
+		}
+		labels := bind(pq.Array(names))
+		where += ` AND EXISTS (
+			SELECT 1
+			FROM issue_labels il
+			JOIN labels l ON l.id = il.label_id
+			WHERE il.issue_id = issues.id
+			  AND (l.id::text = ANY(` + labels + `) OR lower(l.name) = ANY(` + labels + `)))`
+	}
+	if len(params.Fields) > 0 {
+		cond, err := fieldFilterSQL(ctx, db, params.ProjectID, params.Fields, bind)
+		if err != nil {
+			return "", err
+		}
+		where += cond
+	}
+	if params.Condition != nil {
+		if cond := params.Condition(bind); cond != "" {
+			where += " AND (" + cond + ")"
+		}
+	}
+	return where, nil
+}
+
+// sortExpr returns the SQL expression for a sort key and the type its cursor
+// value is cast to. Number sorts need no expression besides the tie-breaker.
+func sortExpr(sort string, desc bool) (expr, cast string) {
+	switch sort {
+	case SortCreated:
+		return "created_at", "timestamptz"
+	case SortUpdated:
+		return "updated_at", "timestamptz"
+	case SortPriority:
+		return "CASE priority WHEN 'low' THEN 0 WHEN 'medium' THEN 1 WHEN 'high' THEN 2 ELSE 3 END", "int"
+	case SortDue:
+		// Must match dueSortValue: issues without a due date come last.
+		if desc {
+			return "COALESCE(due_date, '-infinity'::date)", "date"
+		}
+		return "COALESCE(due_date, 'infinity'::date)", "date"
+	}
+	return "", ""
+}
+
+func orderBy(expr string, desc bool) string {
+	dir := " ASC"
+	if desc {
+		dir = " DESC"
+	}
+	if expr == "" {
+		return " ORDER BY number" + dir
+	}
+	return " ORDER BY " + expr + dir + ", number" + dir
+}
+
+func updateIssue(ctx context.Context, db *sqlx.DB, params UpdateParams) (Issue, error) {
+	var issue Issue
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit update issue", func(tx *sqlx.Tx) error {
+		before, err := lockIssue(ctx, tx, params.ProjectID, params.IssueID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE issues
+			 SET title       = $1,
+			     description = $2,
+			     priority    = $3,
+			     assignee_id = $4,
+			     due_date    = $5
+			 WHERE id = $6
+			   AND project_id = $7
+			 RETURNING `+issueCols,
+			params.Title, params.Description, params.Priority, params.AssigneeID, params.DueDate,
+			params.IssueID, params.ProjectID,
+		).StructScan(&issue); err != nil {
+			return fmt.Errorf("update issue: %w", err)
+		}
+
+		changes := diffIssues(before, issue)
+		fieldChanges, err := saveFieldValues(ctx, tx, issue, params.Fields)
+		if err != nil {
+			return err
+		}
+		maps.Copy(changes, fieldChanges)
+		if len(changes) == 0 {
+			return nil
+		}
+		return recordEvent(ctx, tx, issue.ID, eventActor(ctx, ""), EventUpdated, map[string]any{
+			"changes": changes,
+		})
+	}); err != nil {
+		return Issue{}, err
+	}
+	return withExtras(ctx, db, issue)
+}
+
+// lockIssue loads an active issue for update.
+func lockIssue(ctx context.Context, tx *sqlx.Tx, projectID, issueID string) (Issue, error) {
+	var issue Issue
+	if err := tx.GetContext(ctx, &issue,
+		`SELECT `+issueCols+`
+		 FROM issues
+		 WHERE id = $1
+		   AND project_id = $2
+		   AND archived_at IS NULL
+		 FOR UPDATE`,
+		issueID, projectID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Issue{}, ErrNotFound
+		}
+		return Issue{}, fmt.Errorf("load issue for update: %w", err)
 	}
+	return issue, nil
+}
 
-	query += ` ORDER BY status_id, status_position ASC`
+// integrityError turns exceptions raised by the validate_issue_integrity
+// trigger into ErrIntegrity so that callers can report them as client errors.
+func integrityError(err error) error {
+	if msg, ok := pgutil.RaisedException(err); ok {
+		return fmt.Errorf("%w: %s", ErrIntegrity, msg)
+	}
+	return err
+}
+
+func setParent(ctx context.Context, db *sqlx.DB, params ParentParams) (Issue, error) {
+	var issue Issue
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit set issue parent", func(tx *sqlx.Tx) error {
+		before, err := lockIssue(ctx, tx, params.ProjectID, params.IssueID)
+		if err != nil {
+			return err
+		}
+
+		var parentID *string
+		if params.ParentIssueID != "" {
+			var parentExists bool
+			if err := tx.GetContext(ctx, &parentExists,
+				`SELECT EXISTS(
+					SELECT 1 FROM issues
+					WHERE id = $1 AND project_id = $2 AND archived_at IS NULL
+				)`,
+				params.ParentIssueID, params.ProjectID,
+			); err != nil {
+				return fmt.Errorf("check parent issue exists: %w", err)
+			}
+			if !parentExists {
+				return ErrParentNotFound
+			}
+			parentID = &params.ParentIssueID
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE issues
+			 SET parent_issue_id = $1
+			 WHERE id = $2
+			   AND project_id = $3
+			 RETURNING `+issueCols,
+			parentID, params.IssueID, params.ProjectID,
+		).StructScan(&issue); err != nil {
+			return integrityError(fmt.Errorf("set issue parent: %w", err))
+		}
+
+		changes := diffIssues(before, issue)
+		if len(changes) == 0 {
+			return nil
+		}
+		return recordEvent(ctx, tx, issue.ID, eventActor(ctx, ""), EventUpdated, map[string]any{
+			"changes": changes,
+		})
+	}); err != nil {
+		return Issue{}, err
+	}
+	return issue, nil
+}
+
+func listChildren(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Issue, error) {
+	if _, err := getIssue(ctx, db, projectID, issueID); err != nil {
+		return nil, err
+	}
+	children := []Issue{}
+	if err := db.SelectContext(ctx, &children,
+		`SELECT `+issueCols+`
+		 FROM issues
+		 WHERE parent_issue_id = $1
+		   AND project_id = $2
+		   AND archived_at IS NULL
+		 ORDER BY number ASC`,
+		issueID, projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list child issues: %w", err)
+	}
+	return children, nil
+}
+
+// listAncestors walks parent links upwards; the trigger rules out cycles.
+func listAncestors(ctx context.Context, db *sqlx.DB, projectID, issueID string) ([]Issue, error) {
+	if _, err := getIssue(ctx, db, projectID, issueID); err != nil {
+		return nil, err
+	}
+	ancestors := []Issue{}
+	if err := db.SelectContext(ctx, &ancestors,
+		`WITH RECURSIVE chain (ancestor_id, depth) AS (
+		   SELECT parent_issue_id, 1
+		   FROM issues
+		   WHERE id = $1 AND project_id = $2 AND parent_issue_id IS NOT NULL
+		   UNION ALL
+		   SELECT p.parent_issue_id, c.depth + 1
+		   FROM issues p
+		   JOIN chain c ON c.ancestor_id = p.id
+		   WHERE p.parent_issue_id IS NOT NULL
+		 )
+		 SELECT `+issueCols+`
+		 FROM issues
+		 JOIN chain ON chain.ancestor_id = issues.id
+		 ORDER BY chain.depth ASC`,
+		issueID, projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list issue ancestors: %w", err)
+	}
+	return ancestors, nil
+}
+
+func getRollup(ctx context.Context, db *sqlx.DB, issueID string) (Rollup, error) {
+	var rollup Rollup
+	if err := db.GetContext(ctx, &rollup,
+		`SELECT COUNT(*)                                    AS total,
+		        COUNT(*) FILTER (WHERE s.category = 'todo')  AS todo,
+		        COUNT(*) FILTER (WHERE s.category = 'doing') AS doing,
+		        COUNT(*) FILTER (WHERE s.category = 'done')  AS done
+		 FROM issues i
+		 JOIN statuses s ON s.id = i.status_id
+		 WHERE i.parent_issue_id = $1
+		   AND i.archived_at IS NULL`,
+		issueID,
+	); err != nil {
+		return Rollup{}, fmt.Errorf("get issue rollup: %w", err)
+	}
+	return rollup, nil
+}
+
+// attachExtras loads the labels and custom field values of the given issues.
+// Issues share their backing array with the caller, so results are visible there.
+func attachExtras(ctx context.Context, db *sqlx.DB, issues []Issue) error {
+	if err := attachLabels(ctx, db, issues); err != nil {
+		return err
+	}
+	return attachFieldValues(ctx, db, issues)
+}
+
+// withExtras returns a copy of issue with its labels and custom field values
+// loaded.
+func withExtras(ctx context.Context, db *sqlx.DB, issue Issue) (Issue, error) {
+	list := []Issue{issue}
+	if err := attachExtras(ctx, db, list); err != nil {
+		return Issue{}, err
+	}
+	return list[0], nil
+}
+
+// attachLabels loads the labels of the given issues in a single query.
+func attachLabels(ctx context.Context, db *sqlx.DB, issues []Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	var rows []struct {
+		IssueID string `db:"issue_id"`
+		Label
+	}
+	if err := db.SelectContext(ctx, &rows,
+		`SELECT il.issue_id, l.id, l.name, l.color
+		 FROM issue_labels il
+		 JOIN labels l ON l.id = il.label_id
+		 WHERE il.issue_id = ANY($1::uuid[])
+		 ORDER BY lower(l.name) ASC`,
+		pq.Array(ids),
+	); err != nil {
+		return fmt.Errorf("list issue labels: %w", err)
+	}
+	byIssue := make(map[string][]Label, len(issues))
+	for _, row := range rows {
+		byIssue[row.IssueID] = append(byIssue[row.IssueID], row.Label)
+	}
+	for i := range issues {
+		issues[i].Labels = byIssue[issues[i].ID]
+	}
+	return nil
+}
+
+// attachFieldValues loads the custom field values of the given issues.
+// Values of archived fields are left out.
+func attachFieldValues(ctx context.Context, db *sqlx.DB, issues []Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	var rows []struct {
+		IssueID string          `db:"issue_id"`
+		FieldID string          `db:"field_id"`
+		Value   json.RawMessage `db:"value"`
+	}
+	if err := db.SelectContext(ctx, &rows,
+		`SELECT v.issue_id, v.field_id, v.value
+		 FROM issue_field_values v
+		 JOIN custom_fields f ON f.id = v.field_id
+		 WHERE v.issue_id = ANY($1::uuid[])
+		   AND f.archived_at IS NULL`,
+		pq.Array(ids),
+	); err != nil {
+		return fmt.Errorf("list issue field values: %w", err)
+	}
+	byIssue := make(map[string]Fields, len(issues))
+	for _, row := range rows {
+		if byIssue[row.IssueID] == nil {
+			byIssue[row.IssueID] = Fields{}
+		}
+		byIssue[row.IssueID][row.FieldID] = row.Value
+	}
+	for i := range issues {
+		issues[i].Fields = byIssue[issues[i].ID]
+	}
+	return nil
+}
+
+// loadFields returns the active custom field definitions of a project among
+// ids, keyed by ID. Unknown IDs are simply missing from the result.
+func loadFields(ctx context.Context, q sqlx.QueryerContext, projectID string, ids []string) (map[string]customfields.Field, error) {
+	defs := []customfields.Field{}
+	if err := sqlx.SelectContext(ctx, q, &defs,
+		`SELECT `+customfields.FieldCols+`
+		 FROM custom_fields f
+		 WHERE f.project_id = $1
+		   AND f.archived_at IS NULL
+		   AND f.id::text = ANY($2)`,
+		projectID, pq.Array(ids),
+	); err != nil {
+		return nil, fmt.Errorf("load custom fields: %w", err)
+	}
+	byID := make(map[string]customfields.Field, len(defs))
+	for _, def := range defs {
+		byID[def.ID] = def
+	}
+	return byID, nil
+}
+
+// saveFieldValues checks custom field values against their definitions and
+// writes them for the issue. It returns the changed values keyed as
+// "fields.<id>" for the activity log.
+func saveFieldValues(ctx context.Context, tx *sqlx.Tx, issue Issue, values Fields) (map[string]FieldChange, error) {
+	changes := map[string]FieldChange{}
+	if len(values) == 0 {
+		return changes, nil
+	}
+	ids := slices.Sorted(maps.Keys(values))
+	defs, err := loadFields(ctx, tx, issue.ProjectID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make(Fields, len(ids))
+	userIDs := []string{}
+	for _, id := range ids {
+		def, ok := defs[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %s", customfields.ErrInvalidValue, id)
+		}
+		if !def.AppliesTo(issue.IssueTypeID) {
+			return nil, fmt.Errorf("%w: %s does not apply to this issue type", customfields.ErrInvalidValue, def.Name)
+		}
+		value, err := def.Normalize(values[id])
+		if err != nil {
+			return nil, err
+		}
+		if value != nil && def.Type == customfields.TypeUser {
+			var userID string
+			if err := json.Unmarshal(value, &userID); err != nil {
+				return nil, fmt.Errorf("decode user field value: %w", err)
+			}
+			userIDs = append(userIDs, userID)
+		}
+		normalized[id] = value
+	}
+	if err := checkFieldUsers(ctx, tx, issue.ProjectID, userIDs); err != nil {
+		return nil, err
+	}
+
+	var current []struct {
+		FieldID string          `db:"field_id"`
+		Value   json.RawMessage `db:"value"`
+	}
+	if err := tx.SelectContext(ctx, &current,
+		`SELECT field_id, value
+		 FROM issue_field_values
+		 WHERE issue_id = $1
+		   AND field_id::text = ANY($2)`,
+		issue.ID, pq.Array(ids),
+	); err != nil {
+		return nil, fmt.Errorf("load issue field values: %w", err)
+	}
+	before := make(Fields, len(current))
+	for _, row := range current {
+		before[row.FieldID] = row.Value
+	}
+
+	for _, id := range ids {
+		from, to := before[id], normalized[id]
+		if sameJSON(from, to) {
+			continue
+		}
+		if to == nil {
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM issue_field_values WHERE issue_id = $1 AND field_id = $2`,
+				issue.ID, id,
+			); err != nil {
+				return nil, fmt.Errorf("clear issue field value: %w", err)
+			}
+		} else if _, err := tx.ExecContext(ctx,
+			`INSERT INTO issue_field_values (issue_id, field_id, value)
+			 VALUES ($1, $2, $3)
+			 ON CONFLICT (issue_id, field_id) DO UPDATE SET value = EXCLUDED.value`,
+			issue.ID, id, []byte(to),
+		); err != nil {
+			return nil, fmt.Errorf("set issue field value: %w", err)
+		}
+		changes["fields."+id] = FieldChange{From: from, To: to}
+	}
+	return changes, nil
+}
+
+// checkFieldUsers verifies that user field values reference active members
+// of the project's workspace.
+func checkFieldUsers(ctx context.Context, tx *sqlx.Tx, projectID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+	var found int
+	if err := tx.GetContext(ctx, &found,
+		`SELECT COUNT(DISTINCT wm.user_id)
+		 FROM workspace_members wm
+		 JOIN projects p ON p.workspace_id = wm.workspace_id
+		 JOIN app_users u ON u.id = wm.user_id
+		 WHERE p.id = $1
+		   AND wm.archived_at IS NULL
+		   AND u.archived_at IS NULL
+		   AND wm.user_id = ANY($2::uuid[])`,
+		projectID, pq.Array(userIDs),
+	); err != nil {
+		return fmt.Errorf("check user field values: %w", err)
+	}
+	if found != len(userIDs) {
+		return fmt.Errorf("%w: user fields must reference workspace members", customfields.ErrInvalidValue)
+	}
+	return nil
+}
+
+// sameJSON reports whether two stored values are equal, ignoring the
+// whitespace that jsonb adds on output.
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// fieldFilterSQL compiles custom field filters for listIssues.
+func fieldFilterSQL(ctx context.Context, db *sqlx.DB, projectID string, filters map[string][]string, bind func(arg any) string) (string, error) {
+	ids := slices.Sorted(maps.Keys(filters))
+	defs, err := loadFields(ctx, db, projectID, ids)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, id := range ids {
+		def, ok := defs[id]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown field %s", customfields.ErrInvalidValue, id)
+		}
+		values, err := def.ParseFilter(filters[id])
+		if err != nil {
+			return "", err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		var match string
+		switch def.Type {
+		case customfields.TypeText:
+			patterns := make([]string, len(values))
+			for i, v := range values {
+				patterns[i] = "%" + escapeLike(v) + "%"
+			}
+			match = `(fv.value #>> '{}') ILIKE ANY(` + bind(pq.Array(patterns)) + `)`
+		case customfields.TypeNumber:
+			match = `(fv.value #>> '{}')::numeric = ANY(` + bind(pq.Array(values)) + `::numeric[])`
+		case customfields.TypeDate:
+			match = `(fv.value #>> '{}')::date = ANY(` + bind(pq.Array(values)) + `::date[])`
+		case customfields.TypeMultiSelect:
+			match = `EXISTS (SELECT 1 FROM jsonb_array_elements_text(fv.value) e WHERE e = ANY(` + bind(pq.Array(values)) + `))`
+		default:
+			match = `(fv.value #>> '{}') = ANY(` + bind(pq.Array(values)) + `)`
+		}
+		sb.WriteString(` AND EXISTS (
+			SELECT 1 FROM issue_field_values fv
+			WHERE fv.issue_id = issues.id
+			  AND fv.field_id = ` + bind(id) + `
+			  AND ` + match + `)`)
+	}
+	return sb.String(), nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// linkRow is a stored link read from one of its ends.
+type linkRow struct {
+	Link
+	LinkType string `db:"link_type"`
+	Outward  bool   `db:"outward"`
+}
+
+func createLink(ctx context.Context, db *sqlx.DB, params CreateLinkParams) (Link, error) {
+	linkType, inward, _ := storedLinkType(params.Type)
+	sourceID, targetID := params.IssueID, params.TargetIssueID
+	if inward {
+		sourceID, targetID = targetID, sourceID
+	}
+	var createdBy *string
+	if actor := eventActor(ctx, params.CreatedBy); actor != "" {
+		createdBy = &actor
+	}
+
+	var linkID string
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit create issue link", func(tx *sqlx.Tx) error {
+		if _, err := lockIssue(ctx, tx, params.ProjectID, params.IssueID); err != nil {
+			return err
+		}
+		var targetExists bool
+		if err := tx.GetContext(ctx, &targetExists,
+			`SELECT EXISTS(
+				SELECT 1
+				FROM issues t
+				JOIN projects tp ON tp.id = t.project_id
+				JOIN projects sp ON sp.workspace_id = tp.workspace_id
+				WHERE t.id = $1
+				  AND t.archived_at IS NULL
+				  AND tp.archived_at IS NULL
+				  AND sp.id = $2
+			)`,
+			params.TargetIssueID, params.ProjectID,
+		); err != nil {
+			return fmt.Errorf("check link target exists: %w", err)
+		}
+		if !targetExists {
+			return ErrLinkTargetNotFound
+		}
+		if err := tx.GetContext(ctx, &linkID,
+			`INSERT INTO issue_links (source_issue_id, target_issue_id, link_type, created_by)
+			 VALUES ($1, $2, $3, $4)
+			 RETURNING id`,
+			sourceID, targetID, linkType, createdBy,
+		); err != nil {
+			if pgutil.IsUniqueViolation(err) {
+				return ErrLinkExists
+			}
+			return fmt.Errorf("insert issue link: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return Link{}, err
+	}
+
+	links, err := listLinks(ctx, db, params.IssueID, linkID)
+	if err != nil {
+		return Link{}, err
+	}
+	if len(links) == 0 {
+		return Link{}, ErrLinkNotFound
+	}
+	return links[0], nil
+}
+
+// listLinks returns the links of an issue, optionally narrowed to one link.
+func listLinks(ctx context.Context, db *sqlx.DB, issueID, linkID string) ([]Link, error) {
+	rows := []linkRow{}
+	if err := db.SelectContext(ctx, &rows,
+		`SELECT l.id, l.link_type, l.source_issue_id = $1 AS outward, l.created_by, l.created_at,
+		        i.id            AS "issue.id",
+		        i.project_id    AS "issue.project_id",
+		        p.key || '-' || i.number AS "issue.key",
+		        i.number        AS "issue.number",
+		        i.title         AS "issue.title",
+		        i.priority      AS "issue.priority",
+		        s.id            AS "issue.status_id",
+		        s.name          AS "issue.status_name",
+		        s.category      AS "issue.status_category"
+		 FROM issue_links l
+		 JOIN issues i
+		   ON i.id = CASE WHEN l.source_issue_id = $1 THEN l.target_issue_id ELSE l.source_issue_id END
+		 JOIN projects p ON p.id = i.project_id
+		 JOIN statuses s ON s.id = i.status_id
+		 WHERE (l.source_issue_id = $1 OR l.target_issue_id = $1)
+		   AND ($2 = '' OR l.id::text = $2)
+		   AND i.archived_at IS NULL
+		   AND p.archived_at IS NULL
+		 ORDER BY l.created_at ASC, l.id ASC`,
+		issueID, linkID,
+	); err != nil {
+		return nil, fmt.Errorf("list issue links: %w", err)
+	}
+	links := make([]Link, 0, len(rows))
+	for _, row := range rows {
+		link := row.Link
+		link.Type = row.LinkType
+		if !row.Outward {
+			link.Type = inwardLinkTypes[row.LinkType]
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+func deleteLink(ctx context.Context, db *sqlx.DB, projectID, issueID, linkID string) error {
+	if _, err := getIssue(ctx, db, projectID, issueID); err != nil {
+		return err
+	}
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM issue_links
+		 WHERE id = $1
+		   AND (source_issue_id = $2 OR target_issue_id = $2)`,
+		linkID, issueID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete issue link: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete issue link rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+func archiveIssue(ctx context.Context, db *sqlx.DB, projectID, issueID string) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit archive issue", func(tx *sqlx.Tx) error {
+		return archiveInTx(ctx, tx, projectID, issueID)
+	})
+}
+
+func archiveInTx(ctx context.Context, tx *sqlx.Tx, projectID, issueID string) error {
+	res, err := tx.ExecContext(ctx,
+		`UPDATE issues
+		 SET archived_at = NOW()
+		 WHERE id = $1
+		   AND project_id = $2
+		   AND archived_at IS NULL`,
+		issueID, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("archive issue: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("archive issue rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return recordEvent(ctx, tx, issueID, eventActor(ctx, ""), EventArchived, map[string]any{})
+}
+
+type transferNode struct {
+	ID            string  `db:"id"`
+	Number        int     `db:"number"`
+	ParentIssueID *string `db:"parent_issue_id"`
+	Archived      bool    `db:"archived"`
+	IssueTypeID   string  `db:"issue_type_id"`
+	TypeName      string  `db:"type_name"`
+	StatusID      string  `db:"status_id"`
+	StatusName    string  `db:"status_name"`
+}
+
+type transferType struct {
+	namedRow
+	Level           int     `db:"level"`
+	DefaultStatusID *string `db:"default_status_id"`
+}
+
+type namedRow struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+func transferIssue(ctx context.Context, db *sqlx.DB, params TransferParams) (Issue, error) {
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit transfer issue", func(tx *sqlx.Tx) error {
+		var keys struct {
+			Source string `db:"source_key"`
+			Target string `db:"target_key"`
+		}
+		if err := tx.GetContext(ctx, &keys,
+			`SELECT s.key AS source_key, t.key AS target_key
+			 FROM projects s
+			 JOIN projects t ON t.workspace_id = s.workspace_id
+			 WHERE s.id = $1
+			   AND t.id::text = $2
+			   AND t.archived_at IS NULL`,
+			params.ProjectID, params.TargetProjectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTargetProjectNotFound
+			}
+			return fmt.Errorf("load transfer projects: %w", err)
+		}
+
+		root, err := lockIssue(ctx, tx, params.ProjectID, params.IssueID)
+		if err != nil {
+			return err
+		}
+		// Archived descendants move too, or restoring them later would break
+		// the same-project rule for parents.
+		var tree []transferNode
+		if err := tx.SelectContext(ctx, &tree,
+			`WITH RECURSIVE tree AS (
+			   SELECT id, 0 AS depth FROM issues WHERE id = $1
+			   UNION ALL
+			   SELECT c.id, tree.depth + 1
+			   FROM issues c
+			   JOIN tree ON c.parent_issue_id = tree.id
+			 )
+			 SELECT i.id, i.number, i.parent_issue_id, i.archived_at IS NOT NULL AS archived,
+			        i.issue_type_id, it.name AS type_name,
+			        i.status_id, s.name AS status_name
+			 FROM tree
+			 JOIN issues i ON i.id = tree.id
+			 JOIN issue_types it ON it.id = i.issue_type_id
+			 JOIN statuses s ON s.id = i.status_id
+			 ORDER BY tree.depth, i.number
+			 FOR UPDATE OF i`,
+			root.ID,
+		); err != nil {
+			return fmt.Errorf("load issue tree: %w", err)
+		}
+
+		var targetTypes []transferType
+		if err := tx.SelectContext(ctx, &targetTypes,
+			`SELECT id, name, level, default_status_id
+			 FROM issue_types
+			 WHERE project_id = $1 AND archived_at IS NULL
+			 ORDER BY level, name`,
+			params.TargetProjectID,
+		); err != nil {
+			return fmt.Errorf("load target issue types: %w", err)
+		}
+		var targetStatuses []namedRow
+		if err := tx.SelectContext(ctx, &targetStatuses,
+			`SELECT id, name FROM statuses WHERE project_id = $1 AND archived_at IS NULL ORDER BY position`,
+			params.TargetProjectID,
+		); err != nil {
+			return fmt.Errorf("load target statuses: %w", err)
+		}
+		typeRows := make([]namedRow, len(targetTypes))
+		for i, t := range targetTypes {
+			typeRows[i] = t.namedRow
+		}
+		typeOf, statusOf := map[string]string{}, map[string]string{}
+		for _, node := range tree {
+			typeID, typeErr := mapByName("issue type", node.IssueTypeID, node.TypeName, params.TypeMap, typeRows)
+			statusID, statusErr := mapByName("status", node.StatusID, node.StatusName, params.StatusMap, targetStatuses)
+			if node.Archived {
+				// Archived issues are hidden from the caller, so their types
+				// and statuses are not expected in the maps.
+				if _, ok := params.TypeMap[node.IssueTypeID]; typeErr != nil && !ok {
+					if typeID, ok = archivedTransferType(targetTypes, typeOf[*node.ParentIssueID]); ok {
+						typeErr = nil
+					} else {
+						typeErr = fmt.Errorf("%w: no issue type in the target project can sit below the parent of archived issue %s-%d",
+							ErrUnmapped, keys.Source, node.Number)
+					}
+				}
+				if _, ok := params.StatusMap[node.StatusID]; statusErr != nil && !ok && typeErr == nil {
+					statusID, statusErr = archivedTransferStatus(targetTypes, targetStatuses, typeID)
+				}
+			}
+			if typeErr != nil {
+				return typeErr
+			}
+			if statusErr != nil {
+				return statusErr
+			}
+			typeOf[node.ID], statusOf[node.ID] = typeID, statusID
+		}
+
+		// New slots go to the end of the target's statuses and backlog.
+		if err := lockBacklog(ctx, tx, params.TargetProjectID); err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(tree))
+		for _, node := range tree {
+			var number int
+			if err := tx.QueryRowxContext(ctx,
+				`INSERT INTO project_issue_counters (project_id, last_number)
+				 VALUES ($1, 1)
+				 ON CONFLICT (project_id)
+				 DO UPDATE SET last_number = project_issue_counters.last_number + 1
+				 RETURNING last_number`,
+				params.TargetProjectID,
+			).Scan(&number); err != nil {
+				return fmt.Errorf("upsert issue counter: %w", err)
+			}
+			// Parents come first in tree order, so children always follow
+			// them into a project they are already in.
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE issues
+				 SET project_id      = $2,
+				     number          = $3,
+				     issue_type_id   = $4,
+				     status_id       = $5,
+				     parent_issue_id = CASE WHEN id = $6 THEN NULL ELSE parent_issue_id END,
+				     sprint_id       = NULL,
+				     status_position = CASE WHEN archived_at IS NULL
+				       THEN (SELECT COALESCE(MAX(status_position), -1) + 1
+				             FROM issues
+				             WHERE project_id = $2 AND status_id = $5 AND archived_at IS NULL)
+				       ELSE status_position END,
+				     backlog_rank    = CASE WHEN archived_at IS NULL
+				       THEN (SELECT COALESCE(MAX(backlog_rank), -1) + 1
+				             FROM issues
+				             WHERE project_id = $2 AND archived_at IS NULL)
+				       ELSE backlog_rank END
+				 WHERE id = $1`,
+				node.ID, params.TargetProjectID, number,
+				typeOf[node.ID], statusOf[node.ID], root.ID,
+			); err != nil {
+				return integrityError(fmt.Errorf("transfer issue: %w", err))
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO issue_key_redirects (project_id, number, issue_id) VALUES ($1, $2, $3)`,
+				params.ProjectID, node.Number, node.ID,
+			); err != nil {
+				return fmt.Errorf("insert issue key redirect: %w", err)
+			}
+			payload := map[string]any{
+				"from_project_id": params.ProjectID,
+				"to_project_id":   params.TargetProjectID,
+				"from_key":        keys.Source + "-" + strconv.Itoa(node.Number),
+				"to_key":          keys.Target + "-" + strconv.Itoa(number),
+			}
+			if node.ID == root.ID && root.ParentIssueID != nil {
+				payload["from_parent_issue_id"] = *root.ParentIssueID
+			}
+			if err := recordEvent(ctx, tx, node.ID, eventActor(ctx, ""), EventTransferred, payload); err != nil {
+				return err
+			}
+			ids = append(ids, node.ID)
+		}
+		return carryProjectData(ctx, tx, ids, params.TargetProjectID)
+	}); err != nil {
+		return Issue{}, err
+	}
+	return getIssueDetail(ctx, db, params.TargetProjectID, params.IssueID)
+}
+
+// mapByName picks the target for a source issue type or status: the
+// explicit mapping if there is one, else the target with the same name.
+func mapByName(kind, sourceID, sourceName string, explicit map[string]string, targets []namedRow) (string, error) {
+	if targetID, ok := explicit[sourceID]; ok {
+		for _, t := range targets {
+			if t.ID == targetID {
+				return t.ID, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %s %q is mapped outside the target project", ErrUnmapped, kind, sourceName)
+	}
+	for _, t := range targets {
+		if strings.EqualFold(t.Name, sourceName) {
+			return t.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no %s named %q in the target project", ErrUnmapped, kind, sourceName)
+}
+
+// archivedTransferType picks the issue type for an archived issue whose type
+// has no counterpart in the target project: the lowest-level type that can
+// sit below the parent's new type.
+func archivedTransferType(targets []transferType, parentTypeID string) (string, bool) {
+	parentLevel := -1
+	for _, t := range targets {
+		if t.ID == parentTypeID {
+			parentLevel = t.Level
+		}
+	}
+	for _, t := range targets {
+		if t.Level > parentLevel {
+			return t.ID, true
+		}
+	}
+	return "", false
+}
+
+// archivedTransferStatus picks the status for an archived issue whose status
+// has no counterpart in the target project: the default status of its new
+// type, else the target's first status.
+func archivedTransferStatus(types []transferType, statuses []namedRow, typeID string) (string, error) {
+	for _, t := range types {
+		if t.ID != typeID || t.DefaultStatusID == nil {
+			continue
+		}
+		for _, s := range statuses {
+			if s.ID == *t.DefaultStatusID {
+				return s.ID, nil
+			}
+		}
+	}
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("%w: the target project has no statuses", ErrUnmapped)
+	}
+	return statuses[0].ID, nil
+}
+
+// carryProjectData swaps the labels and custom field values of transferred
+// issues for the target project's ones with the same name, dropping the
+// rest. Field values also need the same type and, for selects, valid options.
+func carryProjectData(ctx context.Context, tx *sqlx.Tx, issueIDs []string, targetProjectID string) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO issue_labels (issue_id, label_id)
+		 SELECT il.issue_id, tl.id
+		 FROM issue_labels il
+		 JOIN labels sl ON sl.id = il.label_id
+		 JOIN labels tl ON tl.project_id = $2 AND lower(tl.name) = lower(sl.name)
+		 WHERE il.issue_id = ANY($1::uuid[])
+		   AND sl.project_id <> $2
+		 ON CONFLICT DO NOTHING`,
+		pq.Array(issueIDs), targetProjectID,
+	); err != nil {
+		return fmt.Errorf("carry issue labels: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM issue_labels il
+		 USING labels l
+		 WHERE l.id = il.label_id
+		   AND il.issue_id = ANY($1::uuid[])
+		   AND l.project_id <> $2`,
+		pq.Array(issueIDs), targetProjectID,
+	); err != nil {
+		return fmt.Errorf("drop source labels: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO issue_field_values (issue_id, field_id, value)
+		 SELECT v.issue_id, tf.id, v.value
+		 FROM issue_field_values v
+		 JOIN custom_fields sf ON sf.id = v.field_id
+		 JOIN custom_fields tf ON tf.project_id = $2
+		                      AND tf.archived_at IS NULL
+		                      AND lower(tf.name) = lower(sf.name)
+		                      AND tf.field_type = sf.field_type
+		 WHERE v.issue_id = ANY($1::uuid[])
+		   AND sf.project_id <> $2
+		   AND (tf.field_type NOT IN ('single_select', 'multi_select')
+		        OR NOT EXISTS (
+		          SELECT 1
+		          FROM jsonb_array_elements_text(
+		            CASE WHEN jsonb_typeof(v.value) = 'array' THEN v.value ELSE jsonb_build_array(v.value) END
+		          ) AS o(option)
+		          WHERE NOT o.option = ANY(tf.options)))
+		 ON CONFLICT DO NOTHING`,
+		pq.Array(issueIDs), targetProjectID,
+	); err != nil {
+		return fmt.Errorf("carry issue field values: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM issue_field_values v
+		 USING custom_fields f
+		 WHERE f.id = v.field_id
+		   AND v.issue_id = ANY($1::uuid[])
+		   AND f.project_id <> $2`,
+		pq.Array(issueIDs), targetProjectID,
+	); err != nil {
+		return fmt.Errorf("drop source field values: %w", err)
+	}
+	return nil
+}
 
+func listTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Issue, error) {
 	issues := []Issue{}
-	if err := db.SelectContext(ctx, &issues, query, args...); err != nil {
-		return nil, fmt.Errorf("list issues: %w", err)
+	if err := db.SelectContext(ctx, &issues,
+		`SELECT `+issueCols+`
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NOT NULL
+		 ORDER BY archived_at DESC, number DESC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list archived issues: %w", err)
+	}
+	if err := attachExtras(ctx, db, issues); err != nil {
+		return nil, err
 	}
 	return issues, nil
 }
 
-func updateIssue(ctx context.Context, db *sqlx.DB, params UpdateParams) (Issue, error) {
+func restoreIssue(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issue, error) {
 	var issue Issue
-	err := db.QueryRowxContext(ctx,
-		`UPDATE issues
-		 SET title       = $1,
-		     description = $2,
-		     priority    = $3,
-		     assignee_id = $4,
-		     due_date    = $5
-		 WHERE id = $6
-		   AND project_id = $7
-		   AND archived_at IS NULL
-		 RETURNING `+issueCols,
-		params.Title, params.Description, params.Priority, params.AssigneeID, params.DueDate,
-		params.IssueID, params.ProjectID,
-	).StructScan(&issue)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return Issue{}, ErrNotFound
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit restore issue", func(tx *sqlx.Tx) error {
+		// The issue takes new slots at the end of its status and of the
+		// backlog, so it has to wait for moves and reorders in flight.
+		if err := lockBacklog(ctx, tx, projectID); err != nil {
+			return err
+		}
+		var archived Issue
+		if err := tx.GetContext(ctx, &archived,
+			`SELECT `+issueCols+`
+			 FROM issues
+			 WHERE id = $1
+			   AND project_id = $2
+			   AND archived_at IS NOT NULL
+			 FOR UPDATE`,
+			issueID, projectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("load archived issue: %w", err)
 		}
-		return Issue{}, fmt.Errorf("update issue: %w", err)
+		var active struct {
+			Status    bool `db:"status_active"`
+			IssueType bool `db:"issue_type_active"`
+		}
+		if err := tx.GetContext(ctx, &active,
+			`SELECT (SELECT archived_at IS NULL FROM statuses WHERE id = $1)    AS status_active,
+			        (SELECT archived_at IS NULL FROM issue_types WHERE id = $2) AS issue_type_active`,
+			archived.StatusID, archived.IssueTypeID,
+		); err != nil {
+			return fmt.Errorf("load issue status and type: %w", err)
+		}
+		if !active.Status {
+			return ErrStatusArchived
+		}
+		if !active.IssueType {
+			return ErrIssueTypeArchived
+		}
+
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE issues
+			 SET archived_at     = NULL,
+			     status_position = (SELECT COALESCE(MAX(status_position), -1) + 1
+			                        FROM issues
+			                        WHERE project_id = $2 AND status_id = $3 AND archived_at IS NULL),
+			     backlog_rank    = (SELECT COALESCE(MAX(backlog_rank), -1) + 1
+			                        FROM issues
+			                        WHERE project_id = $2 AND archived_at IS NULL)
+			 WHERE id = $1
+			 RETURNING `+issueCols,
+			issueID, projectID, archived.StatusID,
+		).StructScan(&issue); err != nil {
+			return fmt.Errorf("restore issue: %w", err)
+		}
+		return recordEvent(ctx, tx, issueID, eventActor(ctx, ""), EventRestored, map[string]any{})
+	}); err != nil {
+		return Issue{}, err
 	}
-	return issue, nil
+	return withExtras(ctx, db, issue)
 }
 
-func archiveIssue(ctx context.Context, db *sqlx.DB, projectID, issueID string) error {
+func purgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
 	res, err := db.ExecContext(ctx,
-		`UPDATE issues
-		 SET archived_at = NOW()
-		 WHERE id = $1
-		   AND project_id = $2
-		   AND archived_at IS NULL`,
-		issueID, projectID,
+		`DELETE FROM issues WHERE archived_at < $1`,
+		before,
 	)
 	if err != nil {
-		return fmt.Errorf("archive issue: %w", err)
+		return 0, fmt.Errorf("purge archived issues: %w", err)
 	}
 	n, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("archive issue rows affected: %w", err)
+		return 0, fmt.Errorf("purge archived issues rows affected: %w", err)
 	}
-	if n == 0 {
-		return ErrNotFound
-	}
-	return nil
+	return n, nil
 }
 
 type issuePosition struct {
-	StatusID       string `db:"status_id"`
-	StatusPosition int    `db:"status_position"`
+	IssueTypeID    string  `db:"issue_type_id"`
+	AssigneeID     *string `db:"assignee_id"`
+	StatusID       string  `db:"status_id"`
+	StatusPosition int     `db:"status_position"`
 }
 
 // moveIssue persists the move of an issue to a target status/position.
 // It uses a two-phase offset strategy to avoid transient unique index collisions.
 func moveIssue(ctx context.Context, db *sqlx.DB, params MoveParams) error {
-	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
-	if err != nil {
-		return fmt.Errorf("begin tx: %w", err)
-	}
-	defer tx.Rollback()
+	return pgutil.WithTx(ctx, db, &sql.TxOptions{Isolation: sql.LevelReadCommitted}, "begin tx", "commit move issue", func(tx *sqlx.Tx) error {
+		return moveInTx(ctx, tx, params)
+	})
+}
 
+// moveInTx moves one issue inside tx, locking its source and target statuses
+// and their issues first. Bulk moves call it once per issue.
+func moveInTx(ctx context.Context, tx *sqlx.Tx, params MoveParams) error {
 	current, err := getIssuePositionForUpdate(ctx, tx, params.ProjectID, params.IssueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -191,6 +1450,15 @@ func moveIssue(ctx context.Context, db *sqlx.DB, params MoveParams) error {
 		return err
 	}
 
+	if sourceStatusID != targetStatusID {
+		if err := checkTransition(ctx, tx, params.ProjectID, params.IssueID, current, targetStatusID); err != nil {
+			return err
+		}
+		if err := checkWIPLimits(ctx, tx, params.ProjectID, sourceStatusID, targetStatusID); err != nil {
+			return err
+		}
+	}
+
 	if err := lockAffectedIssues(ctx, tx, params.ProjectID, sourceStatusID, targetStatusID); err != nil {
 		return err
 	}
@@ -201,9 +1469,6 @@ func moveIssue(ctx context.Context, db *sqlx.DB, params MoveParams) error {
 	}
 
 	if sourceStatusID == targetStatusID && targetPos == current.StatusPosition {
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("commit no-op move: %w", err)
-		}
 		return nil
 	}
 
@@ -239,19 +1504,233 @@ func moveIssue(ctx context.Context, db *sqlx.DB, params MoveParams) error {
 		return fmt.Errorf("place moved issue: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit move issue: %w", err)
+	return recordEvent(ctx, tx, params.IssueID, eventActor(ctx, ""), EventMoved, map[string]any{
+		"from_status_id": sourceStatusID,
+		"to_status_id":   targetStatusID,
+		"from_position":  current.StatusPosition,
+		"to_position":    targetPos,
+	})
+}
+
+func bulkIssues(ctx context.Context, db *sqlx.DB, params BulkParams) ([]BulkResult, error) {
+	var results []BulkResult
+	if err := pgutil.WithTx(ctx, db, &sql.TxOptions{Isolation: sql.LevelReadCommitted}, "begin tx", "commit bulk issues", func(tx *sqlx.Tx) error {
+		statusOf, err := lockBulkIssues(ctx, tx, params.ProjectID, params.IssueIDs)
+		if err != nil {
+			return err
+		}
+		switch params.Operation {
+		case BulkMove:
+			if err := lockBulkMove(ctx, tx, params.ProjectID, params.StatusID, slices.Collect(maps.Values(statusOf))); err != nil {
+				return err
+			}
+		case BulkAssign:
+			if params.AssigneeID != nil {
+				if err := checkAssignee(ctx, tx, params.ProjectID, *params.AssigneeID); err != nil {
+					return err
+				}
+			}
+		}
+
+		results = make([]BulkResult, 0, len(params.IssueIDs))
+		for _, issueID := range params.IssueIDs {
+			result := BulkResult{IssueID: issueID, Status: BulkUnchanged}
+			statusID, ok := statusOf[issueID]
+			if !ok {
+				result.Status, result.Error = BulkFailed, ErrNotFound.Error()
+				results = append(results, result)
+				continue
+			}
+			var changed bool
+			err := inSavepoint(ctx, tx, func() error {
+				var err error
+				changed, err = bulkApply(ctx, tx, params, issueID, statusID)
+				return err
+			})
+			switch {
+			case errors.Is(err, ErrNotFound), errors.Is(err, ErrIntegrity),
+				errors.Is(err, ErrTransitionNotAllowed), errors.Is(err, ErrConditionsUnmet),
+				errors.Is(err, ErrWIPLimit):
+				result.Status, result.Error = BulkFailed, err.Error()
+			case err != nil:
+				return err
+			case changed:
+				result.Status = BulkApplied
+			}
+			results = append(results, result)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// lockBulkIssues locks the requested active issues in ID order and returns
+// their status IDs. IDs that are not active issues of the project are left out.
+func lockBulkIssues(ctx context.Context, tx *sqlx.Tx, projectID string, issueIDs []string) (map[string]string, error) {
+	var rows []struct {
+		ID       string `db:"id"`
+		StatusID string `db:"status_id"`
+	}
+	// Comparing as text lets malformed IDs come back as not found.
+	if err := tx.SelectContext(ctx, &rows,
+		`SELECT id, status_id
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		   AND id::text = ANY($2::text[])
+		 ORDER BY id
+		 FOR UPDATE`,
+		projectID, pq.Array(issueIDs),
+	); err != nil {
+		return nil, fmt.Errorf("lock bulk issues: %w", err)
+	}
+	statusOf := make(map[string]string, len(rows))
+	for _, row := range rows {
+		statusOf[row.ID] = row.StatusID
+	}
+	return statusOf, nil
+}
+
+type statusLock struct {
+	ID     string `db:"id"`
+	Active bool   `db:"active"`
+}
+
+// lockBulkMove takes the locks moveInTx takes for a single move, for every
+// status involved at once and in ID order, so that the per-issue moves that
+// follow never wait on each other or on concurrent moves half-way through.
+func lockBulkMove(ctx context.Context, tx *sqlx.Tx, projectID, targetStatusID string, sourceStatusIDs []string) error {
+	statusIDs := slices.Compact(slices.Sorted(slices.Values(append(sourceStatusIDs, targetStatusID))))
+	var statuses []statusLock
+	if err := tx.SelectContext(ctx, &statuses,
+		`SELECT id, archived_at IS NULL AS active
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND id::text = ANY($2::text[])
+		 ORDER BY id
+		 FOR UPDATE`,
+		projectID, pq.Array(statusIDs),
+	); err != nil {
+		return fmt.Errorf("lock bulk statuses: %w", err)
+	}
+	if !slices.Contains(statuses, statusLock{ID: targetStatusID, Active: true}) {
+		return ErrStatusNotFound
+	}
+	if _, err := tx.ExecContext(ctx,
+		`SELECT id
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		   AND status_id::text = ANY($2::text[])
+		 ORDER BY id
+		 FOR UPDATE`,
+		projectID, pq.Array(statusIDs),
+	); err != nil {
+		return fmt.Errorf("lock affected issues: %w", err)
+	}
+	return nil
+}
+
+func checkAssignee(ctx context.Context, tx *sqlx.Tx, projectID, userID string) error {
+	var member bool
+	if err := tx.GetContext(ctx, &member,
+		`SELECT EXISTS (
+		   SELECT 1
+		   FROM workspace_members wm
+		   JOIN projects p ON p.workspace_id = wm.workspace_id
+		   JOIN app_users u ON u.id = wm.user_id
+		   WHERE p.id = $1
+		     AND wm.user_id::text = $2
+		     AND wm.archived_at IS NULL
+		     AND u.archived_at IS NULL
+		 )`,
+		projectID, userID,
+	); err != nil {
+		return fmt.Errorf("check assignee: %w", err)
 	}
+	if !member {
+		return ErrAssigneeNotMember
+	}
+	return nil
+}
 
+// inSavepoint runs fn so that its failure undoes only its own changes and
+// leaves the rest of the transaction usable.
+func inSavepoint(ctx context.Context, tx *sqlx.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT bulk_item`); err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT bulk_item`); rbErr != nil {
+			return fmt.Errorf("rollback to savepoint: %w", rbErr)
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT bulk_item`); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
 	return nil
 }
 
+// bulkApply applies the bulk operation to one locked issue and reports
+// whether it changed anything.
+func bulkApply(ctx context.Context, tx *sqlx.Tx, params BulkParams, issueID, statusID string) (bool, error) {
+	switch params.Operation {
+	case BulkMove:
+		if statusID == params.StatusID {
+			return false, nil
+		}
+		// Past-the-end positions are clamped, which appends the issue.
+		return true, moveInTx(ctx, tx, MoveParams{
+			ProjectID:      params.ProjectID,
+			IssueID:        issueID,
+			TargetStatusID: params.StatusID,
+			TargetPosition: math.MaxInt32,
+		})
+	case BulkArchive:
+		return true, archiveInTx(ctx, tx, params.ProjectID, issueID)
+	}
+
+	before, err := lockIssue(ctx, tx, params.ProjectID, issueID)
+	if err != nil {
+		return false, err
+	}
+	set, value := "assignee_id = $3", any(params.AssigneeID)
+	unchanged := optionalString(before.AssigneeID) == optionalString(params.AssigneeID)
+	if params.Operation == BulkSetPriority {
+		set, value = "priority = $3", params.Priority
+		unchanged = before.Priority == params.Priority
+	}
+	if unchanged {
+		return false, nil
+	}
+	var after Issue
+	if err := tx.QueryRowxContext(ctx,
+		`UPDATE issues SET `+set+`
+		 WHERE id = $1
+		   AND project_id = $2
+		 RETURNING `+issueCols,
+		issueID, params.ProjectID, value,
+	).StructScan(&after); err != nil {
+		return false, integrityError(fmt.Errorf("bulk update issue: %w", err))
+	}
+	changes := diffIssues(before, after)
+	if len(changes) == 0 {
+		return false, nil
+	}
+	return true, recordEvent(ctx, tx, issueID, eventActor(ctx, ""), EventUpdated, map[string]any{
+		"changes": changes,
+	})
+}
+
 func getIssuePositionForUpdate(ctx context.Context, tx *sqlx.Tx, projectID, issueID string) (issuePosition, error) {
 	var pos issuePosition
 	err := tx.GetContext(
 		ctx,
 		&pos,
-		`SELECT status_id, status_position
+		`SELECT issue_type_id, assignee_id, status_id, status_position
 		 FROM issues
 		 WHERE id = $1
 		   AND project_id = $2
@@ -267,9 +1746,9 @@ func getIssuePositionForUpdate(ctx context.Context, tx *sqlx.Tx, projectID, issu
 }
 
 func lockStatuses(ctx context.Context, tx *sqlx.Tx, projectID, sourceStatusID, targetStatusID string) error {
-	rows, err := tx.QueryxContext(
-		ctx,
-		`SELECT id
+	var statuses []statusLock
+	if err := tx.SelectContext(ctx, &statuses,
+		`SELECT id, archived_at IS NULL AS active
 		 FROM statuses
 		 WHERE project_id = $1
 		   AND (id = $2 OR id = $3)
@@ -278,28 +1757,246 @@ func lockStatuses(ctx context.Context, tx *sqlx.Tx, projectID, sourceStatusID, t
 		projectID,
 		sourceStatusID,
 		targetStatusID,
-	)
-	if err != nil {
+	); err != nil {
 		return fmt.Errorf("lock statuses: %w", err)
 	}
-	defer rows.Close()
-
-	count := 0
-	for rows.Next() {
-		count++
-	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterate status locks: %w", err)
-	}
 
 	required := 1
 	if sourceStatusID != targetStatusID {
 		required = 2
 	}
-	if count != required {
+	if len(statuses) != required {
 		return errors.New("source or target status not found in project")
 	}
+	if !slices.Contains(statuses, statusLock{ID: targetStatusID, Active: true}) {
+		return ErrStatusNotFound
+	}
+
+	return nil
+}
+
+// checkTransition enforces the project's workflow rules on a move between two
+// different statuses. Issue types without any rule move freely. Admin-only
+// rules count when the actor is a workspace admin or owner or a project admin.
+// The issue must meet the validators of every rule that allows the move.
+func checkTransition(ctx context.Context, tx *sqlx.Tx, projectID, issueID string, current issuePosition, toStatusID string) error {
+	var rules []struct {
+		ToStatusID  string         `db:"to_status_id"`
+		AdminOnly   bool           `db:"admin_only"`
+		Validators  pq.StringArray `db:"validators"`
+		FromMatches bool           `db:"from_matches"`
+	}
+	if err := tx.SelectContext(ctx, &rules,
+		`SELECT to_status_id, admin_only, validators,
+		        (from_status_id IS NULL OR from_status_id = $3) AS from_matches
+		 FROM status_transitions
+		 WHERE project_id = $1
+		   AND (issue_type_id IS NULL OR issue_type_id = $2)`,
+		projectID, current.IssueTypeID, current.StatusID,
+	); err != nil {
+		return fmt.Errorf("load transitions: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	admin, adminKnown := false, false
+	isAdmin := func() (bool, error) {
+		if adminKnown {
+			return admin, nil
+		}
+		adminKnown = true
+		actorID := eventActor(ctx, "")
+		if actorID == "" {
+			return false, nil
+		}
+		if err := tx.GetContext(ctx, &admin,
+			`SELECT EXISTS (
+			   SELECT 1
+			   FROM projects p
+			   JOIN workspace_members wm ON wm.workspace_id = p.workspace_id
+			   WHERE p.id = $1
+			     AND wm.user_id = $2
+			     AND wm.role IN ('admin', 'owner')
+			     AND wm.archived_at IS NULL
+			 ) OR EXISTS (
+			   SELECT 1
+			   FROM project_members
+			   WHERE project_id = $1
+			     AND user_id = $2
+			     AND role = 'admin'
+			     AND archived_at IS NULL
+			 )`,
+			projectID, actorID,
+		); err != nil {
+			return false, fmt.Errorf("check workflow admin: %w", err)
+		}
+		return admin, nil
+	}
+
+	allowed := map[string]bool{}
+	validators := map[string]bool{}
+	for _, rule := range rules {
+		if !rule.FromMatches {
+			continue
+		}
+		if rule.AdminOnly {
+			ok, err := isAdmin()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		allowed[rule.ToStatusID] = true
+		if rule.ToStatusID == toStatusID {
+			for _, v := range rule.Validators {
+				validators[v] = true
+			}
+		}
+	}
+
+	var names []struct {
+		ID       string `db:"id"`
+		Name     string `db:"name"`
+		Archived bool   `db:"archived"`
+	}
+	ids := append([]string{current.StatusID, toStatusID}, slices.Collect(maps.Keys(allowed))...)
+	if err := tx.SelectContext(ctx, &names,
+		`SELECT id, name, archived_at IS NOT NULL AS archived
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND id = ANY($2::uuid[])
+		 ORDER BY position ASC, name ASC`,
+		projectID, pq.Array(ids),
+	); err != nil {
+		return fmt.Errorf("load transition statuses: %w", err)
+	}
+	var from, to string
+	allowedNames := []string{}
+	for _, status := range names {
+		switch {
+		case status.ID == current.StatusID:
+			from = status.Name
+		case status.ID == toStatusID:
+			to = status.Name
+		case allowed[status.ID] && !status.Archived:
+			allowedNames = append(allowedNames, status.Name)
+		}
+	}
+	if !allowed[toStatusID] {
+		return &TransitionError{From: from, To: to, Allowed: allowedNames}
+	}
+
+	unmet := []UnmetCondition{}
+	if validators[statuses.ValidatorAssigneeRequired] && current.AssigneeID == nil {
+		unmet = append(unmet, UnmetCondition{
+			Validator: statuses.ValidatorAssigneeRequired,
+			Message:   "the issue must have an assignee",
+		})
+	}
+	if validators[statuses.ValidatorCommentRequired] {
+		var commented bool
+		if err := tx.GetContext(ctx, &commented,
+			`SELECT EXISTS (
+			   SELECT 1
+			   FROM issue_comments c
+			   WHERE c.issue_id = $1
+			     AND c.deleted_at IS NULL
+			     AND c.created_at >= COALESCE(
+			       (SELECT MAX(e.created_at)
+			        FROM issue_events e
+			        WHERE e.issue_id = $1
+			          AND (e.event_type = 'transferred'
+			            OR (e.event_type = 'moved'
+			                AND e.payload_json->>'from_status_id' IS DISTINCT FROM e.payload_json->>'to_status_id'))),
+			       '-infinity'
+			     )
+			 )`,
+			issueID,
+		); err != nil {
+			return fmt.Errorf("check resolution comment: %w", err)
+		}
+		if !commented {
+			unmet = append(unmet, UnmetCondition{
+				Validator: statuses.ValidatorCommentRequired,
+				Message:   fmt.Sprintf("a comment must be added since the issue entered %q", from),
+			})
+		}
+	}
+	if len(unmet) > 0 {
+		return &ConditionsError{From: from, To: to, Unmet: unmet}
+	}
+	return nil
+}
+
+// checkWIPLimits rejects a move between two statuses that would take a
+// blocking column of an active board over its maximum or under its minimum.
+// Columns showing both statuses keep their count. The columns are locked
+// first so concurrent moves into different statuses of one column serialize.
+func checkWIPLimits(ctx context.Context, tx *sqlx.Tx, projectID, fromStatusID, toStatusID string) error {
+	var columnIDs []string
+	if err := tx.SelectContext(ctx, &columnIDs,
+		`SELECT bc.id
+		 FROM board_columns bc
+		 JOIN boards b ON b.id = bc.board_id
+		 WHERE b.project_id = $1
+		   AND b.archived_at IS NULL
+		   AND bc.archived_at IS NULL
+		   AND bc.wip_mode = 'block'
+		   AND (bc.wip_min IS NOT NULL OR bc.wip_max IS NOT NULL)
+		   AND EXISTS (
+		     SELECT 1 FROM board_column_statuses bcs
+		     WHERE bcs.board_column_id = bc.id
+		       AND bcs.status_id IN ($2, $3)
+		   )
+		 ORDER BY bc.id
+		 FOR UPDATE OF bc`,
+		projectID, fromStatusID, toStatusID,
+	); err != nil {
+		return fmt.Errorf("lock wip columns: %w", err)
+	}
+	if len(columnIDs) == 0 {
+		return nil
+	}
 
+	var columns []struct {
+		Name    string `db:"name"`
+		Board   string `db:"board_name"`
+		WIPMin  *int   `db:"wip_min"`
+		WIPMax  *int   `db:"wip_max"`
+		HasFrom bool   `db:"has_from"`
+		HasTo   bool   `db:"has_to"`
+		Count   int    `db:"count"`
+	}
+	if err := tx.SelectContext(ctx, &columns,
+		`SELECT bc.name, b.name AS board_name, bc.wip_min, bc.wip_max,
+		        EXISTS (SELECT 1 FROM board_column_statuses bcs
+		                WHERE bcs.board_column_id = bc.id AND bcs.status_id = $2) AS has_from,
+		        EXISTS (SELECT 1 FROM board_column_statuses bcs
+		                WHERE bcs.board_column_id = bc.id AND bcs.status_id = $3) AS has_to,
+		        (SELECT COUNT(*)
+		         FROM board_column_statuses bcs
+		         JOIN statuses s ON s.id = bcs.status_id AND s.archived_at IS NULL
+		         JOIN issues i ON i.status_id = s.id AND i.archived_at IS NULL
+		         WHERE bcs.board_column_id = bc.id) AS count
+		 FROM board_columns bc
+		 JOIN boards b ON b.id = bc.board_id
+		 WHERE bc.id = ANY($1::uuid[])
+		 ORDER BY b.name, bc.position`,
+		pq.Array(columnIDs), fromStatusID, toStatusID,
+	); err != nil {
+		return fmt.Errorf("count wip columns: %w", err)
+	}
+	for _, column := range columns {
+		switch {
+		case column.HasTo && !column.HasFrom && column.WIPMax != nil && column.Count+1 > *column.WIPMax:
+			return &WIPLimitError{Board: column.Board, Column: column.Name, Limit: "max", Value: *column.WIPMax, Count: column.Count}
+		case column.HasFrom && !column.HasTo && column.WIPMin != nil && column.Count-1 < *column.WIPMin:
+			return &WIPLimitError{Board: column.Board, Column: column.Name, Limit: "min", Value: *column.WIPMin, Count: column.Count}
+		}
+	}
 	return nil
 }
 
@@ -410,7 +2107,7 @@ func openGapInTargetStatus(ctx context.Context, tx *sqlx.Tx, projectID, statusID
 		   AND status_id = $3
 		   AND archived_at IS NULL
 		   AND status_position >= $4`,
-		reorderOffset, projectID, statusID, targetPos,
+		pgutil.ReorderOffset, projectID, statusID, targetPos,
 	); err != nil {
 		return fmt.Errorf("phase 1 open gap: %w", err)
 	}
@@ -423,7 +2120,7 @@ func openGapInTargetStatus(ctx context.Context, tx *sqlx.Tx, projectID, statusID
 		   AND status_id = $3
 		   AND archived_at IS NULL
 		   AND status_position >= $4`,
-		reorderOffset, projectID, statusID, targetPos+reorderOffset,
+		pgutil.ReorderOffset, projectID, statusID, targetPos+pgutil.ReorderOffset,
 	); err != nil {
 		return fmt.Errorf("phase 2 open gap: %w", err)
 	}
@@ -445,7 +2142,7 @@ func shiftUpRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, statusID
 		   AND archived_at IS NULL
 		   AND id <> $4
 		   AND status_position BETWEEN $5 AND $6`,
-		reorderOffset,
+		pgutil.ReorderOffset,
 		projectID,
 		statusID,
 		issueID,
@@ -464,12 +2161,12 @@ func shiftUpRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, statusID
 		   AND archived_at IS NULL
 		   AND id <> $4
 		   AND status_position BETWEEN $5 AND $6`,
-		reorderOffset,
+		pgutil.ReorderOffset,
 		projectID,
 		statusID,
 		issueID,
-		startPos+reorderOffset,
-		endPos+reorderOffset,
+		startPos+pgutil.ReorderOffset,
+		endPos+pgutil.ReorderOffset,
 	); err != nil {
 		return fmt.Errorf("phase 2 shift up range: %w", err)
 	}
@@ -484,7 +2181,7 @@ func shiftDownRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, status
 		return nil
 	}
 
-	args1 := []any{reorderOffset, projectID, statusID, issueID, startPos}
+	args1 := []any{pgutil.ReorderOffset, projectID, statusID, issueID, startPos}
 	phase1 := `UPDATE issues
 		 SET status_position = status_position + $1
 		 WHERE project_id = $2
@@ -493,7 +2190,7 @@ func shiftDownRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, status
 		   AND id <> $4
 		   AND status_position >= $5`
 
-	args2 := []any{reorderOffset, projectID, statusID, issueID, startPos + reorderOffset}
+	args2 := []any{pgutil.ReorderOffset, projectID, statusID, issueID, startPos + pgutil.ReorderOffset}
 	phase2 := `UPDATE issues
 		 SET status_position = status_position - $1 - 1
 		 WHERE project_id = $2
@@ -505,7 +2202,7 @@ func shiftDownRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, status
 	if endPos >= 0 {
 		args1 = append(args1, endPos)
 		phase1 += " AND status_position <= $6"
-		args2 = append(args2, endPos+reorderOffset)
+		args2 = append(args2, endPos+pgutil.ReorderOffset)
 		phase2 += " AND status_position <= $6"
 	}
 
@@ -517,3 +2214,220 @@ func shiftDownRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID, status
 	}
 	return nil
 }
+
+func listBacklog(ctx context.Context, db *sqlx.DB, projectID string) ([]Issue, error) {
+	issues := []Issue{}
+	if err := db.SelectContext(ctx, &issues,
+		`SELECT `+issueCols+`
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		   AND (sprint_id IS NULL
+		        OR sprint_id IN (SELECT sp.id FROM sprints sp WHERE sp.state = 'closed'))
+		   AND status_id IN (SELECT s.id FROM statuses s WHERE s.category <> 'done')
+		 ORDER BY backlog_rank ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list backlog: %w", err)
+	}
+	if err := attachExtras(ctx, db, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// rankIssue moves an issue next to a reference issue in the backlog order.
+// Like moveIssue, it locks the issue first and then, in ID order, the issues
+// whose ranks shift; the shift uses the two-phase offset so the unique rank
+// index never sees a duplicate.
+func rankIssue(ctx context.Context, db *sqlx.DB, params RankParams) error {
+	return pgutil.WithTx(ctx, db, &sql.TxOptions{Isolation: sql.LevelReadCommitted}, "begin tx", "commit rank issue", func(tx *sqlx.Tx) error {
+		var sourceRank int
+		if err := tx.GetContext(ctx, &sourceRank,
+			`SELECT backlog_rank FROM issues
+			 WHERE id = $1 AND project_id = $2 AND archived_at IS NULL
+			 FOR UPDATE`,
+			params.IssueID, params.ProjectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("load issue rank: %w", err)
+		}
+
+		targetRank, err := lockRankRange(ctx, tx, params, sourceRank)
+		if err != nil {
+			return err
+		}
+		if targetRank == sourceRank {
+			return nil
+		}
+
+		// Phase 1 of the shift lifts the range by the same offset, and the
+		// range never holds the source rank, so the parked rank stays free.
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE issues SET backlog_rank = backlog_rank + $1 WHERE id = $2`,
+			pgutil.ReorderOffset, params.IssueID,
+		); err != nil {
+			return fmt.Errorf("park ranked issue: %w", err)
+		}
+
+		shift, from, to := 1, targetRank, sourceRank-1
+		if targetRank > sourceRank {
+			shift, from, to = -1, sourceRank+1, targetRank
+		}
+		if err := shiftBacklogRange(ctx, tx, params.ProjectID, params.IssueID, from, to, shift); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE issues SET backlog_rank = $1 WHERE id = $2`,
+			targetRank, params.IssueID,
+		); err != nil {
+			return fmt.Errorf("place ranked issue: %w", err)
+		}
+
+		return recordEvent(ctx, tx, params.IssueID, eventActor(ctx, ""), EventUpdated, map[string]any{
+			"changes": map[string]FieldChange{
+				"backlog_rank": {From: sourceRank, To: targetRank},
+			},
+		})
+	})
+}
+
+type rankedIssue struct {
+	ID   string `db:"id"`
+	Rank int    `db:"backlog_rank"`
+}
+
+// lockRankRange locks, in ID order, the reference issue and the active issues
+// ranked between the ranked issue and the reference, and returns the rank the
+// issue moves to. A reorder committed while the locks were awaited can change
+// the range, so it is read again until it matches what was locked.
+func lockRankRange(ctx context.Context, tx *sqlx.Tx, params RankParams, sourceRank int) (int, error) {
+	referenceID := params.BeforeIssueID
+	if referenceID == "" {
+		referenceID = params.AfterIssueID
+	}
+	for {
+		var referenceRank int
+		if err := tx.GetContext(ctx, &referenceRank,
+			`SELECT backlog_rank FROM issues
+			 WHERE id = $1 AND project_id = $2 AND archived_at IS NULL`,
+			referenceID, params.ProjectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, ErrRankReferenceNotFound
+			}
+			return 0, fmt.Errorf("load reference issue rank: %w", err)
+		}
+
+		targetRank := referenceRank
+		if params.BeforeIssueID != "" && sourceRank < referenceRank {
+			targetRank = referenceRank - 1
+		}
+		if params.AfterIssueID != "" && sourceRank > referenceRank {
+			targetRank = referenceRank + 1
+		}
+
+		query := `SELECT id, backlog_rank
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		   AND id <> $2
+		   AND (id = $3 OR backlog_rank BETWEEN $4 AND $5)
+		 ORDER BY id`
+		args := []any{params.ProjectID, params.IssueID, referenceID, min(sourceRank, referenceRank), max(sourceRank, referenceRank)}
+		var locked, current []rankedIssue
+		if err := tx.SelectContext(ctx, &locked, query+` FOR UPDATE`, args...); err != nil {
+			return 0, fmt.Errorf("lock backlog range: %w", err)
+		}
+		if err := tx.SelectContext(ctx, &current, query, args...); err != nil {
+			return 0, fmt.Errorf("reload backlog range: %w", err)
+		}
+		if slices.Equal(locked, current) && slices.Contains(locked, rankedIssue{ID: referenceID, Rank: referenceRank}) {
+			return targetRank, nil
+		}
+	}
+}
+
+// lockBacklog serializes taking a slot at the end of the backlog with issue
+// creation, which takes the same project counter row, and locks the
+// project's active issues.
+func lockBacklog(ctx context.Context, tx *sqlx.Tx, projectID string) error {
+	if _, err := tx.ExecContext(ctx,
+		`SELECT project_id FROM project_issue_counters WHERE project_id = $1 FOR UPDATE`,
+		projectID,
+	); err != nil {
+		return fmt.Errorf("lock issue counter: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`SELECT id
+		 FROM issues
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY id
+		 FOR UPDATE`,
+		projectID,
+	); err != nil {
+		return fmt.Errorf("lock backlog issues: %w", err)
+	}
+	return nil
+}
+
+// shiftBacklogRange moves the ranks in [from, to] by shift (+1 or -1),
+// going through pgutil.ReorderOffset first to avoid transient collisions.
+func shiftBacklogRange(ctx context.Context, tx *sqlx.Tx, projectID, issueID string, from, to, shift int) error {
+	if from > to {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issues
+		 SET backlog_rank = backlog_rank + $1
+		 WHERE project_id = $2
+		   AND archived_at IS NULL
+		   AND id <> $3
+		   AND backlog_rank BETWEEN $4 AND $5`,
+		pgutil.ReorderOffset, projectID, issueID, from, to,
+	); err != nil {
+		return fmt.Errorf("phase 1 shift backlog range: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issues
+		 SET backlog_rank = backlog_rank - $1 + $2
+		 WHERE project_id = $3
+		   AND archived_at IS NULL
+		   AND id <> $4
+		   AND backlog_rank BETWEEN $5 AND $6`,
+		pgutil.ReorderOffset, shift, projectID, issueID, from+pgutil.ReorderOffset, to+pgutil.ReorderOffset,
+	); err != nil {
+		return fmt.Errorf("phase 2 shift backlog range: %w", err)
+	}
+	return nil
+}
+
+// recordEvent appends an entry to the issue activity log inside tx. An empty
+// actorID records the event as made by the system.
+func recordEvent(ctx context.Context, tx *sqlx.Tx, issueID, actorID, eventType string, payload any) error {
+	return activity.Record(ctx, tx, issueID, actorID, eventType, payload)
+}
+
+func listEvents(ctx context.Context, db *sqlx.DB, params ActivityParams) ([]Event, error) {
+	if _, err := getIssue(ctx, db, params.ProjectID, params.IssueID); err != nil {
+		return nil, err
+	}
+	events := []Event{}
+	if err := db.SelectContext(ctx, &events,
+		`SELECT e.id, e.issue_id, e.actor_id, COALESCE(u.name, '') AS actor_name,
+		        e.event_type, e.payload_json, e.created_at
+		 FROM issue_events e
+		 LEFT JOIN app_users u ON u.id = e.actor_id
+		 WHERE e.issue_id = $1
+		 ORDER BY e.created_at DESC, e.id DESC
+		 LIMIT $2 OFFSET $3`,
+		params.IssueID, params.Limit, params.Offset,
+	); err != nil {
+		return nil, fmt.Errorf("list issue events: %w", err)
+	}
+	return events, nil
+}
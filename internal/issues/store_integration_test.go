@@ -7,13 +7,20 @@ package issues
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/customfields"
+	"github.com/start-codex/tookly/internal/pgutil"
 	"github.com/start-codex/tookly/internal/testpg"
 )
 
@@ -262,6 +269,156 @@ func TestMoveIssue_ConcurrentMixedMoves(t *testing.T) {
 	assertContainsSameIDs(t, allGot, allExpected)
 }
 
+func TestMoveIssue_WorkflowTransitions(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	var doneID string
+	if err := db.GetContext(ctx, &doneID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Hecho', 'done', 2) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert done status: %v", err)
+	}
+	addRule := func(fromID, toID string, adminOnly bool) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx,
+			`INSERT INTO status_transitions (project_id, from_status_id, to_status_id, issue_type_id, admin_only)
+			 VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5)`,
+			seed.projectID, fromID, toID, seed.issueTypeID, adminOnly,
+		); err != nil {
+			t.Fatalf("insert transition: %v", err)
+		}
+	}
+
+	a := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+	b := insertIssue(t, db, seed, issueSeed{number: 2, title: "B", statusID: seed.statusTodoID, statusPosition: 1})
+
+	// Without rules every move is allowed.
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: b, TargetStatusID: doneID}); err != nil {
+		t.Fatalf("Move without rules error = %v", err)
+	}
+
+	addRule(seed.statusTodoID, seed.statusDoingID, false)
+	addRule(seed.statusDoingID, doneID, true)
+	addRule("", seed.statusTodoID, false)
+
+	err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID})
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) || !errors.Is(err, ErrTransitionNotAllowed) {
+		t.Fatalf("Move(todo -> done) error = %v, want a TransitionError", err)
+	}
+	if transitionErr.From != "Por hacer" || transitionErr.To != "Hecho" || !slices.Equal(transitionErr.Allowed, []string{"En curso"}) {
+		t.Fatalf("Move(todo -> done) error: got %+v", transitionErr)
+	}
+
+	// Reordering within a status is never restricted.
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetPosition: 0}); err != nil {
+		t.Fatalf("Move within status error = %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: seed.statusDoingID}); err != nil {
+		t.Fatalf("Move(todo -> doing) error = %v", err)
+	}
+
+	// Admin-only rules do not apply to members and are left out of Allowed.
+	err = Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID})
+	if !errors.As(err, &transitionErr) || !slices.Equal(transitionErr.Allowed, []string{"Por hacer"}) {
+		t.Fatalf("Move(doing -> done) as member error = %v, want only 'Por hacer' allowed", err)
+	}
+	results, err := Bulk(ctx, db, BulkParams{ProjectID: seed.projectID, IssueIDs: []string{a}, Operation: BulkMove, StatusID: doneID})
+	if err != nil {
+		t.Fatalf("Bulk(move) error = %v", err)
+	}
+	if results[0].Status != BulkFailed || results[0].Error != transitionErr.Error() {
+		t.Fatalf("Bulk(move) result: got %+v, want a failed transition", results[0])
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, 'admin')`, seed.projectID, seed.reporterID); err != nil {
+		t.Fatalf("add project admin: %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID}); err != nil {
+		t.Fatalf("Move(doing -> done) as admin error = %v", err)
+	}
+
+	// Rules scoped to another type leave this one free.
+	var epicID string
+	if err := db.GetContext(ctx, &epicID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Epic', 0) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert epic type: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE status_transitions SET issue_type_id = $2 WHERE project_id = $1`, seed.projectID, epicID); err != nil {
+		t.Fatalf("scope transitions: %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: b, TargetStatusID: seed.statusDoingID}); err != nil {
+		t.Fatalf("Move with rules for another type error = %v", err)
+	}
+}
+
+func TestMoveIssue_TransitionValidators(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	var doneID string
+	if err := db.GetContext(ctx, &doneID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Hecho', 'done', 2) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert done status: %v", err)
+	}
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO status_transitions (project_id, from_status_id, to_status_id, validators)
+		 VALUES ($1, NULL, $2, '{assignee_required}'), ($1, NULL, $3, '{assignee_required,comment_required}'), ($1, NULL, $4, '{}')`,
+		seed.projectID, seed.statusDoingID, doneID, seed.statusTodoID,
+	); err != nil {
+		t.Fatalf("insert transitions: %v", err)
+	}
+	a := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+
+	validatorsOf := func(err error) []string {
+		t.Helper()
+		var conditionsErr *ConditionsError
+		if !errors.As(err, &conditionsErr) || !errors.Is(err, ErrConditionsUnmet) {
+			t.Fatalf("Move() error = %v, want a ConditionsError", err)
+		}
+		out := []string{}
+		for _, c := range conditionsErr.Unmet {
+			out = append(out, c.Validator)
+		}
+		return out
+	}
+
+	err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID})
+	if got, want := validatorsOf(err), []string{"assignee_required", "comment_required"}; !slices.Equal(got, want) {
+		t.Fatalf("Move(todo -> done) unmet: got %v, want %v", got, want)
+	}
+	results, err := Bulk(ctx, db, BulkParams{ProjectID: seed.projectID, IssueIDs: []string{a}, Operation: BulkMove, StatusID: seed.statusDoingID})
+	if err != nil {
+		t.Fatalf("Bulk(move) error = %v", err)
+	}
+	if results[0].Status != BulkFailed || !strings.Contains(results[0].Error, "assignee") {
+		t.Fatalf("Bulk(move) result: got %+v, want a failed assignee condition", results[0])
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE issues SET assignee_id = $2 WHERE id = $1`, a, seed.reporterID); err != nil {
+		t.Fatalf("assign issue: %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: seed.statusDoingID}); err != nil {
+		t.Fatalf("Move(todo -> doing) error = %v", err)
+	}
+
+	// A comment from before the issue entered its current status does not count.
+	if _, err := db.ExecContext(ctx, `INSERT INTO issue_comments (issue_id, author_id, body, created_at) VALUES ($1, $2, 'old note', NOW() - INTERVAL '1 hour')`, a, seed.reporterID); err != nil {
+		t.Fatalf("insert old comment: %v", err)
+	}
+	err = Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID})
+	if got, want := validatorsOf(err), []string{"comment_required"}; !slices.Equal(got, want) {
+		t.Fatalf("Move(doing -> done) unmet: got %v, want %v", got, want)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO issue_comments (issue_id, author_id, body) VALUES ($1, $2, 'fixed in 1.2')`, a, seed.reporterID); err != nil {
+		t.Fatalf("insert resolution comment: %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: doneID}); err != nil {
+		t.Fatalf("Move(doing -> done) with resolution comment error = %v", err)
+	}
+}
+
 type projectSeed struct {
 	workspaceID   string
 	reporterID    string
@@ -283,6 +440,67 @@ type orderedIssue struct {
 	Pos int    `db:"status_position"`
 }
 
+func TestMoveIssue_ArchivedTarget(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	a := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+	if _, err := db.Exec(`UPDATE statuses SET archived_at = NOW() WHERE id = $1`, seed.statusDoingID); err != nil {
+		t.Fatalf("archive status: %v", err)
+	}
+	err := Move(ctx, db, MoveParams{ProjectID: seed.projectID, IssueID: a, TargetStatusID: seed.statusDoingID})
+	if !errors.Is(err, ErrStatusNotFound) {
+		t.Fatalf("Move() into an archived status error = %v, want %v", err, ErrStatusNotFound)
+	}
+}
+
+func TestCreateIssue_TypeDefaults(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	params := CreateParams{ProjectID: seed.projectID, IssueTypeID: seed.issueTypeID, Title: "No status", ReporterID: seed.reporterID}
+	if _, err := Create(ctx, db, params); !errors.Is(err, ErrStatusRequired) {
+		t.Fatalf("Create() without status or default error = %v, want %v", err, ErrStatusRequired)
+	}
+	other := seedProject(t, db)
+	foreign := CreateParams{ProjectID: seed.projectID, IssueTypeID: other.issueTypeID, Title: "Foreign type", ReporterID: seed.reporterID}
+	if _, err := Create(ctx, db, foreign); !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("Create() with another project's type error = %v, want %v", err, ErrIntegrity)
+	}
+
+	if _, err := db.ExecContext(ctx,
+		`UPDATE issue_types
+		 SET default_status_id = $2, default_priority = 'high',
+		     description_template = '## Steps by {{reporter}} in {{ project }} ({{issue_type}}, {{date}}) {{unknown}}'
+		 WHERE id = $1`,
+		seed.issueTypeID, seed.statusDoingID,
+	); err != nil {
+		t.Fatalf("set type defaults: %v", err)
+	}
+	today := time.Now().UTC().Format(time.DateOnly)
+	issue, err := Create(ctx, db, params)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	wantDescription := "## Steps by Reporter in Project (Task, " + today + ") {{unknown}}"
+	if issue.StatusID != seed.statusDoingID || issue.Priority != "high" || issue.Description != wantDescription {
+		t.Fatalf("Create() = status %s, priority %q, description %q; want the type defaults", issue.StatusID, issue.Priority, issue.Description)
+	}
+
+	params.StatusID, params.Priority, params.Description = seed.statusTodoID, "low", "Given"
+	issue, err = Create(ctx, db, params)
+	if err != nil {
+		t.Fatalf("Create() with values error = %v", err)
+	}
+	if issue.StatusID != seed.statusTodoID || issue.Priority != "low" || issue.Description != "Given" {
+		t.Fatalf("Create() = status %s, priority %q, description %q; want the given values", issue.StatusID, issue.Priority, issue.Description)
+	}
+}
+
 func seedProject(t *testing.T, db *sqlx.DB) projectSeed {
 	t.Helper()
 
@@ -608,6 +826,88 @@ func TestListIssues(t *testing.T) {
 	}
 }
 
+func TestListIssuePage(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	ids := make(map[int]string)
+	for n, priority := range map[int]string{1: "low", 2: "critical", 3: "high", 4: "critical", 5: "medium"} {
+		ids[n] = insertIssue(t, db, seed, issueSeed{number: n, title: "Issue", statusID: seed.statusTodoID, statusPosition: n - 1})
+		if _, err := db.ExecContext(ctx, `UPDATE issues SET priority = $1 WHERE id = $2`, priority, ids[n]); err != nil {
+			t.Fatalf("set priority: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE issues SET due_date = '2025-03-01' WHERE id = $1`, ids[3]); err != nil {
+		t.Fatalf("set due date: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE issues SET assignee_id = $1 WHERE id = $2`, seed.reporterID, ids[5]); err != nil {
+		t.Fatalf("set assignee: %v", err)
+	}
+	if err := Archive(ctx, db, seed.projectID, ids[1]); err != nil {
+		t.Fatalf("archive issue: %v", err)
+	}
+
+	numbers := func(issues []Issue) []int {
+		out := []int{}
+		for _, issue := range issues {
+			out = append(out, issue.Number)
+		}
+		return out
+	}
+
+	params := ListParams{ProjectID: seed.projectID, Sort: SortPriority, Desc: true, Limit: 2}
+	got := []int{}
+	for range 3 {
+		page, err := ListPage(ctx, db, params)
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if page.Total != 4 {
+			t.Fatalf("total: got %d, want 4", page.Total)
+		}
+		got = append(got, numbers(page.Issues)...)
+		if page.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	if !slices.Equal(got, []int{4, 2, 3, 5}) {
+		t.Fatalf("pages by priority desc: got %v, want [4 2 3 5]", got)
+	}
+
+	params.Sort = SortDue
+	if _, err := ListPage(ctx, db, params); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("ListPage() with cursor of another sort error = %v, want ErrInvalidCursor", err)
+	}
+
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name   string
+		params ListParams
+		want   []int
+	}{
+		{name: "due first, undated last", params: ListParams{Sort: SortDue}, want: []int{3, 2, 4, 5}},
+		{name: "include archived", params: ListParams{Sort: SortNumber, Desc: true, IncludeArchived: true}, want: []int{5, 4, 3, 2, 1}},
+		{name: "priorities", params: ListParams{Priorities: []string{"high", "medium"}}, want: []int{3, 5}},
+		{name: "unassigned", params: ListParams{Unassigned: true, Sort: SortNumber}, want: []int{2, 3, 4}},
+		{name: "due before", params: ListParams{DueBefore: &due}, want: []int{3}},
+		{name: "reporter", params: ListParams{ReporterIDs: []string{seed.reporterID}, Sort: SortNumber}, want: []int{2, 3, 4, 5}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.ProjectID = seed.projectID
+			page, err := ListPage(ctx, db, tt.params)
+			if err != nil {
+				t.Fatalf("ListPage() error = %v", err)
+			}
+			if !slices.Equal(numbers(page.Issues), tt.want) || page.Total != len(tt.want) || page.NextCursor != "" {
+				t.Fatalf("ListPage(): got %v (total %d, cursor %q), want %v", numbers(page.Issues), page.Total, page.NextCursor, tt.want)
+			}
+		})
+	}
+}
+
 func TestUpdateIssue(t *testing.T) {
 	db := testpg.Open(t)
 	testpg.EnsureMigrated(t, db)
@@ -736,3 +1036,940 @@ func TestArchiveIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkIssues(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+	if _, err := db.ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, 'member')`, seed.workspaceID, seed.reporterID); err != nil {
+		t.Fatalf("add workspace member: %v", err)
+	}
+
+	a := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+	b := insertIssue(t, db, seed, issueSeed{number: 2, title: "B", statusID: seed.statusTodoID, statusPosition: 1})
+	c := insertIssue(t, db, seed, issueSeed{number: 3, title: "C", statusID: seed.statusTodoID, statusPosition: 2})
+	d := insertIssue(t, db, seed, issueSeed{number: 4, title: "D", statusID: seed.statusDoingID, statusPosition: 0})
+	missing := "00000000-0000-0000-0000-000000000000"
+
+	statuses := func(results []BulkResult) []string {
+		out := []string{}
+		for _, r := range results {
+			out = append(out, r.Status)
+		}
+		return out
+	}
+
+	results, err := Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID,
+		IssueIDs:  []string{c, missing, a, d, "not-a-uuid"},
+		Operation: BulkMove,
+		StatusID:  seed.statusDoingID,
+	})
+	if err != nil {
+		t.Fatalf("Bulk(move) error = %v", err)
+	}
+	if got, want := statuses(results), []string{BulkApplied, BulkFailed, BulkApplied, BulkUnchanged, BulkFailed}; !slices.Equal(got, want) {
+		t.Fatalf("Bulk(move) statuses: got %v, want %v", got, want)
+	}
+	if results[1].IssueID != missing || results[1].Error != ErrNotFound.Error() {
+		t.Fatalf("Bulk(move) missing issue: got %+v", results[1])
+	}
+	assertOrder(t, fetchStatusOrder(t, db, seed.projectID, seed.statusTodoID), []orderedIssue{{ID: b, Pos: 0}})
+	assertOrder(t, fetchStatusOrder(t, db, seed.projectID, seed.statusDoingID), []orderedIssue{
+		{ID: d, Pos: 0}, {ID: c, Pos: 1}, {ID: a, Pos: 2},
+	})
+
+	if _, err := Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID, IssueIDs: []string{b}, Operation: BulkMove, StatusID: missing,
+	}); !errors.Is(err, ErrStatusNotFound) {
+		t.Fatalf("Bulk(move) to unknown status error = %v, want %v", err, ErrStatusNotFound)
+	}
+
+	results, err = Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID, IssueIDs: []string{a, b}, Operation: BulkAssign, AssigneeID: &seed.reporterID,
+	})
+	if err != nil {
+		t.Fatalf("Bulk(assign) error = %v", err)
+	}
+	if got, want := statuses(results), []string{BulkApplied, BulkApplied}; !slices.Equal(got, want) {
+		t.Fatalf("Bulk(assign) statuses: got %v, want %v", got, want)
+	}
+	if _, err := Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID, IssueIDs: []string{a}, Operation: BulkAssign, AssigneeID: &missing,
+	}); !errors.Is(err, ErrAssigneeNotMember) {
+		t.Fatalf("Bulk(assign) non-member error = %v, want %v", err, ErrAssigneeNotMember)
+	}
+
+	results, err = Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID, IssueIDs: []string{a, b}, Operation: BulkSetPriority, Priority: "medium",
+	})
+	if err != nil {
+		t.Fatalf("Bulk(set_priority) error = %v", err)
+	}
+	if got, want := statuses(results), []string{BulkUnchanged, BulkUnchanged}; !slices.Equal(got, want) {
+		t.Fatalf("Bulk(set_priority) statuses: got %v, want %v", got, want)
+	}
+
+	results, err = Bulk(ctx, db, BulkParams{
+		ProjectID: seed.projectID, IssueIDs: []string{b, c}, Operation: BulkArchive,
+	})
+	if err != nil {
+		t.Fatalf("Bulk(archive) error = %v", err)
+	}
+	if got, want := statuses(results), []string{BulkApplied, BulkApplied}; !slices.Equal(got, want) {
+		t.Fatalf("Bulk(archive) statuses: got %v, want %v", got, want)
+	}
+
+	events, err := ListActivity(ctx, db, ActivityParams{ProjectID: seed.projectID, IssueID: a})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	types := []string{}
+	for _, e := range events {
+		types = append(types, e.EventType)
+	}
+	if want := []string{EventUpdated, EventMoved}; !slices.Equal(types, want) {
+		t.Fatalf("activity of a: got %v, want %v", types, want)
+	}
+}
+
+func TestRestoreIssue(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	archived := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+	if err := Archive(ctx, db, seed.projectID, archived); err != nil {
+		t.Fatalf("archive issue: %v", err)
+	}
+	// Another issue takes the archived one's slot in the meantime.
+	taken := insertIssue(t, db, seed, issueSeed{number: 2, title: "B", statusID: seed.statusTodoID, statusPosition: 0})
+
+	trash, err := ListTrash(ctx, db, seed.projectID)
+	if err != nil {
+		t.Fatalf("ListTrash() error = %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != archived {
+		t.Fatalf("ListTrash(): got %+v, want the archived issue", trash)
+	}
+
+	restored, err := Restore(ctx, db, seed.projectID, archived)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.ArchivedAt != nil {
+		t.Fatal("expected archived_at to be cleared")
+	}
+	assertOrder(t, fetchStatusOrder(t, db, seed.projectID, seed.statusTodoID), []orderedIssue{
+		{ID: taken, Pos: 0},
+		{ID: archived, Pos: 1},
+	})
+	if _, err := Restore(ctx, db, seed.projectID, archived); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore() of an active issue error = %v, want %v", err, ErrNotFound)
+	}
+
+	events, err := ListActivity(ctx, db, ActivityParams{ProjectID: seed.projectID, IssueID: archived})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(events) == 0 || events[0].EventType != EventRestored {
+		t.Fatalf("activity: got %+v, want a restored event first", events)
+	}
+
+	// Issues in an archived status stay in the trash until the status is back.
+	if err := Archive(ctx, db, seed.projectID, archived); err != nil {
+		t.Fatalf("archive issue again: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE statuses SET archived_at = NOW() WHERE id = $1`, seed.statusTodoID); err != nil {
+		t.Fatalf("archive status: %v", err)
+	}
+	if _, err := Restore(ctx, db, seed.projectID, archived); !errors.Is(err, ErrStatusArchived) {
+		t.Fatalf("Restore() into an archived status error = %v, want %v", err, ErrStatusArchived)
+	}
+
+	// Likewise for issues of an archived type.
+	if _, err := db.Exec(`UPDATE statuses SET archived_at = NULL WHERE id = $1`, seed.statusTodoID); err != nil {
+		t.Fatalf("restore status: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE issue_types SET archived_at = NOW() WHERE id = $1`, seed.issueTypeID); err != nil {
+		t.Fatalf("archive issue type: %v", err)
+	}
+	if _, err := Restore(ctx, db, seed.projectID, archived); !errors.Is(err, ErrIssueTypeArchived) {
+		t.Fatalf("Restore() of an archived type error = %v, want %v", err, ErrIssueTypeArchived)
+	}
+}
+
+func TestIssueActivity(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	issue, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueTypeID: seed.issueTypeID,
+		StatusID: seed.statusTodoID, Title: "Tracked",
+		ReporterID: seed.reporterID,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := Update(ctx, db, UpdateParams{
+		IssueID: issue.ID, ProjectID: seed.projectID, Title: "Tracked", Priority: "high",
+	}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// An update that changes nothing must not add an event.
+	if _, err := Update(ctx, db, UpdateParams{
+		IssueID: issue.ID, ProjectID: seed.projectID, Title: "Tracked", Priority: "high",
+	}); err != nil {
+		t.Fatalf("no-op update: %v", err)
+	}
+	if err := Move(ctx, db, MoveParams{
+		ProjectID: seed.projectID, IssueID: issue.ID, TargetStatusID: seed.statusDoingID,
+	}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if err := Archive(ctx, db, seed.projectID, issue.ID); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+
+	events, err := ListActivity(context.Background(), db, ActivityParams{ProjectID: seed.projectID, IssueID: issue.ID})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	wantTypes := []string{EventArchived, EventMoved, EventUpdated, EventCreated}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("events: got %d, want %d", len(events), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if events[i].EventType != want {
+			t.Fatalf("event[%d]: got %q, want %q", i, events[i].EventType, want)
+		}
+		if events[i].ActorID == nil || *events[i].ActorID != seed.reporterID {
+			t.Fatalf("event[%d] actor: got %v, want %q", i, events[i].ActorID, seed.reporterID)
+		}
+	}
+
+	var payload struct {
+		Changes map[string]FieldChange `json:"changes"`
+	}
+	if err := json.Unmarshal(events[2].Payload, &payload); err != nil {
+		t.Fatalf("unmarshal update payload: %v", err)
+	}
+	if got := payload.Changes["priority"]; got.From != "medium" || got.To != "high" {
+		t.Fatalf("priority change: got %v, want medium -> high", got)
+	}
+
+	page, err := ListActivity(context.Background(), db, ActivityParams{ProjectID: seed.projectID, IssueID: issue.ID, Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("list activity page: %v", err)
+	}
+	if len(page) != 2 || page[1].EventType != EventCreated {
+		t.Fatalf("second page: got %d events, want [updated created]", len(page))
+	}
+}
+
+func TestIssueActivity_SystemActor(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+
+	seed := seedProject(t, db)
+	issueID := insertIssue(t, db, seed, issueSeed{number: 1, title: "Imported", statusID: seed.statusTodoID, statusPosition: 0})
+	// Without a user in context the move is recorded as a system event.
+	if err := Move(context.Background(), db, MoveParams{
+		ProjectID: seed.projectID, IssueID: issueID, TargetStatusID: seed.statusDoingID,
+	}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+
+	events, err := ListActivity(context.Background(), db, ActivityParams{ProjectID: seed.projectID, IssueID: issueID})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != EventMoved {
+		t.Fatalf("events: got %d, want one moved event", len(events))
+	}
+	if events[0].ActorID != nil || events[0].ActorName != "" {
+		t.Fatalf("actor: got %v %q, want a system event", events[0].ActorID, events[0].ActorName)
+	}
+
+	// Other domains record system events through RecordEvent the same way.
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("begin tx: %v", err)
+	}
+	if err := RecordEvent(context.Background(), tx, issueID, "", EventCommented, map[string]any{}); err != nil {
+		tx.Rollback()
+		t.Fatalf("RecordEvent() without actor error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	events, err = ListActivity(context.Background(), db, ActivityParams{ProjectID: seed.projectID, IssueID: issueID})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(events) != 2 || events[0].EventType != EventCommented || events[0].ActorID != nil {
+		t.Fatalf("events: got %+v, want a system comment event first", events)
+	}
+}
+
+func TestRankIssue(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	a := insertIssue(t, db, seed, issueSeed{number: 1, title: "A", statusID: seed.statusTodoID, statusPosition: 0})
+	b := insertIssue(t, db, seed, issueSeed{number: 2, title: "B", statusID: seed.statusDoingID, statusPosition: 0})
+	c := insertIssue(t, db, seed, issueSeed{number: 3, title: "C", statusID: seed.statusTodoID, statusPosition: 1})
+	d := insertIssue(t, db, seed, issueSeed{number: 4, title: "D", statusID: seed.statusDoingID, statusPosition: 1})
+
+	backlogOrder := func(t *testing.T) []string {
+		t.Helper()
+		list, err := ListBacklog(ctx, db, seed.projectID)
+		if err != nil {
+			t.Fatalf("ListBacklog() error = %v", err)
+		}
+		ids := make([]string, 0, len(list))
+		for _, issue := range list {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	if got, want := backlogOrder(t), []string{a, b, c, d}; !slices.Equal(got, want) {
+		t.Fatalf("initial backlog: got %v, want %v", got, want)
+	}
+
+	steps := []struct {
+		name   string
+		params RankParams
+		want   []string
+	}{
+		{name: "move down before", params: RankParams{IssueID: a, BeforeIssueID: d}, want: []string{b, c, a, d}},
+		{name: "move up before", params: RankParams{IssueID: d, BeforeIssueID: b}, want: []string{d, b, c, a}},
+		{name: "move down after", params: RankParams{IssueID: d, AfterIssueID: a}, want: []string{b, c, a, d}},
+		{name: "move up after", params: RankParams{IssueID: a, AfterIssueID: b}, want: []string{b, a, c, d}},
+		{name: "no-op", params: RankParams{IssueID: a, AfterIssueID: b}, want: []string{b, a, c, d}},
+	}
+	for _, step := range steps {
+		step.params.ProjectID = seed.projectID
+		if err := Rank(ctx, db, step.params); err != nil {
+			t.Fatalf("%s: Rank() error = %v", step.name, err)
+		}
+		if got := backlogOrder(t); !slices.Equal(got, step.want) {
+			t.Fatalf("%s: got %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	missing := "00000000-0000-0000-0000-000000000000"
+	if err := Rank(ctx, db, RankParams{ProjectID: seed.projectID, IssueID: a, BeforeIssueID: missing}); !errors.Is(err, ErrRankReferenceNotFound) {
+		t.Fatalf("Rank() unknown reference error = %v, want ErrRankReferenceNotFound", err)
+	}
+	if err := Rank(ctx, db, RankParams{ProjectID: seed.projectID, IssueID: missing, BeforeIssueID: a}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Rank() unknown issue error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRankIssue_ConcurrentRanksMovesAndCreates(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+
+	seed := seedProject(t, db)
+	ids := make([]string, 8)
+	for i := range ids {
+		ids[i] = insertIssue(t, db, seed, issueSeed{number: i + 1, title: fmt.Sprintf("I%d", i+1), statusID: seed.statusTodoID, statusPosition: i})
+	}
+	// Leave a gap in the ranks.
+	if _, err := db.Exec(`UPDATE issues SET archived_at = NOW() WHERE id = $1`, ids[3]); err != nil {
+		t.Fatalf("archive issue: %v", err)
+	}
+
+	ops := []func() error{
+		func() error {
+			return Rank(context.Background(), db, RankParams{ProjectID: seed.projectID, IssueID: ids[0], AfterIssueID: ids[7]})
+		},
+		func() error {
+			return Rank(context.Background(), db, RankParams{ProjectID: seed.projectID, IssueID: ids[6], BeforeIssueID: ids[1]})
+		},
+		func() error {
+			return Rank(context.Background(), db, RankParams{ProjectID: seed.projectID, IssueID: ids[2], AfterIssueID: ids[5]})
+		},
+		func() error {
+			return Move(context.Background(), db, MoveParams{ProjectID: seed.projectID, IssueID: ids[7], TargetStatusID: seed.statusDoingID})
+		},
+		func() error {
+			return Move(context.Background(), db, MoveParams{ProjectID: seed.projectID, IssueID: ids[1], TargetStatusID: seed.statusTodoID, TargetPosition: 4})
+		},
+		func() error {
+			_, err := Create(context.Background(), db, CreateParams{
+				ProjectID: seed.projectID, IssueTypeID: seed.issueTypeID, StatusID: seed.statusTodoID,
+				Title: "New", ReporterID: seed.reporterID, Priority: "medium",
+			})
+			return err
+		},
+	}
+
+	start := make(chan struct{})
+	errCh := make(chan error, len(ops))
+	var wg sync.WaitGroup
+	for _, op := range ops {
+		wg.Add(1)
+		go func(op func() error) {
+			defer wg.Done()
+			<-start
+			errCh <- op()
+		}(op)
+	}
+	close(start)
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		if err != nil {
+			t.Fatalf("concurrent rank, move or create returned error: %v", err)
+		}
+	}
+
+	var ranks []int
+	if err := db.Select(&ranks,
+		`SELECT backlog_rank FROM issues WHERE project_id = $1 AND archived_at IS NULL ORDER BY backlog_rank`,
+		seed.projectID,
+	); err != nil {
+		t.Fatalf("load ranks: %v", err)
+	}
+	if len(ranks) != 8 {
+		t.Fatalf("active issues: got %d, want 8", len(ranks))
+	}
+	for _, rank := range ranks {
+		if rank >= pgutil.ReorderOffset {
+			t.Fatalf("rank %d left at the reorder offset", rank)
+		}
+	}
+}
+
+func TestIssueHierarchy(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	var epicTypeID, subtaskTypeID, statusDoneID string
+	if err := db.GetContext(ctx, &epicTypeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Epic', 0) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert epic type: %v", err)
+	}
+	if err := db.GetContext(ctx, &subtaskTypeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Subtask', 2) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert subtask type: %v", err)
+	}
+	if err := db.GetContext(ctx, &statusDoneID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Hecho', 'done', 2) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert done status: %v", err)
+	}
+
+	create := func(title, typeID, statusID string) Issue {
+		t.Helper()
+		issue, err := Create(ctx, db, CreateParams{
+			ProjectID: seed.projectID, IssueTypeID: typeID, StatusID: statusID,
+			Title: title, ReporterID: seed.reporterID, Priority: "medium",
+		})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return issue
+	}
+	epic := create("Epic", epicTypeID, seed.statusTodoID)
+	taskTodo := create("Task todo", seed.issueTypeID, seed.statusTodoID)
+	taskDone := create("Task done", seed.issueTypeID, statusDoneID)
+	subtask := create("Subtask", subtaskTypeID, seed.statusDoingID)
+
+	for _, child := range []Issue{taskTodo, taskDone} {
+		if _, err := SetParent(ctx, db, ParentParams{ProjectID: seed.projectID, IssueID: child.ID, ParentIssueID: epic.ID}); err != nil {
+			t.Fatalf("SetParent(%s) error = %v", child.Title, err)
+		}
+	}
+	got, err := SetParent(ctx, db, ParentParams{ProjectID: seed.projectID, IssueID: subtask.ID, ParentIssueID: taskTodo.ID})
+	if err != nil {
+		t.Fatalf("SetParent(subtask) error = %v", err)
+	}
+	if got.ParentIssueID == nil || *got.ParentIssueID != taskTodo.ID {
+		t.Fatalf("parent_issue_id: got %v, want %s", got.ParentIssueID, taskTodo.ID)
+	}
+
+	children, err := ListChildren(ctx, db, seed.projectID, epic.ID)
+	if err != nil {
+		t.Fatalf("ListChildren() error = %v", err)
+	}
+	if len(children) != 2 || children[0].ID != taskTodo.ID || children[1].ID != taskDone.ID {
+		t.Fatalf("children: got %+v", children)
+	}
+
+	ancestors, err := ListAncestors(ctx, db, seed.projectID, subtask.ID)
+	if err != nil {
+		t.Fatalf("ListAncestors() error = %v", err)
+	}
+	if len(ancestors) != 2 || ancestors[0].ID != taskTodo.ID || ancestors[1].ID != epic.ID {
+		t.Fatalf("ancestors: got %+v", ancestors)
+	}
+
+	detail, err := Get(ctx, db, seed.projectID, epic.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if detail.Rollup == nil || *detail.Rollup != (Rollup{Total: 2, Todo: 1, Done: 1}) {
+		t.Fatalf("rollup: got %+v", detail.Rollup)
+	}
+
+	_, err = SetParent(ctx, db, ParentParams{ProjectID: seed.projectID, IssueID: epic.ID, ParentIssueID: subtask.ID})
+	if !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("SetParent() level violation error = %v, want ErrIntegrity", err)
+	}
+	_, err = SetParent(ctx, db, ParentParams{ProjectID: seed.projectID, IssueID: taskTodo.ID, ParentIssueID: "00000000-0000-0000-0000-000000000000"})
+	if !errors.Is(err, ErrParentNotFound) {
+		t.Fatalf("SetParent() unknown parent error = %v, want ErrParentNotFound", err)
+	}
+
+	detached, err := SetParent(ctx, db, ParentParams{ProjectID: seed.projectID, IssueID: taskDone.ID})
+	if err != nil {
+		t.Fatalf("SetParent() detach error = %v", err)
+	}
+	if detached.ParentIssueID != nil {
+		t.Fatalf("parent_issue_id after detach: got %v, want nil", *detached.ParentIssueID)
+	}
+}
+
+func TestIssueLinks(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	other := seedProject(t, db)
+
+	sibling := seed
+	if err := db.GetContext(ctx, &sibling.projectID, `INSERT INTO projects (workspace_id, name, key, description) VALUES ($1, 'Sibling', upper(substr(replace(gen_random_uuid()::text,'-',''),1,3)), '') RETURNING id`, seed.workspaceID); err != nil {
+		t.Fatalf("insert sibling project: %v", err)
+	}
+	if err := db.GetContext(ctx, &sibling.issueTypeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, sibling.projectID); err != nil {
+		t.Fatalf("insert sibling issue_type: %v", err)
+	}
+	if err := db.GetContext(ctx, &sibling.statusTodoID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Por hacer', 'todo', 0) RETURNING id`, sibling.projectID); err != nil {
+		t.Fatalf("insert sibling status: %v", err)
+	}
+
+	blocker := insertIssue(t, db, seed, issueSeed{number: 1, title: "Blocker", statusID: seed.statusTodoID, statusPosition: 0})
+	blocked := insertIssue(t, db, seed, issueSeed{number: 2, title: "Blocked", statusID: seed.statusTodoID, statusPosition: 1})
+	remote := insertIssue(t, db, sibling, issueSeed{number: 1, title: "Remote", statusID: sibling.statusTodoID, statusPosition: 0})
+	foreign := insertIssue(t, db, other, issueSeed{number: 1, title: "Foreign", statusID: other.statusTodoID, statusPosition: 0})
+
+	link, err := CreateLink(ctx, db, CreateLinkParams{
+		ProjectID: seed.projectID, IssueID: blocked, TargetIssueID: blocker, Type: "is_blocked_by", CreatedBy: seed.reporterID,
+	})
+	if err != nil {
+		t.Fatalf("CreateLink(is_blocked_by) error = %v", err)
+	}
+	if link.Type != "is_blocked_by" || link.Issue.ID != blocker || link.Issue.StatusCategory != "todo" {
+		t.Fatalf("created link: got %+v", link)
+	}
+	var source, linkType string
+	if err := db.QueryRowContext(ctx, `SELECT source_issue_id, link_type FROM issue_links WHERE id = $1`, link.ID).Scan(&source, &linkType); err != nil {
+		t.Fatalf("read stored link: %v", err)
+	}
+	if source != blocker || linkType != LinkBlocks {
+		t.Fatalf("stored link: got source %s type %s, want %s blocks", source, linkType, blocker)
+	}
+
+	_, err = CreateLink(ctx, db, CreateLinkParams{ProjectID: seed.projectID, IssueID: blocker, TargetIssueID: blocked, Type: LinkBlocks})
+	if !errors.Is(err, ErrLinkExists) {
+		t.Fatalf("CreateLink() duplicate error = %v, want ErrLinkExists", err)
+	}
+	if _, err := CreateLink(ctx, db, CreateLinkParams{ProjectID: seed.projectID, IssueID: blocker, TargetIssueID: remote, Type: LinkRelatesTo}); err != nil {
+		t.Fatalf("CreateLink(cross-project) error = %v", err)
+	}
+	_, err = CreateLink(ctx, db, CreateLinkParams{ProjectID: seed.projectID, IssueID: blocker, TargetIssueID: foreign, Type: LinkRelatesTo})
+	if !errors.Is(err, ErrLinkTargetNotFound) {
+		t.Fatalf("CreateLink(other workspace) error = %v, want ErrLinkTargetNotFound", err)
+	}
+
+	detail, err := Get(ctx, db, seed.projectID, blocker)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(detail.Links) != 2 {
+		t.Fatalf("links: got %+v, want 2", detail.Links)
+	}
+	if detail.Links[0].Type != LinkBlocks || detail.Links[0].Issue.ID != blocked {
+		t.Fatalf("first link: got %+v", detail.Links[0])
+	}
+	if detail.Links[1].Type != LinkRelatesTo || detail.Links[1].Issue.ProjectID != sibling.projectID {
+		t.Fatalf("second link: got %+v", detail.Links[1])
+	}
+
+	if err := DeleteLink(ctx, db, sibling.projectID, remote, detail.Links[1].ID); err != nil {
+		t.Fatalf("DeleteLink() from target error = %v", err)
+	}
+	if err := DeleteLink(ctx, db, seed.projectID, blocker, detail.Links[1].ID); !errors.Is(err, ErrLinkNotFound) {
+		t.Fatalf("DeleteLink() twice error = %v, want ErrLinkNotFound", err)
+	}
+	links, err := ListLinks(ctx, db, seed.projectID, blocker)
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+	if len(links) != 1 || links[0].ID != link.ID {
+		t.Fatalf("links after delete: got %+v", links)
+	}
+}
+
+func TestIssueCustomFields(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+	if _, err := db.ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, 'member')`, seed.workspaceID, seed.reporterID); err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	var bugTypeID string
+	if err := db.GetContext(ctx, &bugTypeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Bug', 1) RETURNING id`, seed.projectID); err != nil {
+		t.Fatalf("insert bug type: %v", err)
+	}
+
+	customer, err := customfields.Create(ctx, db, customfields.CreateParams{ProjectID: seed.projectID, Name: "Customer", Type: customfields.TypeText})
+	if err != nil {
+		t.Fatalf("create text field: %v", err)
+	}
+	env, err := customfields.Create(ctx, db, customfields.CreateParams{
+		ProjectID: seed.projectID, Name: "Environments", Type: customfields.TypeMultiSelect,
+		Options: []string{"prod", "staging"}, IssueTypeIDs: []string{bugTypeID},
+	})
+	if err != nil {
+		t.Fatalf("create multi select field: %v", err)
+	}
+	owner, err := customfields.Create(ctx, db, customfields.CreateParams{ProjectID: seed.projectID, Name: "Owner", Type: customfields.TypeUser})
+	if err != nil {
+		t.Fatalf("create user field: %v", err)
+	}
+
+	issue, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueTypeID: seed.issueTypeID, StatusID: seed.statusTodoID,
+		Title: "Task", ReporterID: seed.reporterID,
+		Fields: Fields{customer.ID: json.RawMessage(`"Acme"`)},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if string(issue.Fields[customer.ID]) != `"Acme"` {
+		t.Fatalf("created fields: got %v", issue.Fields)
+	}
+
+	_, err = Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueTypeID: seed.issueTypeID, StatusID: seed.statusTodoID,
+		Title: "Task", ReporterID: seed.reporterID,
+		Fields: Fields{env.ID: json.RawMessage(`["prod"]`)},
+	})
+	if !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Fatalf("Create() with field of another issue type error = %v, want ErrInvalidValue", err)
+	}
+
+	bug, err := Create(ctx, db, CreateParams{
+		ProjectID: seed.projectID, IssueTypeID: bugTypeID, StatusID: seed.statusTodoID,
+		Title: "Bug", ReporterID: seed.reporterID,
+		Fields: Fields{env.ID: json.RawMessage(`["staging","prod"]`)},
+	})
+	if err != nil {
+		t.Fatalf("Create(bug) error = %v", err)
+	}
+	if !sameJSON(bug.Fields[env.ID], json.RawMessage(`["prod","staging"]`)) {
+		t.Fatalf("bug fields: got %s", bug.Fields[env.ID])
+	}
+
+	update := UpdateParams{IssueID: bug.ID, ProjectID: seed.projectID, Title: "Bug", Priority: "medium"}
+	update.Fields = Fields{owner.ID: json.RawMessage(`"00000000-0000-0000-0000-000000000000"`)}
+	if _, err := Update(ctx, db, update); !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Fatalf("Update() with non-member error = %v, want ErrInvalidValue", err)
+	}
+	update.Fields = Fields{owner.ID: json.RawMessage(`"` + seed.reporterID + `"`), env.ID: json.RawMessage(`null`)}
+	updated, err := Update(ctx, db, update)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, ok := updated.Fields[env.ID]; ok || updated.Fields[owner.ID] == nil {
+		t.Fatalf("updated fields: got %v", updated.Fields)
+	}
+	update.Fields = nil
+	if _, err := Update(ctx, db, update); err != nil {
+		t.Fatalf("Update() without fields error = %v", err)
+	}
+
+	got, err := Get(ctx, db, seed.projectID, bug.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Fields[owner.ID] == nil {
+		t.Fatalf("Get() fields: got %v, want the owner field", got.Fields)
+	}
+	if err := Archive(ctx, db, seed.projectID, bug.ID); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	restored, err := Restore(ctx, db, seed.projectID, bug.ID)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.Fields[owner.ID] == nil {
+		t.Fatalf("Restore() fields: got %v, want the owner field", restored.Fields)
+	}
+
+	events, err := ListActivity(ctx, db, ActivityParams{ProjectID: seed.projectID, IssueID: bug.ID})
+	if err != nil {
+		t.Fatalf("ListActivity() error = %v", err)
+	}
+	var changed bool
+	for _, event := range events {
+		if strings.Contains(string(event.Payload), "fields."+owner.ID) {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Fatalf("activity: no change recorded for the owner field in %+v", events)
+	}
+
+	for _, tt := range []struct {
+		filters map[string][]string
+		want    string
+	}{
+		{filters: map[string][]string{customer.ID: {"acm"}}, want: issue.ID},
+		{filters: map[string][]string{owner.ID: {seed.reporterID}}, want: bug.ID},
+	} {
+		list, err := List(ctx, db, ListParams{ProjectID: seed.projectID, Fields: tt.filters})
+		if err != nil {
+			t.Fatalf("List(%v) error = %v", tt.filters, err)
+		}
+		if len(list) != 1 || list[0].ID != tt.want {
+			t.Fatalf("List(%v): got %d issues, want only %s", tt.filters, len(list), tt.want)
+		}
+	}
+	if _, err := List(ctx, db, ListParams{ProjectID: seed.projectID, Fields: map[string][]string{"missing": {"x"}}}); !errors.Is(err, customfields.ErrInvalidValue) {
+		t.Fatalf("List() with unknown field error = %v, want ErrInvalidValue", err)
+	}
+
+	if err := customfields.Archive(ctx, db, seed.projectID, customer.ID); err != nil {
+		t.Fatalf("archive field: %v", err)
+	}
+	detail, err := Get(ctx, db, seed.projectID, issue.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(detail.Fields) != 0 {
+		t.Fatalf("fields after archive: got %v, want none", detail.Fields)
+	}
+}
+
+func TestGetIssueByKey(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	other := seedProject(t, db)
+	issueID := insertIssue(t, db, seed, issueSeed{number: 42, title: "Keyed", statusID: seed.statusTodoID, statusPosition: 0})
+	// seedProject keys may contain digits, which real project keys cannot.
+	projectKey := "KEYS"
+	if _, err := db.ExecContext(ctx, `UPDATE projects SET key = $1 WHERE id = $2`, projectKey, seed.projectID); err != nil {
+		t.Fatalf("set project key: %v", err)
+	}
+
+	issue, err := GetByKey(ctx, db, seed.workspaceID, strings.ToLower(projectKey)+"-42")
+	if err != nil {
+		t.Fatalf("GetByKey() error = %v", err)
+	}
+	if issue.ID != issueID || issue.Key != projectKey+"-42" {
+		t.Fatalf("GetByKey(): got id %s key %s, want %s %s-42", issue.ID, issue.Key, issueID, projectKey)
+	}
+	if _, err := GetByKey(ctx, db, other.workspaceID, projectKey+"-42"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetByKey() from other workspace error = %v, want ErrNotFound", err)
+	}
+	if _, err := GetByKey(ctx, db, seed.workspaceID, projectKey+"-43"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetByKey() unknown number error = %v, want ErrNotFound", err)
+	}
+
+	list, err := List(ctx, db, ListParams{ProjectID: seed.projectID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].Key != projectKey+"-42" {
+		t.Fatalf("List(): got %+v, want key %s-42", list, projectKey)
+	}
+}
+
+func TestTransferIssue(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+	if _, err := db.ExecContext(ctx, `UPDATE projects SET key = 'SRC' WHERE id = $1`, seed.projectID); err != nil {
+		t.Fatalf("set source key: %v", err)
+	}
+
+	var subtaskID, targetID, targetTaskID, targetSubtaskID, targetTodoID, targetDoneID, targetLabelID string
+	for dest, query := range map[*string]string{
+		&subtaskID: `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Subtask', 2) RETURNING id`,
+		&targetID:  `INSERT INTO projects (workspace_id, name, key, description) SELECT workspace_id, 'Target', 'TGT', '' FROM projects WHERE id = $1 RETURNING id`,
+	} {
+		if err := db.GetContext(ctx, dest, query, seed.projectID); err != nil {
+			t.Fatalf("seed source data: %v", err)
+		}
+	}
+	for dest, query := range map[*string]string{
+		&targetTaskID:    `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'TASK', 1) RETURNING id`,
+		&targetSubtaskID: `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Subtask', 2) RETURNING id`,
+		&targetTodoID:    `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'POR HACER', 'todo', 0) RETURNING id`,
+		&targetDoneID:    `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Hecho', 'done', 1) RETURNING id`,
+		&targetLabelID:   `INSERT INTO labels (project_id, name, color) VALUES ($1, 'BUG', '#ff0000') RETURNING id`,
+	} {
+		if err := db.GetContext(ctx, dest, query, targetID); err != nil {
+			t.Fatalf("seed target data: %v", err)
+		}
+	}
+	existing, err := Create(ctx, db, CreateParams{
+		ProjectID: targetID, IssueTypeID: targetTaskID, StatusID: targetTodoID,
+		Title: "Existing", ReporterID: seed.reporterID, Priority: "medium",
+	})
+	if err != nil {
+		t.Fatalf("seed target issue: %v", err)
+	}
+
+	parent := insertIssue(t, db, seed, issueSeed{number: 1, title: "Parent", statusID: seed.statusTodoID, statusPosition: 0})
+	child := insertIssue(t, db, seed, issueSeed{number: 2, title: "Child", statusID: seed.statusDoingID, statusPosition: 0})
+	linked := insertIssue(t, db, seed, issueSeed{number: 3, title: "Linked", statusID: seed.statusTodoID, statusPosition: 1})
+	if _, err := db.ExecContext(ctx, `UPDATE issues SET issue_type_id = $1, parent_issue_id = $2 WHERE id = $3`, subtaskID, parent, child); err != nil {
+		t.Fatalf("make child: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO issue_links (source_issue_id, target_issue_id, link_type) VALUES ($1, $2, 'blocks')`, parent, linked); err != nil {
+		t.Fatalf("link issues: %v", err)
+	}
+	if _, err := db.ExecContext(ctx,
+		`WITH l AS (INSERT INTO labels (project_id, name, color) VALUES ($1, 'bug', '#00ff00') RETURNING id)
+		 INSERT INTO issue_labels (issue_id, label_id) SELECT $2, id FROM l`,
+		seed.projectID, parent,
+	); err != nil {
+		t.Fatalf("label issue: %v", err)
+	}
+
+	params := TransferParams{ProjectID: seed.projectID, IssueID: parent, TargetProjectID: targetID}
+	if _, err := Transfer(ctx, db, params); !errors.Is(err, ErrUnmapped) {
+		t.Fatalf("Transfer() with an unmatched status error = %v, want %v", err, ErrUnmapped)
+	}
+	params.StatusMap = map[string]string{seed.statusDoingID: targetDoneID}
+	moved, err := Transfer(ctx, db, params)
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	if moved.ID != parent || moved.ProjectID != targetID || moved.Key != "TGT-2" ||
+		moved.IssueTypeID != targetTaskID || moved.StatusID != targetTodoID || moved.StatusPosition != 1 {
+		t.Fatalf("Transfer(): got %+v", moved)
+	}
+	if len(moved.Links) != 1 || len(moved.Labels) != 1 || moved.Labels[0].ID != targetLabelID {
+		t.Fatalf("Transfer(): got links %+v labels %+v, want the link and the target label", moved.Links, moved.Labels)
+	}
+
+	movedChild, err := Get(ctx, db, targetID, child)
+	if err != nil {
+		t.Fatalf("get transferred child: %v", err)
+	}
+	if movedChild.Key != "TGT-3" || movedChild.IssueTypeID != targetSubtaskID || movedChild.StatusID != targetDoneID ||
+		movedChild.ParentIssueID == nil || *movedChild.ParentIssueID != parent {
+		t.Fatalf("transferred child: got %+v", movedChild)
+	}
+
+	redirected, err := GetByKey(ctx, db, seed.workspaceID, "SRC-1")
+	if err != nil {
+		t.Fatalf("GetByKey() old key error = %v", err)
+	}
+	if redirected.ID != parent || redirected.Key != "TGT-2" {
+		t.Fatalf("GetByKey() old key: got %s %s, want %s TGT-2", redirected.ID, redirected.Key, parent)
+	}
+	if byNewKey, err := GetByKey(ctx, db, seed.workspaceID, "TGT-1"); err != nil || byNewKey.ID != existing.ID {
+		t.Fatalf("GetByKey(TGT-1): got %+v, %v, want the existing issue", byNewKey, err)
+	}
+
+	events, err := ListActivity(ctx, db, ActivityParams{ProjectID: targetID, IssueID: parent})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(events) == 0 || events[0].EventType != EventTransferred {
+		t.Fatalf("activity: got %+v, want a transferred event first", events)
+	}
+	var count int
+	if err := db.GetContext(ctx, &count, `SELECT COUNT(*) FROM issues WHERE id IN ($1, $2)`, parent, child); err != nil || count != 2 {
+		t.Fatalf("issue rows after transfer: got %d, %v, want 2", count, err)
+	}
+}
+
+func TestTransferIssue_ArchivedDescendants(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	var spikeID, oldStatusID, targetID, targetTaskID, targetTodoID, targetBugID, targetTriageID string
+	for dest, query := range map[*string]string{
+		&spikeID:     `INSERT INTO issue_types (project_id, name, level, archived_at) VALUES ($1, 'Spike', 2, NOW()) RETURNING id`,
+		&oldStatusID: `INSERT INTO statuses (project_id, name, category, position, archived_at) VALUES ($1, 'Old', 'todo', 5, NOW()) RETURNING id`,
+		&targetID:    `INSERT INTO projects (workspace_id, name, key, description) SELECT workspace_id, 'Target', 'TGT', '' FROM projects WHERE id = $1 RETURNING id`,
+	} {
+		if err := db.GetContext(ctx, dest, query, seed.projectID); err != nil {
+			t.Fatalf("seed source data: %v", err)
+		}
+	}
+	for dest, query := range map[*string]string{
+		&targetTaskID: `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`,
+		&targetTodoID: `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Por hacer', 'todo', 0) RETURNING id`,
+	} {
+		if err := db.GetContext(ctx, dest, query, targetID); err != nil {
+			t.Fatalf("seed target data: %v", err)
+		}
+	}
+
+	// The child sits in an archived type and status the target lacks.
+	parent := insertIssue(t, db, seed, issueSeed{number: 1, title: "Parent", statusID: seed.statusTodoID, statusPosition: 0})
+	child := insertIssue(t, db, seed, issueSeed{number: 2, title: "Child", statusID: seed.statusTodoID, statusPosition: 1})
+	if _, err := db.ExecContext(ctx,
+		`UPDATE issues SET issue_type_id = $1, status_id = $2, parent_issue_id = $3, archived_at = NOW() WHERE id = $4`,
+		spikeID, oldStatusID, parent, child,
+	); err != nil {
+		t.Fatalf("archive child: %v", err)
+	}
+
+	params := TransferParams{ProjectID: seed.projectID, IssueID: parent, TargetProjectID: targetID}
+	if _, err := Transfer(ctx, db, params); !errors.Is(err, ErrUnmapped) || !strings.Contains(err.Error(), "archived issue") {
+		t.Fatalf("Transfer() with no type below the parent error = %v, want %v naming the archived issue", err, ErrUnmapped)
+	}
+
+	if err := db.GetContext(ctx, &targetTriageID,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Triage', 'todo', 1) RETURNING id`, targetID,
+	); err != nil {
+		t.Fatalf("seed target status: %v", err)
+	}
+	if err := db.GetContext(ctx, &targetBugID,
+		`INSERT INTO issue_types (project_id, name, level, default_status_id) VALUES ($1, 'Bug', 2, $2) RETURNING id`,
+		targetID, targetTriageID,
+	); err != nil {
+		t.Fatalf("seed target type: %v", err)
+	}
+	if _, err := Transfer(ctx, db, params); err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	var moved struct {
+		ProjectID   string     `db:"project_id"`
+		IssueTypeID string     `db:"issue_type_id"`
+		StatusID    string     `db:"status_id"`
+		ArchivedAt  *time.Time `db:"archived_at"`
+	}
+	if err := db.GetContext(ctx, &moved, `SELECT project_id, issue_type_id, status_id, archived_at FROM issues WHERE id = $1`, child); err != nil {
+		t.Fatalf("load transferred child: %v", err)
+	}
+	if moved.ProjectID != targetID || moved.IssueTypeID != targetBugID || moved.StatusID != targetTriageID || moved.ArchivedAt == nil {
+		t.Fatalf("archived child: got %+v, want it archived in the target as a Bug in Triage", moved)
+	}
+}
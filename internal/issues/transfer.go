@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrTargetProjectNotFound = errors.New("target project not found in workspace")
+	ErrUnmapped              = errors.New("issue type or status has no counterpart in the target project")
+)
+
+// TransferParams moves an issue, with all its descendants, to another
+// project of the same workspace. Issue types and statuses are matched by
+// name (case-insensitive) unless TypeMap or StatusMap maps a source ID to a
+// target ID explicitly.
+type TransferParams struct {
+	ProjectID       string
+	IssueID         string
+	TargetProjectID string
+	TypeMap         map[string]string
+	StatusMap       map[string]string
+}
+
+func (params TransferParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if params.TargetProjectID == "" {
+		return errors.New("target_project_id is required")
+	}
+	if params.TargetProjectID == params.ProjectID {
+		return errors.New("target_project_id must be a different project")
+	}
+	for source, target := range params.TypeMap {
+		if source == "" || target == "" {
+			return errors.New("type_map entries must map an issue type ID to an issue type ID")
+		}
+	}
+	for source, target := range params.StatusMap {
+		if source == "" || target == "" {
+			return errors.New("status_map entries must map a status ID to a status ID")
+		}
+	}
+	return nil
+}
+
+// Transfer moves the issue and its descendants to the target project. Each
+// keeps its ID, comments, links and activity, gets a new number from the
+// target project and leaves its old key behind as a redirect for GetByKey.
+// The issue is detached from its parent and every moved issue leaves its
+// sprint; labels and custom field values carry over where the target
+// project has one with the same name. Archived descendants whose issue type
+// or status has no counterpart take the lowest-level type that fits below
+// their parent and that type's default status, or the target's first
+// status. It returns the transferred issue.
+func Transfer(ctx context.Context, db *sqlx.DB, params TransferParams) (Issue, error) {
+	if db == nil {
+		return Issue{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Issue{}, err
+	}
+	return transferIssue(ctx, db, params)
+}
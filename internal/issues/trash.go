@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrStatusArchived    = errors.New("issue status is archived; restore the status first")
+	ErrIssueTypeArchived = errors.New("issue type is archived; restore the issue type first")
+)
+
+// ListTrash returns the archived issues of a project, most recently archived first.
+func ListTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Issue, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listTrash(ctx, db, projectID)
+}
+
+// Restore brings an archived issue back. Its old slots may have been taken
+// meanwhile, so it goes to the bottom of its status and of the backlog.
+func Restore(ctx context.Context, db *sqlx.DB, projectID, issueID string) (Issue, error) {
+	if db == nil {
+		return Issue{}, errors.New("db is required")
+	}
+	if projectID == "" {
+		return Issue{}, errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return Issue{}, errors.New("issue_id is required")
+	}
+	return restoreIssue(ctx, db, projectID, issueID)
+}
+
+// PurgeArchived permanently deletes issues archived before the given time,
+// with their comments, links and activity. It returns how many were deleted.
+func PurgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	if db == nil {
+		return 0, errors.New("db is required")
+	}
+	return purgeArchived(ctx, db, before)
+}
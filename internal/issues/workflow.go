@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrTransitionNotAllowed = errors.New("transition not allowed by the project workflow")
+	ErrConditionsUnmet      = errors.New("transition conditions not met")
+	ErrWIPLimit             = errors.New("move would break a blocking WIP limit")
+)
+
+// TransitionError reports a move that no workflow rule allows. Allowed names
+// the statuses the issue may move to from its current one, in workflow order.
+type TransitionError struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Allowed []string `json:"allowed"`
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("moving from %q to %q is not allowed; no transitions are allowed from %q", e.From, e.To, e.From)
+	}
+	quoted := make([]string, len(e.Allowed))
+	for i, name := range e.Allowed {
+		quoted[i] = strconv.Quote(name)
+	}
+	return fmt.Sprintf("moving from %q to %q is not allowed; allowed from %q: %s", e.From, e.To, e.From, strings.Join(quoted, ", "))
+}
+
+func (e *TransitionError) Unwrap() error { return ErrTransitionNotAllowed }
+
+// UnmetCondition is a transition validator the issue does not meet.
+type UnmetCondition struct {
+	Validator string `json:"validator"`
+	Message   string `json:"message"`
+}
+
+// ConditionsError reports a move that the workflow allows but whose
+// validators the issue does not meet yet. Unmet lists every failing one.
+type ConditionsError struct {
+	From  string           `json:"from"`
+	To    string           `json:"to"`
+	Unmet []UnmetCondition `json:"unmet"`
+}
+
+func (e *ConditionsError) Error() string {
+	messages := make([]string, len(e.Unmet))
+	for i, c := range e.Unmet {
+		messages[i] = c.Message
+	}
+	return fmt.Sprintf("moving from %q to %q requires: %s", e.From, e.To, strings.Join(messages, "; "))
+}
+
+func (e *ConditionsError) Unwrap() error { return ErrConditionsUnmet }
+
+// WIPLimitError reports a move that would take a blocking board column over
+// its maximum or under its minimum. Limit is "max" or "min"; Count is the
+// number of issues in the column before the move.
+type WIPLimitError struct {
+	Board  string `json:"board"`
+	Column string `json:"column"`
+	Limit  string `json:"limit"`
+	Value  int    `json:"value"`
+	Count  int    `json:"count"`
+}
+
+func (e *WIPLimitError) Error() string {
+	bound := "over its WIP limit"
+	if e.Limit == "min" {
+		bound = "under its WIP minimum"
+	}
+	return fmt.Sprintf("moving the issue would take column %q of board %q %s of %d (currently %d)", e.Column, e.Board, bound, e.Value, e.Count)
+}
+
+func (e *WIPLimitError) Unwrap() error { return ErrWIPLimit }
@@ -18,6 +18,7 @@ import (
 func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
 	mux.HandleFunc("POST /projects/{projectID}/issue-types", handleCreate(db))
 	mux.HandleFunc("GET /projects/{projectID}/issue-types", handleList(db))
+	mux.HandleFunc("PUT /projects/{projectID}/issue-types/{issueTypeID}", handleUpdate(db))
 	mux.HandleFunc("DELETE /projects/{projectID}/issue-types/{issueTypeID}", handleArchive(db))
 }
 
@@ -32,14 +33,27 @@ func fail(w http.ResponseWriter, err error) {
 		respond.Error(w, http.StatusNotFound, err.Error())
 	case errors.Is(err, ErrNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrDuplicate):
+	case errors.Is(err, ErrDuplicate), errors.Is(err, ErrLevelConflict):
 		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidDefaultStatus), errors.Is(err, ErrTargetRequired),
+		errors.Is(err, ErrInvalidTarget):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
 	default:
 		slog.Error("issuetypes handler error", "error", err)
 		respond.Error(w, http.StatusInternalServerError, "internal server error")
 	}
 }
 
+// typeBody is the request body of create and update.
+type typeBody struct {
+	Name                string `json:"name"`
+	Icon                string `json:"icon"`
+	Level               int    `json:"level"`
+	DefaultStatusID     string `json:"default_status_id"`
+	DefaultPriority     string `json:"default_priority"`
+	DescriptionTemplate string `json:"description_template"`
+}
+
 func handleCreate(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projID := r.PathValue("projectID")
@@ -52,20 +66,19 @@ func handleCreate(db *sqlx.DB) http.HandlerFunc {
 			fail(w, err)
 			return
 		}
-		var body struct {
-			Name  string `json:"name"`
-			Icon  string `json:"icon"`
-			Level int    `json:"level"`
-		}
+		var body typeBody
 		if err := respond.Decode(r, &body); err != nil {
 			respond.Error(w, http.StatusBadRequest, "invalid JSON")
 			return
 		}
 		params := CreateParams{
-			ProjectID: r.PathValue("projectID"),
-			Name:      body.Name,
-			Icon:      body.Icon,
-			Level:     body.Level,
+			ProjectID:           r.PathValue("projectID"),
+			Name:                body.Name,
+			Icon:                body.Icon,
+			Level:               body.Level,
+			DefaultStatusID:     body.DefaultStatusID,
+			DefaultPriority:     body.DefaultPriority,
+			DescriptionTemplate: body.DescriptionTemplate,
 		}
 		if err := params.Validate(); err != nil {
 			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
@@ -96,6 +109,46 @@ func handleList(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleUpdate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body typeBody
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateParams{
+			ProjectID:           projID,
+			IssueTypeID:         r.PathValue("issueTypeID"),
+			Name:                body.Name,
+			Icon:                body.Icon,
+			Level:               body.Level,
+			DefaultStatusID:     body.DefaultStatusID,
+			DefaultPriority:     body.DefaultPriority,
+			DescriptionTemplate: body.DescriptionTemplate,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		it, err := Update(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, it)
+	}
+}
+
 func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projID := r.PathValue("projectID")
@@ -108,7 +161,16 @@ func handleArchive(db *sqlx.DB) http.HandlerFunc {
 			fail(w, err)
 			return
 		}
-		if err := Archive(r.Context(), db, projID, r.PathValue("issueTypeID")); err != nil {
+		params := ArchiveParams{
+			ProjectID:         projID,
+			IssueTypeID:       r.PathValue("issueTypeID"),
+			TargetIssueTypeID: r.URL.Query().Get("target_issue_type_id"),
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := Archive(r.Context(), db, params); err != nil {
 			fail(w, err)
 			return
 		}
@@ -8,47 +8,132 @@ package issuetypes
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 )
 
 var (
-	ErrNotFound  = errors.New("issue type not found")
-	ErrDuplicate = errors.New("issue type name already exists in project")
+	ErrNotFound             = errors.New("issue type not found")
+	ErrDuplicate            = errors.New("issue type name already exists in project")
+	ErrInvalidDefaultStatus = errors.New("default status must be an active status of the project")
+	ErrLevelConflict        = errors.New("level would put issues of this type at or above their parent's level, or at or below their children's")
+	ErrTargetRequired       = errors.New("target_issue_type_id is required while issues use the type")
+	ErrInvalidTarget        = errors.New("target issue type must be another active issue type of the project")
 )
 
+// Icons is the icon set issue types can use. An empty icon is also allowed.
+// Migration 0029 cleared icons saved before the set existed.
+var Icons = []string{"task", "subtask", "bug", "story", "epic", "feature", "improvement", "spike", "incident", "question"}
+
+var validPriorities = map[string]bool{"low": true, "medium": true, "high": true, "critical": true}
+
+// Type is an issue type of a project. Level orders types in the issue
+// hierarchy: a child's type must have a greater level than its parent's.
+// DefaultStatusID, DefaultPriority and DescriptionTemplate fill in new issues
+// of the type when the client leaves those out. DescriptionTemplate is
+// Markdown and may use the placeholders rendered by RenderTemplate.
 type Type struct {
-	ID         string     `db:"id"          json:"id"`
-	ProjectID  string     `db:"project_id"  json:"project_id"`
-	Name       string     `db:"name"        json:"name"`
-	Icon       string     `db:"icon"        json:"icon"`
-	Level      int        `db:"level"       json:"level"`
-	CreatedAt  time.Time  `db:"created_at"  json:"created_at"`
-	UpdatedAt  time.Time  `db:"updated_at"  json:"updated_at"`
-	ArchivedAt *time.Time `db:"archived_at" json:"archived_at,omitempty"`
+	ID                  string     `db:"id"                   json:"id"`
+	ProjectID           string     `db:"project_id"           json:"project_id"`
+	Name                string     `db:"name"                 json:"name"`
+	Icon                string     `db:"icon"                 json:"icon"`
+	Level               int        `db:"level"                json:"level"`
+	DefaultStatusID     *string    `db:"default_status_id"    json:"default_status_id"`
+	DefaultPriority     *string    `db:"default_priority"     json:"default_priority"`
+	DescriptionTemplate string     `db:"description_template" json:"description_template"`
+	CreatedAt           time.Time  `db:"created_at"           json:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"           json:"updated_at"`
+	ArchivedAt          *time.Time `db:"archived_at"          json:"archived_at,omitempty"`
 }
 
 type CreateParams struct {
-	ProjectID string
-	Name      string
-	Icon      string
-	Level     int
+	ProjectID           string
+	Name                string
+	Icon                string
+	Level               int
+	DefaultStatusID     string
+	DefaultPriority     string
+	DescriptionTemplate string
 }
 
 func (params CreateParams) Validate() error {
 	if params.ProjectID == "" {
 		return errors.New("project_id is required")
 	}
-	if params.Name == "" {
+	if err := validateFields(params.Name, params.Icon, params.Level, params.DefaultPriority); err != nil {
+		return err
+	}
+	return validateTemplate(params.DescriptionTemplate)
+}
+
+// UpdateParams replaces the editable fields of an issue type. Empty
+// DefaultStatusID and DefaultPriority clear those defaults.
+type UpdateParams struct {
+	ProjectID           string
+	IssueTypeID         string
+	Name                string
+	Icon                string
+	Level               int
+	DefaultStatusID     string
+	DefaultPriority     string
+	DescriptionTemplate string
+}
+
+func (params UpdateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueTypeID == "" {
+		return errors.New("issue_type_id is required")
+	}
+	if err := validateFields(params.Name, params.Icon, params.Level, params.DefaultPriority); err != nil {
+		return err
+	}
+	return validateTemplate(params.DescriptionTemplate)
+}
+
+// ArchiveParams archives an issue type. TargetIssueTypeID receives the
+// type's issues and may be left empty when no issue uses it.
+type ArchiveParams struct {
+	ProjectID         string
+	IssueTypeID       string
+	TargetIssueTypeID string
+}
+
+func (params ArchiveParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueTypeID == "" {
+		return errors.New("issue_type_id is required")
+	}
+	if params.TargetIssueTypeID == params.IssueTypeID {
+		return errors.New("target_issue_type_id must be a different issue type")
+	}
+	return nil
+}
+
+func validateFields(name, icon string, level int, defaultPriority string) error {
+	if name == "" {
 		return errors.New("name is required")
 	}
-	if params.Level < 0 {
+	if icon != "" && !slices.Contains(Icons, icon) {
+		return errors.New("icon must be one of " + strings.Join(Icons, ", "))
+	}
+	if level < 0 {
 		return errors.New("level must be >= 0")
 	}
+	if defaultPriority != "" && !validPriorities[defaultPriority] {
+		return errors.New("default_priority must be 'low', 'medium', 'high' or 'critical'")
+	}
 	return nil
 }
 
+// Create adds an issue type to a project. It returns ErrInvalidDefaultStatus
+// when the default status is archived or belongs to another project.
 func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Type, error) {
 	if db == nil {
 		return Type{}, errors.New("db is required")
@@ -69,15 +154,28 @@ func List(ctx context.Context, db *sqlx.DB, projectID string) ([]Type, error) {
 	return listIssueTypes(ctx, db, projectID)
 }
 
-func Archive(ctx context.Context, db *sqlx.DB, projectID, issueTypeID string) error {
+// Update changes an issue type. Changing the level returns ErrLevelConflict
+// when it would break the parent-child order of existing issues.
+func Update(ctx context.Context, db *sqlx.DB, params UpdateParams) (Type, error) {
 	if db == nil {
-		return errors.New("db is required")
+		return Type{}, errors.New("db is required")
 	}
-	if projectID == "" {
-		return errors.New("project_id is required")
+	if err := params.Validate(); err != nil {
+		return Type{}, err
 	}
-	if issueTypeID == "" {
-		return errors.New("issue_type_id is required")
+	return updateIssueType(ctx, db, params)
+}
+
+// Archive archives an issue type, first moving all its issues, archived ones
+// included, to the target type in the same transaction. It returns
+// ErrTargetRequired when issues use the type but no target is given and
+// ErrLevelConflict when the target's level does not fit their hierarchy.
+func Archive(ctx context.Context, db *sqlx.DB, params ArchiveParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
 	}
-	return archiveIssueType(ctx, db, projectID, issueTypeID)
+	return archiveIssueType(ctx, db, params)
 }
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issuetypes
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CreateParams
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			params: CreateParams{ProjectID: "p-1", Name: "Bug", Icon: "bug", Level: 1, DefaultPriority: "high"},
+		},
+		{
+			name:   "empty icon",
+			params: CreateParams{ProjectID: "p-1", Name: "Bug"},
+		},
+		{
+			name:    "missing project_id",
+			params:  CreateParams{Name: "Bug"},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			params:  CreateParams{ProjectID: "p-1"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown icon",
+			params:  CreateParams{ProjectID: "p-1", Name: "Bug", Icon: "beetle"},
+			wantErr: true,
+		},
+		{
+			name:    "negative level",
+			params:  CreateParams{ProjectID: "p-1", Name: "Bug", Level: -1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid default priority",
+			params:  CreateParams{ProjectID: "p-1", Name: "Bug", DefaultPriority: "urgent"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown template placeholder",
+			params:  CreateParams{ProjectID: "p-1", Name: "Bug", DescriptionTemplate: "Seen by {{owner}}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  UpdateParams
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			params: UpdateParams{ProjectID: "p-1", IssueTypeID: "t-1", Name: "Story", Icon: "story"},
+		},
+		{
+			name:    "missing issue_type_id",
+			params:  UpdateParams{ProjectID: "p-1", Name: "Story"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown icon",
+			params:  UpdateParams{ProjectID: "p-1", IssueTypeID: "t-1", Name: "Story", Icon: "book"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArchiveParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ArchiveParams
+		wantErr bool
+	}{
+		{
+			name:   "without target",
+			params: ArchiveParams{ProjectID: "p-1", IssueTypeID: "t-1"},
+		},
+		{
+			name:   "with target",
+			params: ArchiveParams{ProjectID: "p-1", IssueTypeID: "t-1", TargetIssueTypeID: "t-2"},
+		},
+		{
+			name:    "target is the archived type",
+			params:  ArchiveParams{ProjectID: "p-1", IssueTypeID: "t-1", TargetIssueTypeID: "t-1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreate_NilDB(t *testing.T) {
+	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p-1", Name: "Bug"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Create() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestUpdate_NilDB(t *testing.T) {
+	_, err := Update(context.Background(), nil, UpdateParams{ProjectID: "p-1", IssueTypeID: "t-1", Name: "Bug"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Update() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestArchive_NilDB(t *testing.T) {
+	err := Archive(context.Background(), nil, ArchiveParams{ProjectID: "p-1", IssueTypeID: "t-1"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Archive() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := TemplateData{Reporter: "Ada", Date: "2025-03-14", Project: "Tookly", IssueType: "Bug"}
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "all placeholders",
+			tmpl: "{{reporter}} filed a {{issue_type}} in {{project}} on {{date}}",
+			want: "Ada filed a Bug in Tookly on 2025-03-14",
+		},
+		{
+			name: "whitespace inside braces",
+			tmpl: "## {{ project }} / {{  issue_type}}",
+			want: "## Tookly / Bug",
+		},
+		{
+			name: "repeated placeholder",
+			tmpl: "{{reporter}}, {{reporter}}",
+			want: "Ada, Ada",
+		},
+		{
+			name: "unknown placeholder left as written",
+			tmpl: "Owner: {{ owner }} ({{reporter}})",
+			want: "Owner: {{ owner }} (Ada)",
+		},
+		{
+			name: "not a placeholder",
+			tmpl: "{{Reporter}} {reporter} {{ }}",
+			want: "{{Reporter}} {reporter} {{ }}",
+		},
+		{
+			name: "empty template",
+			tmpl: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderTemplate(tt.tmpl, data); got != tt.want {
+				t.Fatalf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr bool
+	}{
+		{name: "empty", tmpl: ""},
+		{name: "plain markdown", tmpl: "## Steps\n\n1. "},
+		{name: "known placeholders", tmpl: "{{reporter}} {{date}} {{project}} {{issue_type}}"},
+		{name: "whitespace inside braces", tmpl: "{{ reporter }}"},
+		{name: "unknown placeholder", tmpl: "{{owner}}", wantErr: true},
+		{name: "unknown among known", tmpl: "{{reporter}} {{ due_date }}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTemplate(tt.tmpl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
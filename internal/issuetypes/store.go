@@ -7,21 +7,29 @@ package issuetypes
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/activity"
 	"github.com/start-codex/tookly/internal/pgutil"
 )
 
-const issueTypeCols = `id, project_id, name, icon, level, created_at, updated_at, archived_at`
+const issueTypeCols = `id, project_id, name, icon, level, default_status_id, default_priority,
+	description_template, created_at, updated_at, archived_at`
 
 func createIssueType(ctx context.Context, db *sqlx.DB, params CreateParams) (Type, error) {
+	if err := checkDefaultStatus(ctx, db, params.ProjectID, params.DefaultStatusID); err != nil {
+		return Type{}, err
+	}
 	var issueType Type
 	err := db.QueryRowxContext(ctx,
-		`INSERT INTO issue_types (project_id, name, icon, level)
-		 VALUES ($1, $2, $3, $4)
+		`INSERT INTO issue_types (project_id, name, icon, level, default_status_id, default_priority, description_template)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
 		 RETURNING `+issueTypeCols,
 		params.ProjectID, params.Name, params.Icon, params.Level,
+		optional(params.DefaultStatusID), optional(params.DefaultPriority), params.DescriptionTemplate,
 	).StructScan(&issueType)
 	if err != nil {
 		if pgutil.IsUniqueViolation(err) {
@@ -47,24 +55,206 @@ func listIssueTypes(ctx context.Context, db *sqlx.DB, projectID string) ([]Type,
 	return issueTypes, nil
 }
 
-func archiveIssueType(ctx context.Context, db *sqlx.DB, projectID, issueTypeID string) error {
-	res, err := db.ExecContext(ctx,
-		`UPDATE issue_types
-		 SET archived_at = NOW()
-		 WHERE id         = $1
-		   AND project_id = $2
-		   AND archived_at IS NULL`,
-		issueTypeID, projectID,
-	)
+func updateIssueType(ctx context.Context, db *sqlx.DB, params UpdateParams) (Type, error) {
+	var issueType Type
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit issue type update", func(tx *sqlx.Tx) error {
+		var level int
+		if err := tx.GetContext(ctx, &level,
+			`SELECT level
+			 FROM issue_types
+			 WHERE id         = $1
+			   AND project_id = $2
+			   AND archived_at IS NULL
+			 FOR UPDATE`,
+			params.IssueTypeID, params.ProjectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("lock issue type: %w", err)
+		}
+		if err := checkDefaultStatus(ctx, tx, params.ProjectID, params.DefaultStatusID); err != nil {
+			return err
+		}
+		if params.Level != level {
+			if err := checkHierarchy(ctx, tx, params.IssueTypeID, params.Level); err != nil {
+				return err
+			}
+		}
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE issue_types
+			 SET name                 = $2,
+			     icon                 = $3,
+			     level                = $4,
+			     default_status_id    = $5,
+			     default_priority     = $6,
+			     description_template = $7
+			 WHERE id = $1
+			 RETURNING `+issueTypeCols,
+			params.IssueTypeID, params.Name, params.Icon, params.Level,
+			optional(params.DefaultStatusID), optional(params.DefaultPriority), params.DescriptionTemplate,
+		).StructScan(&issueType); err != nil {
+			if pgutil.IsUniqueViolation(err) {
+				return ErrDuplicate
+			}
+			return fmt.Errorf("update issue type: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("archive issue type: %w", err)
+		return Type{}, err
 	}
-	n, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("archive issue type rows affected: %w", err)
+	return issueType, nil
+}
+
+func archiveIssueType(ctx context.Context, db *sqlx.DB, params ArchiveParams) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit issue type archive", func(tx *sqlx.Tx) error {
+		var active []Type
+		if err := tx.SelectContext(ctx, &active,
+			`SELECT `+issueTypeCols+`
+			 FROM issue_types
+			 WHERE project_id = $1
+			   AND archived_at IS NULL
+			 ORDER BY id
+			 FOR UPDATE`,
+			params.ProjectID,
+		); err != nil {
+			return fmt.Errorf("lock issue types: %w", err)
+		}
+		var found bool
+		var target *Type
+		for i := range active {
+			switch active[i].ID {
+			case params.IssueTypeID:
+				found = true
+			case params.TargetIssueTypeID:
+				target = &active[i]
+			}
+		}
+		if !found {
+			return ErrNotFound
+		}
+		if params.TargetIssueTypeID != "" && target == nil {
+			return ErrInvalidTarget
+		}
+
+		var issueIDs []string
+		if err := tx.SelectContext(ctx, &issueIDs,
+			`SELECT id
+			 FROM issues
+			 WHERE project_id = $1
+			   AND issue_type_id = $2
+			 ORDER BY id
+			 FOR UPDATE`,
+			params.ProjectID, params.IssueTypeID,
+		); err != nil {
+			return fmt.Errorf("lock issue type issues: %w", err)
+		}
+		if len(issueIDs) > 0 {
+			if target == nil {
+				return ErrTargetRequired
+			}
+			if err := checkHierarchy(ctx, tx, params.IssueTypeID, target.Level); err != nil {
+				return err
+			}
+			if err := migrateIssues(ctx, tx, params, issueIDs); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE issue_types SET archived_at = NOW() WHERE id = $1`,
+			params.IssueTypeID,
+		); err != nil {
+			return fmt.Errorf("archive issue type: %w", err)
+		}
+		return nil
+	})
+}
+
+// migrateIssues moves the given issues to the target type and records an
+// updated event for each.
+func migrateIssues(ctx context.Context, tx *sqlx.Tx, params ArchiveParams, issueIDs []string) error {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issues
+		 SET issue_type_id = $3
+		 WHERE project_id = $1
+		   AND issue_type_id = $2`,
+		params.ProjectID, params.IssueTypeID, params.TargetIssueTypeID,
+	); err != nil {
+		return fmt.Errorf("migrate issue type issues: %w", err)
+	}
+
+	actorID := activity.Actor(ctx, "")
+	payload := map[string]any{
+		"changes": map[string]any{
+			"issue_type_id": map[string]string{"from": params.IssueTypeID, "to": params.TargetIssueTypeID},
+		},
 	}
-	if n == 0 {
-		return ErrNotFound
+	for _, issueID := range issueIDs {
+		if err := activity.Record(ctx, tx, issueID, actorID, activity.Updated, payload); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// checkDefaultStatus returns ErrInvalidDefaultStatus unless statusID is empty
+// or an active status of the project.
+func checkDefaultStatus(ctx context.Context, q sqlx.QueryerContext, projectID, statusID string) error {
+	if statusID == "" {
+		return nil
+	}
+	var ok bool
+	if err := sqlx.GetContext(ctx, q, &ok,
+		`SELECT EXISTS(
+		   SELECT 1 FROM statuses
+		   WHERE id = $1 AND project_id = $2 AND archived_at IS NULL
+		 )`,
+		statusID, projectID,
+	); err != nil {
+		return fmt.Errorf("check default status: %w", err)
+	}
+	if !ok {
+		return ErrInvalidDefaultStatus
+	}
+	return nil
+}
+
+// checkHierarchy returns ErrLevelConflict when giving the issues of a type the
+// given level would put one at or above its parent's level, or at or below
+// one of its children's.
+func checkHierarchy(ctx context.Context, tx *sqlx.Tx, issueTypeID string, level int) error {
+	var conflict bool
+	if err := tx.GetContext(ctx, &conflict,
+		`SELECT EXISTS(
+		   SELECT 1
+		   FROM issues c
+		   JOIN issues p       ON p.id = c.parent_issue_id
+		   JOIN issue_types pt ON pt.id = p.issue_type_id
+		   WHERE c.issue_type_id = $1
+		     AND pt.level >= $2
+		 ) OR EXISTS(
+		   SELECT 1
+		   FROM issues p
+		   JOIN issues c       ON c.parent_issue_id = p.id
+		   JOIN issue_types ct ON ct.id = c.issue_type_id
+		   WHERE p.issue_type_id = $1
+		     AND ct.level <= $2
+		 )`,
+		issueTypeID, level,
+	); err != nil {
+		return fmt.Errorf("check issue hierarchy: %w", err)
+	}
+	if conflict {
+		return ErrLevelConflict
+	}
+	return nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
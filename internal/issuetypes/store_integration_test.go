@@ -0,0 +1,232 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issuetypes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+type typesSeed struct {
+	projectID  string
+	reporterID string
+	statusID   string
+	number     int
+}
+
+func seedTypesProject(t *testing.T, db *sqlx.DB) *typesSeed {
+	t.Helper()
+	ws := testpg.SeedWorkspace(t, db)
+	seed := &typesSeed{
+		projectID:  testpg.SeedProject(t, db, ws, "TYP"),
+		reporterID: testpg.SeedUser(t, db),
+	}
+	if err := db.Get(&seed.statusID,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'To Do', 'todo', 0) RETURNING id`,
+		seed.projectID,
+	); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	return seed
+}
+
+func (seed *typesSeed) issue(t *testing.T, db *sqlx.DB, typeID string, parentID *string) string {
+	t.Helper()
+	seed.number++
+	var id string
+	if err := db.Get(&id,
+		`INSERT INTO issues (project_id, number, issue_type_id, status_id, parent_issue_id, title, reporter_id, status_position)
+		 VALUES ($1, $2, $3, $4, $5, 'Issue', $6, $2)
+		 RETURNING id`,
+		seed.projectID, seed.number, typeID, seed.statusID, parentID, seed.reporterID,
+	); err != nil {
+		t.Fatalf("seed issue: %v", err)
+	}
+	return id
+}
+
+func TestUpdateIssueType(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+	seed := seedTypesProject(t, db)
+
+	epic, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Epic", Icon: "epic", Level: 1})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	task, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Task", Icon: "task", Level: 2})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	parent := seed.issue(t, db, epic.ID, nil)
+	seed.issue(t, db, task.ID, &parent)
+
+	updated, err := Update(ctx, db, UpdateParams{
+		ProjectID: seed.projectID, IssueTypeID: task.ID, Name: "Work item", Icon: "feature", Level: 3,
+		DefaultStatusID: seed.statusID, DefaultPriority: "low", DescriptionTemplate: "By {{reporter}}",
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Name != "Work item" || updated.Icon != "feature" || updated.Level != 3 ||
+		updated.DefaultStatusID == nil || *updated.DefaultStatusID != seed.statusID ||
+		updated.DefaultPriority == nil || *updated.DefaultPriority != "low" {
+		t.Fatalf("updated type: got %+v", updated)
+	}
+
+	// Defaults clear when left empty.
+	cleared, err := Update(ctx, db, UpdateParams{ProjectID: seed.projectID, IssueTypeID: task.ID, Name: "Work item", Level: 3})
+	if err != nil {
+		t.Fatalf("Update() clearing defaults error = %v", err)
+	}
+	if cleared.DefaultStatusID != nil || cleared.DefaultPriority != nil || cleared.Icon != "" {
+		t.Fatalf("cleared type: got %+v", cleared)
+	}
+
+	tests := []struct {
+		name    string
+		params  UpdateParams
+		wantErr error
+	}{
+		{
+			name:    "child level at its parent's",
+			params:  UpdateParams{ProjectID: seed.projectID, IssueTypeID: task.ID, Name: "Work item", Level: 1},
+			wantErr: ErrLevelConflict,
+		},
+		{
+			name:    "parent level at its child's",
+			params:  UpdateParams{ProjectID: seed.projectID, IssueTypeID: epic.ID, Name: "Epic", Level: 3},
+			wantErr: ErrLevelConflict,
+		},
+		{
+			name:    "duplicate name",
+			params:  UpdateParams{ProjectID: seed.projectID, IssueTypeID: task.ID, Name: "Epic", Level: 3},
+			wantErr: ErrDuplicate,
+		},
+		{
+			name:    "default status of another project",
+			params:  UpdateParams{ProjectID: seed.projectID, IssueTypeID: task.ID, Name: "Work item", Level: 3, DefaultStatusID: seedTypesProject(t, db).statusID},
+			wantErr: ErrInvalidDefaultStatus,
+		},
+		{
+			name:    "unknown type",
+			params:  UpdateParams{ProjectID: seed.projectID, IssueTypeID: "00000000-0000-0000-0000-000000000000", Name: "Ghost"},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Update(ctx, db, tt.params); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := db.Exec(`UPDATE statuses SET archived_at = NOW() WHERE id = $1`, seed.statusID); err != nil {
+		t.Fatalf("archive status: %v", err)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Bug", DefaultStatusID: seed.statusID}); !errors.Is(err, ErrInvalidDefaultStatus) {
+		t.Fatalf("Create() with an archived default status error = %v, want %v", err, ErrInvalidDefaultStatus)
+	}
+}
+
+func TestArchiveIssueType(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	seed := seedTypesProject(t, db)
+	ctx := authz.WithUserID(context.Background(), seed.reporterID)
+
+	newType := func(name string, level int) Type {
+		t.Helper()
+		issueType, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: name, Level: level})
+		if err != nil {
+			t.Fatalf("create type %q: %v", name, err)
+		}
+		return issueType
+	}
+	epic := newType("Epic", 1)
+	story := newType("Story", 2)
+	task := newType("Task", 2)
+	subtask := newType("Subtask", 3)
+	unused := newType("Spike", 2)
+
+	parent := seed.issue(t, db, epic.ID, nil)
+	first := seed.issue(t, db, story.ID, &parent)
+	second := seed.issue(t, db, story.ID, &parent)
+	seed.issue(t, db, subtask.ID, &first)
+	if _, err := db.Exec(`UPDATE issues SET archived_at = NOW() WHERE id = $1`, second); err != nil {
+		t.Fatalf("archive issue: %v", err)
+	}
+
+	if err := Archive(ctx, db, ArchiveParams{ProjectID: seed.projectID, IssueTypeID: unused.ID}); err != nil {
+		t.Fatalf("Archive() of an unused type error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		params  ArchiveParams
+		wantErr error
+	}{
+		{
+			name:    "target required",
+			params:  ArchiveParams{ProjectID: seed.projectID, IssueTypeID: story.ID},
+			wantErr: ErrTargetRequired,
+		},
+		{
+			name:    "archived target",
+			params:  ArchiveParams{ProjectID: seed.projectID, IssueTypeID: story.ID, TargetIssueTypeID: unused.ID},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "target level breaks the hierarchy",
+			params:  ArchiveParams{ProjectID: seed.projectID, IssueTypeID: story.ID, TargetIssueTypeID: subtask.ID},
+			wantErr: ErrLevelConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Archive(ctx, db, tt.params); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Archive() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := Archive(ctx, db, ArchiveParams{ProjectID: seed.projectID, IssueTypeID: story.ID, TargetIssueTypeID: task.ID}); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	var migrated int
+	if err := db.Get(&migrated, `SELECT COUNT(*) FROM issues WHERE issue_type_id = $1 AND id IN ($2, $3)`, task.ID, first, second); err != nil {
+		t.Fatalf("count migrated issues: %v", err)
+	}
+	if migrated != 2 {
+		t.Fatalf("migrated issues: got %d, want 2 including the archived one", migrated)
+	}
+	var events int
+	if err := db.Get(&events,
+		`SELECT COUNT(*) FROM issue_events WHERE issue_id IN ($1, $2) AND event_type = 'updated' AND actor_id = $3`,
+		first, second, seed.reporterID,
+	); err != nil {
+		t.Fatalf("count updated events: %v", err)
+	}
+	if events != 2 {
+		t.Fatalf("updated events: got %d, want 2", events)
+	}
+	list, err := List(ctx, db, seed.projectID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, issueType := range list {
+		if issueType.ID == story.ID || issueType.ID == unused.ID {
+			t.Fatalf("archived type %q is still listed", issueType.Name)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package issuetypes
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Placeholders a description template may use, written as {{name}}.
+const (
+	PlaceholderReporter  = "reporter"
+	PlaceholderDate      = "date"
+	PlaceholderProject   = "project"
+	PlaceholderIssueType = "issue_type"
+)
+
+var placeholders = []string{PlaceholderReporter, PlaceholderDate, PlaceholderProject, PlaceholderIssueType}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// TemplateData holds the values substituted into a description template.
+// Date is the creation date, formatted as YYYY-MM-DD.
+type TemplateData struct {
+	Reporter  string
+	Date      string
+	Project   string
+	IssueType string
+}
+
+// RenderTemplate fills the placeholders of a Markdown description template.
+// Unknown placeholders are left as written.
+func RenderTemplate(tmpl string, data TemplateData) string {
+	values := map[string]string{
+		PlaceholderReporter:  data.Reporter,
+		PlaceholderDate:      data.Date,
+		PlaceholderProject:   data.Project,
+		PlaceholderIssueType: data.IssueType,
+	}
+	return placeholderPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+func validateTemplate(tmpl string) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(tmpl, -1) {
+		if !slices.Contains(placeholders, match[1]) {
+			return fmt.Errorf("description_template: unknown placeholder %s; use {{%s}}", match[0], strings.Join(placeholders, "}}, {{"))
+		}
+	}
+	return nil
+}
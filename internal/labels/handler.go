@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package labels
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/respond"
+)
+
+func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
+	mux.HandleFunc("POST /projects/{projectID}/labels", handleCreate(db))
+	mux.HandleFunc("GET /projects/{projectID}/labels", handleList(db))
+	mux.HandleFunc("PUT /projects/{projectID}/labels/{labelID}", handleUpdate(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/labels/{labelID}", handleDelete(db))
+	mux.HandleFunc("POST /projects/{projectID}/issues/{issueID}/labels", handleAddToIssue(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/issues/{issueID}/labels/{labelID}", handleRemoveFromIssue(db))
+}
+
+func fail(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		respond.Error(w, http.StatusUnauthorized, "authentication required")
+	case errors.Is(err, authz.ErrForbidden):
+		respond.Error(w, http.StatusForbidden, "forbidden")
+	case errors.Is(err, authz.ErrWorkspaceNotFound),
+		errors.Is(err, authz.ErrProjectNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrIssueNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrDuplicate):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidColor):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		slog.Error("labels handler error", "error", err)
+		respond.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func handleCreate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CreateParams{
+			ProjectID: projID,
+			Name:      body.Name,
+			Color:     body.Color,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		label, err := Create(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, label)
+	}
+}
+
+func handleList(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		if _, err := authz.RequireProjectMembership(r.Context(), db, projID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := List(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleUpdate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateParams{
+			LabelID:   r.PathValue("labelID"),
+			ProjectID: projID,
+			Name:      body.Name,
+			Color:     body.Color,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		label, err := Update(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, label)
+	}
+}
+
+func handleDelete(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := Delete(r.Context(), db, projID, r.PathValue("labelID")); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleAddToIssue(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			LabelIDs []string `json:"label_ids"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := IssueParams{
+			ProjectID: r.PathValue("projectID"),
+			IssueID:   r.PathValue("issueID"),
+			LabelIDs:  body.LabelIDs,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := AddToIssue(r.Context(), db, params); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleRemoveFromIssue(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := RemoveFromIssue(r.Context(), db, r.PathValue("projectID"), r.PathValue("issueID"), r.PathValue("labelID")); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package labels
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultColor is used when a label is created without a color.
+const DefaultColor = "#6b7280"
+
+var (
+	ErrNotFound      = errors.New("label not found")
+	ErrDuplicate     = errors.New("label name already exists in project")
+	ErrIssueNotFound = errors.New("issue not found")
+	ErrInvalidColor  = errors.New("color must be a hex color like #1f6feb")
+)
+
+var reColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Label struct {
+	ID        string    `db:"id"         json:"id"`
+	ProjectID string    `db:"project_id" json:"project_id"`
+	Name      string    `db:"name"       json:"name"`
+	Color     string    `db:"color"      json:"color"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type CreateParams struct {
+	ProjectID string
+	Name      string
+	Color     string
+}
+
+func (params CreateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if err := validateName(params.Name); err != nil {
+		return err
+	}
+	if params.Color != "" && !reColor.MatchString(params.Color) {
+		return ErrInvalidColor
+	}
+	return nil
+}
+
+type UpdateParams struct {
+	LabelID   string
+	ProjectID string
+	Name      string
+	Color     string
+}
+
+func (params UpdateParams) Validate() error {
+	if params.LabelID == "" {
+		return errors.New("label_id is required")
+	}
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if err := validateName(params.Name); err != nil {
+		return err
+	}
+	if !reColor.MatchString(params.Color) {
+		return ErrInvalidColor
+	}
+	return nil
+}
+
+// validateName rejects names that board filter queries could not reference:
+// commas separate filter values and quotes delimit them.
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.ContainsAny(name, `,"`) {
+		return errors.New(`name must not contain commas or double quotes`)
+	}
+	return nil
+}
+
+// IssueParams attaches labels of the project to one of its issues.
+type IssueParams struct {
+	ProjectID string
+	IssueID   string
+	LabelIDs  []string
+}
+
+func (params IssueParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.IssueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if len(params.LabelIDs) == 0 {
+		return errors.New("label_ids is required")
+	}
+	for _, id := range params.LabelIDs {
+		if id == "" {
+			return errors.New("label_ids must not contain empty values")
+		}
+	}
+	return nil
+}
+
+func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Label, error) {
+	if db == nil {
+		return Label{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Label{}, err
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	params.Color = strings.ToLower(params.Color)
+	if params.Color == "" {
+		params.Color = DefaultColor
+	}
+	return createLabel(ctx, db, params)
+}
+
+func List(ctx context.Context, db *sqlx.DB, projectID string) ([]Label, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listLabels(ctx, db, projectID)
+}
+
+func Update(ctx context.Context, db *sqlx.DB, params UpdateParams) (Label, error) {
+	if db == nil {
+		return Label{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Label{}, err
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	params.Color = strings.ToLower(params.Color)
+	return updateLabel(ctx, db, params)
+}
+
+// Delete removes a label and detaches it from every issue.
+func Delete(ctx context.Context, db *sqlx.DB, projectID, labelID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if labelID == "" {
+		return errors.New("label_id is required")
+	}
+	return deleteLabel(ctx, db, projectID, labelID)
+}
+
+// AddToIssue attaches labels to an active issue. Labels already on the
+// issue are left as they are.
+func AddToIssue(ctx context.Context, db *sqlx.DB, params IssueParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	return addIssueLabels(ctx, db, params)
+}
+
+func RemoveFromIssue(ctx context.Context, db *sqlx.DB, projectID, issueID, labelID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if issueID == "" {
+		return errors.New("issue_id is required")
+	}
+	if labelID == "" {
+		return errors.New("label_id is required")
+	}
+	return removeIssueLabel(ctx, db, projectID, issueID, labelID)
+}
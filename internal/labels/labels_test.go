@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package labels
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CreateParams
+		wantErr bool
+	}{
+		{name: "valid", params: CreateParams{ProjectID: "p", Name: "backend", Color: "#1F6FEB"}, wantErr: false},
+		{name: "default color", params: CreateParams{ProjectID: "p", Name: "ux debt"}, wantErr: false},
+		{name: "missing project_id", params: CreateParams{Name: "backend"}, wantErr: true},
+		{name: "blank name", params: CreateParams{ProjectID: "p", Name: "  "}, wantErr: true},
+		{name: "comma in name", params: CreateParams{ProjectID: "p", Name: "a,b"}, wantErr: true},
+		{name: "bad color", params: CreateParams{ProjectID: "p", Name: "backend", Color: "blue"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  UpdateParams
+		wantErr bool
+	}{
+		{name: "valid", params: UpdateParams{LabelID: "l", ProjectID: "p", Name: "backend", Color: "#1f6feb"}, wantErr: false},
+		{name: "missing label_id", params: UpdateParams{ProjectID: "p", Name: "backend", Color: "#1f6feb"}, wantErr: true},
+		{name: "missing project_id", params: UpdateParams{LabelID: "l", Name: "backend", Color: "#1f6feb"}, wantErr: true},
+		{name: "quote in name", params: UpdateParams{LabelID: "l", ProjectID: "p", Name: `"x"`, Color: "#1f6feb"}, wantErr: true},
+		{name: "missing color", params: UpdateParams{LabelID: "l", ProjectID: "p", Name: "backend"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssueParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  IssueParams
+		wantErr bool
+	}{
+		{name: "valid", params: IssueParams{ProjectID: "p", IssueID: "i", LabelIDs: []string{"l"}}, wantErr: false},
+		{name: "missing issue_id", params: IssueParams{ProjectID: "p", LabelIDs: []string{"l"}}, wantErr: true},
+		{name: "no labels", params: IssueParams{ProjectID: "p", IssueID: "i"}, wantErr: true},
+		{name: "empty label", params: IssueParams{ProjectID: "p", IssueID: "i", LabelIDs: []string{""}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreate_NilDB(t *testing.T) {
+	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p", Name: "backend"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Create() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestList_NilDB(t *testing.T) {
+	_, err := List(context.Background(), nil, "p")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("List() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestDelete_NilDB(t *testing.T) {
+	err := Delete(context.Background(), nil, "p", "l")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Delete() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestAddToIssue_NilDB(t *testing.T) {
+	err := AddToIssue(context.Background(), nil, IssueParams{ProjectID: "p", IssueID: "i", LabelIDs: []string{"l"}})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("AddToIssue() error = %v, want %q", err, "db is required")
+	}
+}
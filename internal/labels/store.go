@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package labels
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/pgutil"
+)
+
+const labelCols = `id, project_id, name, color, created_at, updated_at`
+
+func createLabel(ctx context.Context, db *sqlx.DB, params CreateParams) (Label, error) {
+	var label Label
+	err := db.QueryRowxContext(ctx,
+		`INSERT INTO labels (project_id, name, color)
+		 VALUES ($1, $2, $3)
+		 RETURNING `+labelCols,
+		params.ProjectID, params.Name, params.Color,
+	).StructScan(&label)
+	if err != nil {
+		if pgutil.IsUniqueViolation(err) {
+			return Label{}, ErrDuplicate
+		}
+		return Label{}, fmt.Errorf("create label: %w", err)
+	}
+	return label, nil
+}
+
+func listLabels(ctx context.Context, db *sqlx.DB, projectID string) ([]Label, error) {
+	labels := []Label{}
+	if err := db.SelectContext(ctx, &labels,
+		`SELECT `+labelCols+`
+		 FROM labels
+		 WHERE project_id = $1
+		 ORDER BY lower(name) ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list labels: %w", err)
+	}
+	return labels, nil
+}
+
+func updateLabel(ctx context.Context, db *sqlx.DB, params UpdateParams) (Label, error) {
+	var label Label
+	err := db.QueryRowxContext(ctx,
+		`UPDATE labels
+		 SET name  = $1,
+		     color = $2
+		 WHERE id         = $3
+		   AND project_id = $4
+		 RETURNING `+labelCols,
+		params.Name, params.Color, params.LabelID, params.ProjectID,
+	).StructScan(&label)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Label{}, ErrNotFound
+		}
+		if pgutil.IsUniqueViolation(err) {
+			return Label{}, ErrDuplicate
+		}
+		return Label{}, fmt.Errorf("update label: %w", err)
+	}
+	return label, nil
+}
+
+func deleteLabel(ctx context.Context, db *sqlx.DB, projectID, labelID string) error {
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM labels
+		 WHERE id         = $1
+		   AND project_id = $2`,
+		labelID, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete label: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete label rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func addIssueLabels(ctx context.Context, db *sqlx.DB, params IssueParams) error {
+	labelIDs := slices.Compact(slices.Sorted(slices.Values(params.LabelIDs)))
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit add issue labels", func(tx *sqlx.Tx) error {
+		if err := lockIssue(ctx, tx, params.ProjectID, params.IssueID); err != nil {
+			return err
+		}
+		var found int
+		if err := tx.GetContext(ctx, &found,
+			`SELECT COUNT(*)
+			 FROM labels
+			 WHERE project_id = $1
+			   AND id = ANY($2::uuid[])`,
+			params.ProjectID, pq.Array(labelIDs),
+		); err != nil {
+			return fmt.Errorf("check labels exist: %w", err)
+		}
+		if found != len(labelIDs) {
+			return ErrNotFound
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO issue_labels (issue_id, label_id)
+			 SELECT $1, unnest($2::uuid[])
+			 ON CONFLICT DO NOTHING`,
+			params.IssueID, pq.Array(labelIDs),
+		); err != nil {
+			return fmt.Errorf("add issue labels: %w", err)
+		}
+		return nil
+	})
+}
+
+func removeIssueLabel(ctx context.Context, db *sqlx.DB, projectID, issueID, labelID string) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit remove issue label", func(tx *sqlx.Tx) error {
+		if err := lockIssue(ctx, tx, projectID, issueID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx,
+			`DELETE FROM issue_labels
+			 WHERE issue_id = $1
+			   AND label_id = $2`,
+			issueID, labelID,
+		)
+		if err != nil {
+			return fmt.Errorf("remove issue label: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("remove issue label rows affected: %w", err)
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// lockIssue checks that the issue is active in the project and holds it
+// so that it cannot be archived while its labels change.
+func lockIssue(ctx context.Context, tx *sqlx.Tx, projectID, issueID string) error {
+	var id string
+	if err := tx.GetContext(ctx, &id,
+		`SELECT id
+		 FROM issues
+		 WHERE id = $1
+		   AND project_id = $2
+		   AND archived_at IS NULL
+		 FOR UPDATE`,
+		issueID, projectID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrIssueNotFound
+		}
+		return fmt.Errorf("lock issue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package labels
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/issues"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+func TestLabels(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := testpg.SeedWorkspace(t, db)
+	projectID := testpg.SeedProject(t, db, ws, "LBL")
+	reporterID := testpg.SeedUser(t, db)
+	var statusID, typeID string
+	if err := db.Get(&statusID, `INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'To Do', 'todo', 0) RETURNING id`, projectID); err != nil {
+		t.Fatalf("seed status: %v", err)
+	}
+	if err := db.Get(&typeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, projectID); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	newIssue := func(title string) string {
+		t.Helper()
+		issue, err := issues.Create(ctx, db, issues.CreateParams{
+			ProjectID: projectID, IssueTypeID: typeID, StatusID: statusID, Title: title, ReporterID: reporterID,
+		})
+		if err != nil {
+			t.Fatalf("create issue: %v", err)
+		}
+		return issue.ID
+	}
+	tagged := newIssue("Tagged")
+	plain := newIssue("Plain")
+
+	backend, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: " Backend ", Color: "#1F6FEB"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if backend.Name != "Backend" || backend.Color != "#1f6feb" {
+		t.Fatalf("created label: got %+v", backend)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "backend"}); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Create() duplicate error = %v, want ErrDuplicate", err)
+	}
+	ux, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "UX debt"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if ux.Color != DefaultColor {
+		t.Fatalf("default color: got %q, want %q", ux.Color, DefaultColor)
+	}
+
+	if err := AddToIssue(ctx, db, IssueParams{ProjectID: projectID, IssueID: tagged, LabelIDs: []string{backend.ID, ux.ID, backend.ID}}); err != nil {
+		t.Fatalf("AddToIssue() error = %v", err)
+	}
+	if err := AddToIssue(ctx, db, IssueParams{ProjectID: projectID, IssueID: tagged, LabelIDs: []string{backend.ID}}); err != nil {
+		t.Fatalf("AddToIssue() again error = %v", err)
+	}
+	err = AddToIssue(ctx, db, IssueParams{ProjectID: projectID, IssueID: plain, LabelIDs: []string{"00000000-0000-0000-0000-000000000000"}})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("AddToIssue() unknown label error = %v, want ErrNotFound", err)
+	}
+
+	detail, err := issues.Get(ctx, db, projectID, tagged)
+	if err != nil {
+		t.Fatalf("issues.Get() error = %v", err)
+	}
+	if len(detail.Labels) != 2 || detail.Labels[0].ID != backend.ID || detail.Labels[1].ID != ux.ID {
+		t.Fatalf("issue labels: got %+v", detail.Labels)
+	}
+
+	for _, filter := range [][]string{{"BACKEND"}, {ux.ID}, {"missing", "ux debt"}} {
+		list, err := issues.List(ctx, db, issues.ListParams{ProjectID: projectID, Labels: filter})
+		if err != nil {
+			t.Fatalf("issues.List(%v) error = %v", filter, err)
+		}
+		if len(list) != 1 || list[0].ID != tagged {
+			t.Fatalf("issues.List(%v): got %d issues, want only the tagged one", filter, len(list))
+		}
+	}
+
+	if err := RemoveFromIssue(ctx, db, projectID, tagged, ux.ID); err != nil {
+		t.Fatalf("RemoveFromIssue() error = %v", err)
+	}
+	if err := RemoveFromIssue(ctx, db, projectID, tagged, ux.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("RemoveFromIssue() twice error = %v, want ErrNotFound", err)
+	}
+
+	renamed, err := Update(ctx, db, UpdateParams{LabelID: backend.ID, ProjectID: projectID, Name: "API", Color: "#000000"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if renamed.Name != "API" {
+		t.Fatalf("renamed label: got %+v", renamed)
+	}
+
+	if err := Delete(ctx, db, projectID, backend.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	detail, err = issues.Get(ctx, db, projectID, tagged)
+	if err != nil {
+		t.Fatalf("issues.Get() error = %v", err)
+	}
+	if len(detail.Labels) != 0 {
+		t.Fatalf("labels after delete: got %+v, want none", detail.Labels)
+	}
+	list, err := List(ctx, db, projectID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].ID != ux.ID {
+		t.Fatalf("List(): got %+v", list)
+	}
+}
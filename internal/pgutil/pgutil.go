@@ -15,12 +15,34 @@ import (
 	"github.com/lib/pq"
 )
 
+// ReorderOffset lifts positions out of the way while a reorder rewrites them:
+// moving rows past it first means a unique index on positions never sees two
+// rows at the same value mid-update.
+const ReorderOffset = 1000000
+
 // IsUniqueViolation reports whether err is a PostgreSQL unique-constraint violation (code 23505).
 func IsUniqueViolation(err error) bool {
 	var pqErr *pq.Error
 	return errors.As(err, &pqErr) && pqErr.Code == "23505"
 }
 
+// IsUniqueViolationOf reports whether err is a unique violation of the named
+// constraint or unique index.
+func IsUniqueViolationOf(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// RaisedException reports whether err is an exception raised by a PL/pgSQL
+// RAISE EXCEPTION (code P0001), such as a validation trigger, and returns its message.
+func RaisedException(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "P0001" {
+		return pqErr.Message, true
+	}
+	return "", false
+}
+
 // WithTx begins a transaction, runs fn, and commits on success.
 // defer tx.Rollback() is registered immediately after Begin so it fires on any return path.
 // Begin errors are wrapped with beginLabel; Commit errors are wrapped with commitLabel.
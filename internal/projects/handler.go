@@ -19,10 +19,18 @@ func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
 	mux.HandleFunc("GET /workspaces/{workspaceID}/projects", handleList(db))
 	mux.HandleFunc("GET /projects/{projectID}", handleGet(db))
 	mux.HandleFunc("DELETE /projects/{projectID}", handleArchive(db))
+	mux.HandleFunc("GET /workspaces/{workspaceID}/projects/trash", handleListTrash(db))
+	mux.HandleFunc("POST /projects/{projectID}/restore", handleRestore(db))
 	mux.HandleFunc("GET /projects/{projectID}/members", handleListMembers(db))
 	mux.HandleFunc("POST /projects/{projectID}/members", handleAddMember(db))
 	mux.HandleFunc("PUT /projects/{projectID}/members/{userID}", handleUpdateMemberRole(db))
 	mux.HandleFunc("DELETE /projects/{projectID}/members/{userID}", handleRemoveMember(db))
+	mux.HandleFunc("POST /projects/{projectID}/save-as-template", handleSaveAsTemplate(db))
+	mux.HandleFunc("GET /workspaces/{workspaceID}/project-templates", handleListTemplates(db))
+	mux.HandleFunc("POST /workspaces/{workspaceID}/project-templates", handleCreateTemplate(db))
+	mux.HandleFunc("GET /workspaces/{workspaceID}/project-templates/{templateID}", handleGetTemplate(db))
+	mux.HandleFunc("PUT /workspaces/{workspaceID}/project-templates/{templateID}", handleUpdateTemplate(db))
+	mux.HandleFunc("DELETE /workspaces/{workspaceID}/project-templates/{templateID}", handleDeleteTemplate(db))
 }
 
 func fail(w http.ResponseWriter, err error) {
@@ -34,9 +42,12 @@ func fail(w http.ResponseWriter, err error) {
 	case errors.Is(err, authz.ErrWorkspaceNotFound),
 		errors.Is(err, authz.ErrProjectNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrNotFound), errors.Is(err, ErrMemberNotFound):
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrMemberNotFound),
+		errors.Is(err, ErrTemplateNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrDuplicateKey):
+	case errors.Is(err, ErrUnsavableProject):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, ErrDuplicateKey), errors.Is(err, ErrDuplicateTemplate):
 		respond.Error(w, http.StatusConflict, err.Error())
 	default:
 		respond.Error(w, http.StatusInternalServerError, "internal server error")
@@ -55,6 +66,7 @@ func handleCreate(db *sqlx.DB) http.HandlerFunc {
 			Key         string `json:"key"`
 			Description string `json:"description"`
 			Template    string `json:"template"`
+			TemplateID  string `json:"template_id"`
 			Locale      string `json:"locale"`
 		}
 		if err := respond.Decode(r, &body); err != nil {
@@ -67,6 +79,7 @@ func handleCreate(db *sqlx.DB) http.HandlerFunc {
 			Key:         body.Key,
 			Description: body.Description,
 			Template:    body.Template,
+			TemplateID:  body.TemplateID,
 			Locale:      body.Locale,
 		}
 		if err := params.Validate(); err != nil {
@@ -134,6 +147,43 @@ func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleListTrash(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceMembership(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListTrash(r.Context(), db, wsID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleRestore(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		project, err := Restore(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, project)
+	}
+}
+
 func handleListMembers(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projID := r.PathValue("projectID")
@@ -251,3 +301,158 @@ func handleRemoveMember(db *sqlx.DB) http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func handleSaveAsTemplate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := SaveAsTemplateParams{
+			ProjectID:   projID,
+			Name:        body.Name,
+			Description: body.Description,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		template, err := SaveAsTemplate(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, template)
+	}
+}
+
+// templateBody is the JSON body for creating and replacing a template.
+type templateBody struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Definition  Definition `json:"definition"`
+}
+
+func handleListTemplates(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceMembership(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListTemplates(r.Context(), db, wsID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleCreateTemplate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body templateBody
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CreateTemplateParams{
+			WorkspaceID: wsID,
+			Name:        body.Name,
+			Description: body.Description,
+			Definition:  body.Definition,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		template, err := CreateTemplate(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, template)
+	}
+}
+
+func handleGetTemplate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceMembership(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		template, err := GetTemplate(r.Context(), db, wsID, r.PathValue("templateID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, template)
+	}
+}
+
+func handleUpdateTemplate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body templateBody
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateTemplateParams{
+			WorkspaceID: wsID,
+			TemplateID:  r.PathValue("templateID"),
+			Name:        body.Name,
+			Description: body.Description,
+			Definition:  body.Definition,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		template, err := UpdateTemplate(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, template)
+	}
+}
+
+func handleDeleteTemplate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := DeleteTemplate(r.Context(), db, wsID, r.PathValue("templateID")); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
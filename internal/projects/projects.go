@@ -37,12 +37,16 @@ type Project struct {
 
 var validTemplates = map[string]bool{"kanban": true, "scrum": true}
 
+// CreateParams creates a project, optionally set up from a template:
+// Template names a built-in preset ('kanban' or 'scrum', with status names in
+// Locale) and TemplateID a project template of the workspace.
 type CreateParams struct {
 	WorkspaceID string
 	Name        string
 	Key         string
 	Description string
 	Template    string
+	TemplateID  string
 	Locale      string
 }
 
@@ -59,9 +63,15 @@ func (params CreateParams) Validate() error {
 	if params.Template != "" && !validTemplates[params.Template] {
 		return errors.New("template must be 'kanban' or 'scrum'")
 	}
+	if params.Template != "" && params.TemplateID != "" {
+		return errors.New("template and template_id are mutually exclusive")
+	}
 	return nil
 }
 
+// Create adds a project and everything its template defines in a single
+// transaction. It returns ErrTemplateNotFound when TemplateID is not a
+// template of the workspace.
 func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Project, error) {
 	if db == nil {
 		return Project{}, errors.New("db is required")
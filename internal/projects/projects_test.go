@@ -7,6 +7,7 @@ package projects
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 )
 
@@ -66,6 +67,16 @@ func TestCreateProjectParams_Validate(t *testing.T) {
 			params:  CreateParams{WorkspaceID: "ws-1", Name: "Engineering", Key: "EN G"},
 			wantErr: true,
 		},
+		{
+			name:    "template_id",
+			params:  CreateParams{WorkspaceID: "ws-1", Name: "Engineering", Key: "ENG", TemplateID: "tpl-1"},
+			wantErr: false,
+		},
+		{
+			name:    "template and template_id",
+			params:  CreateParams{WorkspaceID: "ws-1", Name: "Engineering", Key: "ENG", Template: "kanban", TemplateID: "tpl-1"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,3 +127,201 @@ func TestArchiveProject_NilDB(t *testing.T) {
 		t.Fatalf("ArchiveProject() error = %v, want %q", err, "db is required")
 	}
 }
+
+func TestRestoreProject_NilDB(t *testing.T) {
+	_, err := Restore(context.Background(), nil, "some-id")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("RestoreProject() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListTrash_NilDB(t *testing.T) {
+	_, err := ListTrash(context.Background(), nil, "ws-1")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListTrash() error = %v, want %q", err, "db is required")
+	}
+}
+
+func validDefinition() Definition {
+	return Definition{
+		Statuses: []StatusDef{{"To Do", "todo"}, {"Doing", "doing"}, {"Done", "done"}},
+		IssueTypes: []IssueTypeDef{
+			{Name: "Epic", Icon: "epic", Level: 1},
+			{Name: "Task", Icon: "task", Level: 2, DefaultStatus: "To Do", DefaultPriority: "high"},
+		},
+		Boards: []BoardDef{{Name: "Board", Type: "kanban", Columns: []ColumnDef{
+			{Name: "Open", Statuses: []string{"To Do"}},
+			{Name: "Closed", Statuses: []string{"Doing", "Done"}},
+		}}},
+		Labels:       []LabelDef{{Name: "bug", Color: "#ff0000"}},
+		CustomFields: []CustomFieldDef{{Name: "Estimate", Type: "number", IssueTypes: []string{"Task"}}},
+	}
+}
+
+func TestDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Definition)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Definition) {}},
+		{name: "empty", modify: func(def *Definition) { *def = Definition{} }},
+		{
+			name:    "invalid status category",
+			modify:  func(def *Definition) { def.Statuses[0].Category = "later" },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate status",
+			modify:  func(def *Definition) { def.Statuses[1].Name = "To Do" },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate issue type",
+			modify:  func(def *Definition) { def.IssueTypes[1].Name = "Epic" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown default status",
+			modify:  func(def *Definition) { def.IssueTypes[1].DefaultStatus = "Blocked" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid board type",
+			modify:  func(def *Definition) { def.Boards[0].Type = "list" },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate column",
+			modify:  func(def *Definition) { def.Boards[0].Columns[1].Name = "Open" },
+			wantErr: true,
+		},
+		{
+			name:    "column with unknown status",
+			modify:  func(def *Definition) { def.Boards[0].Columns[0].Statuses = []string{"Blocked"} },
+			wantErr: true,
+		},
+		{
+			name:    "column with repeated status",
+			modify:  func(def *Definition) { def.Boards[0].Columns[1].Statuses = []string{"Done", "Done"} },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate label ignoring case",
+			modify:  func(def *Definition) { def.Labels = append(def.Labels, LabelDef{Name: "BUG"}) },
+			wantErr: true,
+		},
+		{
+			name:    "custom field on unknown issue type",
+			modify:  func(def *Definition) { def.CustomFields[0].IssueTypes = []string{"Story"} },
+			wantErr: true,
+		},
+		{
+			name:    "custom field with repeated issue type",
+			modify:  func(def *Definition) { def.CustomFields[0].IssueTypes = []string{"Task", "Epic", "Task"} },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := validDefinition()
+			tt.modify(&def)
+			err := def.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTemplateParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CreateTemplateParams
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			params: CreateTemplateParams{WorkspaceID: "ws-1", Name: "Delivery", Definition: validDefinition()},
+		},
+		{
+			name:    "missing workspace_id",
+			params:  CreateTemplateParams{Name: "Delivery", Definition: validDefinition()},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			params:  CreateTemplateParams{WorkspaceID: "ws-1", Definition: validDefinition()},
+			wantErr: true,
+		},
+		{
+			name: "invalid definition",
+			params: CreateTemplateParams{WorkspaceID: "ws-1", Name: "Delivery", Definition: Definition{
+				Statuses: []StatusDef{{"", "todo"}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefinition_Normalized(t *testing.T) {
+	def := Definition{Boards: []BoardDef{{Name: "Board", Type: "kanban", Columns: []ColumnDef{{Name: "Open"}}}}}
+	raw, err := json.Marshal(def.normalized())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"statuses":[],"issue_types":[],"boards":[{"name":"Board","type":"kanban","columns":[{"name":"Open","statuses":[]}]}],"labels":[],"custom_fields":[]}`
+	if string(raw) != want {
+		t.Fatalf("normalized definition = %s, want %s", raw, want)
+	}
+	if def.Boards[0].Columns[0].Statuses != nil {
+		t.Fatal("normalized() changed the original definition")
+	}
+}
+
+func TestBuiltinDefinition(t *testing.T) {
+	def := builtinDefinition("scrum", "fr")
+	if len(def.Statuses) != 5 || def.Statuses[0].Name != "Backlog" || def.Statuses[1].Name != "To Do" {
+		t.Fatalf("statuses = %v, want the English scrum statuses", def.Statuses)
+	}
+	if len(def.Boards) != 1 || def.Boards[0].Type != "scrum" {
+		t.Fatalf("boards = %v, want one scrum board", def.Boards)
+	}
+	if err := def.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := builtinDefinition("kanban", "es").Statuses[0].Name; got != "Por hacer" {
+		t.Fatalf("es kanban first status = %q, want %q", got, "Por hacer")
+	}
+}
+
+func TestCreateTemplate_NilDB(t *testing.T) {
+	_, err := CreateTemplate(context.Background(), nil, CreateTemplateParams{WorkspaceID: "ws-1", Name: "Delivery"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("CreateTemplate() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListTemplates_NilDB(t *testing.T) {
+	_, err := ListTemplates(context.Background(), nil, "ws-1")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("ListTemplates() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestSaveAsTemplate_NilDB(t *testing.T) {
+	_, err := SaveAsTemplate(context.Background(), nil, SaveAsTemplateParams{ProjectID: "p-1", Name: "Delivery"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("SaveAsTemplate() error = %v, want %q", err, "db is required")
+	}
+}
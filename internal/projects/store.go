@@ -8,63 +8,23 @@ package projects
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/labels"
 	"github.com/start-codex/tookly/internal/pgutil"
 )
 
 const selectCols = `id, workspace_id, name, key, description, created_at, updated_at, archived_at`
 const memberCols = `project_id, user_id, role, created_at, updated_at, archived_at`
 
-type templateStatus struct {
-	name     string
-	category string
-}
-
-var templateDefs = map[string]map[string][]templateStatus{
-	"en": {
-		"kanban": {
-			{"To Do", "todo"},
-			{"In Progress", "doing"},
-			{"Done", "done"},
-		},
-		"scrum": {
-			{"Backlog", "todo"},
-			{"To Do", "todo"},
-			{"In Progress", "doing"},
-			{"In Review", "doing"},
-			{"Done", "done"},
-		},
-	},
-	"es": {
-		"kanban": {
-			{"Por hacer", "todo"},
-			{"En progreso", "doing"},
-			{"Hecho", "done"},
-		},
-		"scrum": {
-			{"Backlog", "todo"},
-			{"Por hacer", "todo"},
-			{"En progreso", "doing"},
-			{"En revisión", "doing"},
-			{"Hecho", "done"},
-		},
-	},
-}
-
-func resolveTemplate(template, locale string) []templateStatus {
-	if defs, ok := templateDefs[locale]; ok {
-		if statuses, ok := defs[template]; ok {
-			return statuses
-		}
-	}
-	return templateDefs["en"][template]
-}
-
 func createProject(ctx context.Context, db *sqlx.DB, params CreateParams) (Project, error) {
-	if params.Template == "" {
+	if params.Template == "" && params.TemplateID == "" {
 		var project Project
 		err := db.QueryRowxContext(
 			ctx,
@@ -84,6 +44,15 @@ func createProject(ctx context.Context, db *sqlx.DB, params CreateParams) (Proje
 
 	var project Project
 	if err := pgutil.WithTx(ctx, db, nil, "begin transaction", "commit transaction", func(tx *sqlx.Tx) error {
+		def := builtinDefinition(params.Template, params.Locale)
+		if params.TemplateID != "" {
+			template, err := getTemplate(ctx, tx, params.WorkspaceID, params.TemplateID)
+			if err != nil {
+				return err
+			}
+			def = template.Definition
+		}
+
 		if err := tx.QueryRowxContext(
 			ctx,
 			`INSERT INTO projects (workspace_id, name, key, description)
@@ -96,27 +65,101 @@ func createProject(ctx context.Context, db *sqlx.DB, params CreateParams) (Proje
 			}
 			return fmt.Errorf("insert project: %w", err)
 		}
+		return instantiate(ctx, tx, project.ID, def)
+	}); err != nil {
+		return Project{}, err
+	}
+	return project, nil
+}
+
+// instantiate creates what a template defines in a new project, resolving
+// the definition's name references to the created IDs.
+func instantiate(ctx context.Context, tx *sqlx.Tx, projectID string, def Definition) error {
+	statusIDs := make(map[string]string, len(def.Statuses))
+	for i, s := range def.Statuses {
+		var id string
+		if err := tx.GetContext(ctx, &id,
+			`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, $2, $3, $4) RETURNING id`,
+			projectID, s.Name, s.Category, i,
+		); err != nil {
+			return fmt.Errorf("insert status %q: %w", s.Name, err)
+		}
+		statusIDs[s.Name] = id
+	}
+
+	typeIDs := make(map[string]string, len(def.IssueTypes))
+	for _, t := range def.IssueTypes {
+		var id string
+		if err := tx.GetContext(ctx, &id,
+			`INSERT INTO issue_types (project_id, name, icon, level, default_status_id, default_priority, description_template)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING id`,
+			projectID, t.Name, t.Icon, t.Level,
+			nullIfEmpty(statusIDs[t.DefaultStatus]), nullIfEmpty(t.DefaultPriority), t.DescriptionTemplate,
+		); err != nil {
+			return fmt.Errorf("insert issue type %q: %w", t.Name, err)
+		}
+		typeIDs[t.Name] = id
+	}
+
+	for _, l := range def.Labels {
+		color := strings.ToLower(l.Color)
+		if color == "" {
+			color = labels.DefaultColor
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO labels (project_id, name, color) VALUES ($1, $2, $3)`,
+			projectID, l.Name, color,
+		); err != nil {
+			return fmt.Errorf("insert label %q: %w", l.Name, err)
+		}
+	}
 
-		for i, s := range resolveTemplate(params.Template, params.Locale) {
+	for _, f := range def.CustomFields {
+		var id string
+		if err := tx.GetContext(ctx, &id,
+			`INSERT INTO custom_fields (project_id, name, field_type, options) VALUES ($1, $2, $3, $4) RETURNING id`,
+			projectID, f.Name, f.Type, pq.Array(nonNil(f.Options)),
+		); err != nil {
+			return fmt.Errorf("insert custom field %q: %w", f.Name, err)
+		}
+		for _, name := range f.IssueTypes {
 			if _, err := tx.ExecContext(ctx,
-				`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, $2, $3, $4)`,
-				project.ID, s.name, s.category, i,
+				`INSERT INTO custom_field_issue_types (field_id, issue_type_id) VALUES ($1, $2)`,
+				id, typeIDs[name],
 			); err != nil {
-				return fmt.Errorf("insert status %q: %w", s.name, err)
+				return fmt.Errorf("scope custom field %q: %w", f.Name, err)
 			}
 		}
+	}
 
-		if _, err := tx.ExecContext(ctx,
-			`INSERT INTO boards (project_id, name, type, filter_query) VALUES ($1, $2, $3, '')`,
-			project.ID, "Board", params.Template,
+	for _, b := range def.Boards {
+		var boardID string
+		if err := tx.GetContext(ctx, &boardID,
+			`INSERT INTO boards (project_id, name, type, filter_query) VALUES ($1, $2, $3, $4) RETURNING id`,
+			projectID, b.Name, b.Type, b.FilterQuery,
 		); err != nil {
-			return fmt.Errorf("insert board: %w", err)
+			return fmt.Errorf("insert board %q: %w", b.Name, err)
+		}
+		for i, c := range b.Columns {
+			var columnID string
+			if err := tx.GetContext(ctx, &columnID,
+				`INSERT INTO board_columns (board_id, name, position) VALUES ($1, $2, $3) RETURNING id`,
+				boardID, c.Name, i,
+			); err != nil {
+				return fmt.Errorf("insert column %q: %w", c.Name, err)
+			}
+			for _, name := range c.Statuses {
+				if _, err := tx.ExecContext(ctx,
+					`INSERT INTO board_column_statuses (board_column_id, status_id) VALUES ($1, $2)`,
+					columnID, statusIDs[name],
+				); err != nil {
+					return fmt.Errorf("map status %q to column %q: %w", name, c.Name, err)
+				}
+			}
 		}
-		return nil
-	}); err != nil {
-		return Project{}, err
 	}
-	return project, nil
+	return nil
 }
 
 func getProject(ctx context.Context, db *sqlx.DB, id string) (Project, error) {
@@ -178,6 +221,55 @@ func archiveProject(ctx context.Context, db *sqlx.DB, id string) error {
 	return nil
 }
 
+func listTrash(ctx context.Context, db *sqlx.DB, workspaceID string) ([]Project, error) {
+	projects := []Project{}
+	if err := db.SelectContext(ctx, &projects,
+		`SELECT `+selectCols+`
+		 FROM projects
+		 WHERE workspace_id = $1
+		   AND archived_at IS NOT NULL
+		 ORDER BY archived_at DESC, name ASC`,
+		workspaceID,
+	); err != nil {
+		return nil, fmt.Errorf("list archived projects: %w", err)
+	}
+	return projects, nil
+}
+
+func restoreProject(ctx context.Context, db *sqlx.DB, id string) (Project, error) {
+	var project Project
+	err := db.QueryRowxContext(ctx,
+		`UPDATE projects
+		 SET archived_at = NULL
+		 WHERE id = $1
+		   AND archived_at IS NOT NULL
+		 RETURNING `+selectCols,
+		id,
+	).StructScan(&project)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Project{}, ErrNotFound
+		}
+		return Project{}, fmt.Errorf("restore project: %w", err)
+	}
+	return project, nil
+}
+
+func purgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM projects WHERE archived_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge archived projects: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge archived projects rows affected: %w", err)
+	}
+	return n, nil
+}
+
 func addMember(ctx context.Context, db *sqlx.DB, params AddMemberParams) (Member, error) {
 	var member Member
 	err := db.QueryRowxContext(ctx,
@@ -246,3 +338,290 @@ func updateMemberRole(ctx context.Context, db *sqlx.DB, params UpdateMemberRoleP
 	}
 	return member, nil
 }
+
+const templateCols = `id, workspace_id, name, description, definition, created_at, updated_at`
+
+// templateRow is a project_templates row with the definition still encoded.
+type templateRow struct {
+	Template
+	RawDefinition []byte `db:"definition"`
+}
+
+func (row templateRow) decode() (Template, error) {
+	template := row.Template
+	if err := json.Unmarshal(row.RawDefinition, &template.Definition); err != nil {
+		return Template{}, fmt.Errorf("decode template definition: %w", err)
+	}
+	template.Definition = template.Definition.normalized()
+	return template, nil
+}
+
+func createTemplate(ctx context.Context, db *sqlx.DB, params CreateTemplateParams) (Template, error) {
+	return insertTemplate(ctx, db, params.WorkspaceID, params.Name, params.Description, params.Definition)
+}
+
+func insertTemplate(ctx context.Context, q sqlx.QueryerContext, workspaceID, name, description string, def Definition) (Template, error) {
+	raw, err := json.Marshal(def.normalized())
+	if err != nil {
+		return Template{}, fmt.Errorf("encode template definition: %w", err)
+	}
+	var row templateRow
+	if err := sqlx.GetContext(ctx, q, &row,
+		`INSERT INTO project_templates (workspace_id, name, description, definition)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING `+templateCols,
+		workspaceID, name, description, raw,
+	); err != nil {
+		if pgutil.IsUniqueViolation(err) {
+			return Template{}, ErrDuplicateTemplate
+		}
+		return Template{}, fmt.Errorf("insert project template: %w", err)
+	}
+	return row.decode()
+}
+
+func listTemplates(ctx context.Context, db *sqlx.DB, workspaceID string) ([]Template, error) {
+	var rows []templateRow
+	if err := db.SelectContext(ctx, &rows,
+		`SELECT `+templateCols+`
+		 FROM project_templates
+		 WHERE workspace_id = $1
+		 ORDER BY lower(name) ASC`,
+		workspaceID,
+	); err != nil {
+		return nil, fmt.Errorf("list project templates: %w", err)
+	}
+	templates := make([]Template, 0, len(rows))
+	for _, row := range rows {
+		template, err := row.decode()
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func getTemplate(ctx context.Context, q sqlx.QueryerContext, workspaceID, templateID string) (Template, error) {
+	var row templateRow
+	if err := sqlx.GetContext(ctx, q, &row,
+		`SELECT `+templateCols+`
+		 FROM project_templates
+		 WHERE id = $1
+		   AND workspace_id = $2`,
+		templateID, workspaceID,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Template{}, ErrTemplateNotFound
+		}
+		return Template{}, fmt.Errorf("get project template: %w", err)
+	}
+	return row.decode()
+}
+
+func updateTemplate(ctx context.Context, db *sqlx.DB, params UpdateTemplateParams) (Template, error) {
+	raw, err := json.Marshal(params.Definition.normalized())
+	if err != nil {
+		return Template{}, fmt.Errorf("encode template definition: %w", err)
+	}
+	var row templateRow
+	if err := db.GetContext(ctx, &row,
+		`UPDATE project_templates
+		 SET name        = $3,
+		     description = $4,
+		     definition  = $5
+		 WHERE id = $1
+		   AND workspace_id = $2
+		 RETURNING `+templateCols,
+		params.TemplateID, params.WorkspaceID, params.Name, params.Description, raw,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Template{}, ErrTemplateNotFound
+		}
+		if pgutil.IsUniqueViolation(err) {
+			return Template{}, ErrDuplicateTemplate
+		}
+		return Template{}, fmt.Errorf("update project template: %w", err)
+	}
+	return row.decode()
+}
+
+func deleteTemplate(ctx context.Context, db *sqlx.DB, workspaceID, templateID string) error {
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM project_templates WHERE id = $1 AND workspace_id = $2`,
+		templateID, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete project template: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete project template rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func saveAsTemplate(ctx context.Context, db *sqlx.DB, params SaveAsTemplateParams) (Template, error) {
+	var template Template
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead}
+	err := pgutil.WithTx(ctx, db, opts, "begin tx", "commit project template", func(tx *sqlx.Tx) error {
+		var workspaceID string
+		if err := tx.GetContext(ctx, &workspaceID,
+			`SELECT workspace_id FROM projects WHERE id = $1 AND archived_at IS NULL`,
+			params.ProjectID,
+		); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("get project: %w", err)
+		}
+		def, err := snapshotProject(ctx, tx, params.ProjectID)
+		if err != nil {
+			return err
+		}
+		if err := def.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrUnsavableProject, err)
+		}
+		template, err = insertTemplate(ctx, tx, workspaceID, params.Name, params.Description, def)
+		return err
+	})
+	if err != nil {
+		return Template{}, err
+	}
+	return template, nil
+}
+
+// snapshotProject reads the active setup of a project as a definition.
+func snapshotProject(ctx context.Context, tx *sqlx.Tx, projectID string) (Definition, error) {
+	def := Definition{
+		Statuses:     []StatusDef{},
+		IssueTypes:   []IssueTypeDef{},
+		Boards:       []BoardDef{},
+		Labels:       []LabelDef{},
+		CustomFields: []CustomFieldDef{},
+	}
+	if err := tx.SelectContext(ctx, &def.Statuses,
+		`SELECT name, category
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY position ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot statuses: %w", err)
+	}
+	if err := tx.SelectContext(ctx, &def.IssueTypes,
+		`SELECT t.name, t.icon, t.level,
+		        COALESCE(s.name, '') AS default_status,
+		        COALESCE(t.default_priority, '') AS default_priority,
+		        t.description_template
+		 FROM issue_types t
+		 LEFT JOIN statuses s ON s.id = t.default_status_id AND s.archived_at IS NULL
+		 WHERE t.project_id = $1
+		   AND t.archived_at IS NULL
+		 ORDER BY t.level ASC, t.name ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot issue types: %w", err)
+	}
+	if err := tx.SelectContext(ctx, &def.Labels,
+		`SELECT name, color
+		 FROM labels
+		 WHERE project_id = $1
+		 ORDER BY lower(name) ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot labels: %w", err)
+	}
+
+	var fields []struct {
+		Name       string         `db:"name"`
+		Type       string         `db:"field_type"`
+		Options    pq.StringArray `db:"options"`
+		IssueTypes pq.StringArray `db:"issue_types"`
+	}
+	if err := tx.SelectContext(ctx, &fields,
+		`SELECT f.name, f.field_type, f.options,
+		        COALESCE(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.id IS NOT NULL), '{}') AS issue_types
+		 FROM custom_fields f
+		 LEFT JOIN custom_field_issue_types ft ON ft.field_id = f.id
+		 LEFT JOIN issue_types t ON t.id = ft.issue_type_id AND t.archived_at IS NULL
+		 WHERE f.project_id = $1
+		   AND f.archived_at IS NULL
+		 GROUP BY f.id
+		 ORDER BY f.created_at ASC, f.name ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot custom fields: %w", err)
+	}
+	for _, f := range fields {
+		def.CustomFields = append(def.CustomFields, CustomFieldDef{
+			Name: f.Name, Type: f.Type, Options: f.Options, IssueTypes: f.IssueTypes,
+		})
+	}
+
+	var boardRows []struct {
+		ID          string `db:"id"`
+		Name        string `db:"name"`
+		Type        string `db:"type"`
+		FilterQuery string `db:"filter_query"`
+	}
+	if err := tx.SelectContext(ctx, &boardRows,
+		`SELECT id, name, type, filter_query
+		 FROM boards
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY created_at ASC, name ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot boards: %w", err)
+	}
+	var columns []struct {
+		BoardID  string         `db:"board_id"`
+		Name     string         `db:"name"`
+		Statuses pq.StringArray `db:"statuses"`
+	}
+	if err := tx.SelectContext(ctx, &columns,
+		`SELECT bc.board_id, bc.name,
+		        COALESCE(array_agg(s.name ORDER BY s.position) FILTER (WHERE s.id IS NOT NULL), '{}') AS statuses
+		 FROM board_columns bc
+		 JOIN boards b ON b.id = bc.board_id
+		 LEFT JOIN board_column_statuses bcs ON bcs.board_column_id = bc.id
+		 LEFT JOIN statuses s ON s.id = bcs.status_id AND s.archived_at IS NULL
+		 WHERE b.project_id = $1
+		   AND b.archived_at IS NULL
+		   AND bc.archived_at IS NULL
+		 GROUP BY bc.id
+		 ORDER BY bc.position ASC`,
+		projectID,
+	); err != nil {
+		return Definition{}, fmt.Errorf("snapshot board columns: %w", err)
+	}
+	for _, b := range boardRows {
+		board := BoardDef{Name: b.Name, Type: b.Type, FilterQuery: b.FilterQuery, Columns: []ColumnDef{}}
+		for _, c := range columns {
+			if c.BoardID == b.ID {
+				board.Columns = append(board.Columns, ColumnDef{Name: c.Name, Statuses: c.Statuses})
+			}
+		}
+		def.Boards = append(def.Boards, board)
+	}
+	return def, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
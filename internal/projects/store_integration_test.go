@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -291,6 +292,83 @@ func TestArchiveProject(t *testing.T) {
 	}
 }
 
+func TestRestoreProject(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := seedWorkspace(t, db)
+	proj, err := Create(ctx, db, CreateParams{WorkspaceID: ws, Name: "Engineering", Key: "ENG"})
+	if err != nil {
+		t.Fatalf("seed project: %v", err)
+	}
+	if _, err := Restore(ctx, db, proj.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore() of an active project error = %v, want %v", err, ErrNotFound)
+	}
+	if err := Archive(ctx, db, proj.ID); err != nil {
+		t.Fatalf("archive project: %v", err)
+	}
+
+	trash, err := ListTrash(ctx, db, ws)
+	if err != nil {
+		t.Fatalf("ListTrash() error = %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != proj.ID {
+		t.Fatalf("ListTrash(): got %+v, want the archived project", trash)
+	}
+
+	restored, err := Restore(ctx, db, proj.ID)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.ArchivedAt != nil {
+		t.Fatal("expected archived_at to be cleared")
+	}
+	list, err := List(ctx, db, ws)
+	if err != nil {
+		t.Fatalf("list projects: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != proj.ID {
+		t.Fatalf("List(): got %+v, want the restored project", list)
+	}
+}
+
+func TestPurgeArchivedProjects(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := seedWorkspace(t, db)
+	old, err := Create(ctx, db, CreateParams{WorkspaceID: ws, Name: "Old", Key: "OLD"})
+	if err != nil {
+		t.Fatalf("seed old project: %v", err)
+	}
+	recent, err := Create(ctx, db, CreateParams{WorkspaceID: ws, Name: "Recent", Key: "REC"})
+	if err != nil {
+		t.Fatalf("seed recent project: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE projects SET archived_at = '2000-01-01' WHERE id = $1`, old.ID); err != nil {
+		t.Fatalf("archive old project: %v", err)
+	}
+	if err := Archive(ctx, db, recent.ID); err != nil {
+		t.Fatalf("archive recent project: %v", err)
+	}
+
+	n, err := PurgeArchived(ctx, db, time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("PurgeArchived() error = %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("PurgeArchived(): deleted %d, want 1", n)
+	}
+	if _, err := Get(ctx, db, old.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get purged project error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := Get(ctx, db, recent.ID); err != nil {
+		t.Fatalf("get recently archived project: %v", err)
+	}
+}
+
 func TestProjectMembers(t *testing.T) {
 	db := testpg.Open(t)
 	testpg.EnsureMigrated(t, db)
@@ -376,6 +454,115 @@ func TestProjectMembers(t *testing.T) {
 
 // --- helpers ---
 
+func TestProjectTemplates(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := seedWorkspace(t, db)
+	template, err := CreateTemplate(ctx, db, CreateTemplateParams{
+		WorkspaceID: ws, Name: "Delivery", Description: "our process", Definition: Definition{
+			Statuses: []StatusDef{{"Open", "todo"}, {"Building", "doing"}, {"Shipped", "done"}},
+			IssueTypes: []IssueTypeDef{
+				{Name: "Epic", Icon: "epic", Level: 1},
+				{Name: "Task", Icon: "task", Level: 2, DefaultStatus: "Open", DefaultPriority: "high"},
+			},
+			Boards: []BoardDef{{Name: "Flow", Type: "kanban", Columns: []ColumnDef{
+				{Name: "Ready", Statuses: []string{"Open"}},
+				{Name: "Active", Statuses: []string{"Building", "Shipped"}},
+			}}},
+			Labels:       []LabelDef{{Name: "bug", Color: "#FF0000"}},
+			CustomFields: []CustomFieldDef{{Name: "Estimate", Type: "number", IssueTypes: []string{"Task"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	if _, err := CreateTemplate(ctx, db, CreateTemplateParams{WorkspaceID: ws, Name: "delivery"}); !errors.Is(err, ErrDuplicateTemplate) {
+		t.Fatalf("duplicate template error = %v, want %v", err, ErrDuplicateTemplate)
+	}
+	blank, err := CreateTemplate(ctx, db, CreateTemplateParams{WorkspaceID: seedWorkspace(t, db), Name: "Blank"})
+	if err != nil {
+		t.Fatalf("create blank template: %v", err)
+	}
+	var stored string
+	if err := db.GetContext(ctx, &stored,
+		`SELECT definition::text FROM project_templates WHERE id = $1`, blank.ID); err != nil {
+		t.Fatalf("load blank definition: %v", err)
+	}
+	if want := `{"boards": [], "labels": [], "statuses": [], "issue_types": [], "custom_fields": []}`; stored != want {
+		t.Fatalf("blank definition = %s, want %s", stored, want)
+	}
+	if _, err := GetTemplate(ctx, db, seedWorkspace(t, db), template.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("get from other workspace error = %v, want %v", err, ErrTemplateNotFound)
+	}
+
+	project, err := Create(ctx, db, CreateParams{WorkspaceID: ws, Name: "Delivery", Key: "DLV", TemplateID: template.ID})
+	if err != nil {
+		t.Fatalf("create project from template: %v", err)
+	}
+	var statusNames []string
+	if err := db.SelectContext(ctx, &statusNames,
+		`SELECT name FROM statuses WHERE project_id = $1 ORDER BY position`, project.ID); err != nil {
+		t.Fatalf("list statuses: %v", err)
+	}
+	if len(statusNames) != 3 || statusNames[0] != "Open" || statusNames[2] != "Shipped" {
+		t.Fatalf("statuses = %v, want Open, Building, Shipped", statusNames)
+	}
+	var defaultStatus string
+	if err := db.GetContext(ctx, &defaultStatus,
+		`SELECT s.name FROM issue_types t JOIN statuses s ON s.id = t.default_status_id
+		 WHERE t.project_id = $1 AND t.name = 'Task'`, project.ID); err != nil {
+		t.Fatalf("task default status: %v", err)
+	}
+	if defaultStatus != "Open" {
+		t.Fatalf("task default status = %q, want %q", defaultStatus, "Open")
+	}
+	var mapped int
+	if err := db.GetContext(ctx, &mapped,
+		`SELECT count(*) FROM board_column_statuses bcs
+		 JOIN board_columns bc ON bc.id = bcs.board_column_id
+		 JOIN boards b ON b.id = bc.board_id
+		 WHERE b.project_id = $1 AND bc.name = 'Active'`, project.ID); err != nil {
+		t.Fatalf("count mapped statuses: %v", err)
+	}
+	if mapped != 2 {
+		t.Fatalf("Active column maps %d statuses, want 2", mapped)
+	}
+
+	saved, err := SaveAsTemplate(ctx, db, SaveAsTemplateParams{ProjectID: project.ID, Name: "Delivery copy"})
+	if err != nil {
+		t.Fatalf("save as template: %v", err)
+	}
+	def := saved.Definition
+	if len(def.Statuses) != 3 || len(def.IssueTypes) != 2 || len(def.Boards) != 1 || len(def.Labels) != 1 || len(def.CustomFields) != 1 {
+		t.Fatalf("saved definition = %+v, want the template's setup", def)
+	}
+	if def.Labels[0].Color != "#ff0000" {
+		t.Fatalf("label color = %q, want %q", def.Labels[0].Color, "#ff0000")
+	}
+	if def.IssueTypes[1].DefaultStatus != "Open" || def.CustomFields[0].IssueTypes[0] != "Task" {
+		t.Fatalf("saved references = %+v, %+v", def.IssueTypes[1], def.CustomFields[0])
+	}
+	if cols := def.Boards[0].Columns; len(cols) != 2 || len(cols[1].Statuses) != 2 {
+		t.Fatalf("saved columns = %+v", cols)
+	}
+
+	list, err := ListTemplates(ctx, db, ws)
+	if err != nil {
+		t.Fatalf("list templates: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("got %d templates, want 2", len(list))
+	}
+	if err := DeleteTemplate(ctx, db, ws, saved.ID); err != nil {
+		t.Fatalf("delete template: %v", err)
+	}
+	if err := DeleteTemplate(ctx, db, ws, saved.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("second delete error = %v, want %v", err, ErrTemplateNotFound)
+	}
+}
+
 func seedWorkspace(t *testing.T, db *sqlx.DB) string {
 	t.Helper()
 	return testpg.SeedWorkspace(t, db)
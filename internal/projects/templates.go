@@ -0,0 +1,393 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/boards"
+	"github.com/start-codex/tookly/internal/customfields"
+	"github.com/start-codex/tookly/internal/issuetypes"
+	"github.com/start-codex/tookly/internal/labels"
+	"github.com/start-codex/tookly/internal/statuses"
+)
+
+var (
+	ErrTemplateNotFound  = errors.New("project template not found")
+	ErrDuplicateTemplate = errors.New("project template name already exists in workspace")
+	ErrUnsavableProject  = errors.New("project setup cannot be saved as a template")
+)
+
+// Template is a workspace's reusable project setup.
+type Template struct {
+	ID          string     `db:"id"           json:"id"`
+	WorkspaceID string     `db:"workspace_id" json:"workspace_id"`
+	Name        string     `db:"name"         json:"name"`
+	Description string     `db:"description"  json:"description"`
+	Definition  Definition `db:"-"            json:"definition"`
+	CreatedAt   time.Time  `db:"created_at"   json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"   json:"updated_at"`
+}
+
+// Definition is what a project created from a template starts with. Entries
+// refer to statuses and issue types by name; statuses keep their order as the
+// project's workflow and board columns keep theirs on the board.
+type Definition struct {
+	Statuses     []StatusDef      `json:"statuses"`
+	IssueTypes   []IssueTypeDef   `json:"issue_types"`
+	Boards       []BoardDef       `json:"boards"`
+	Labels       []LabelDef       `json:"labels"`
+	CustomFields []CustomFieldDef `json:"custom_fields"`
+}
+
+type StatusDef struct {
+	Name     string `db:"name"     json:"name"`
+	Category string `db:"category" json:"category"`
+}
+
+type IssueTypeDef struct {
+	Name                string `db:"name"                 json:"name"`
+	Icon                string `db:"icon"                 json:"icon"`
+	Level               int    `db:"level"                json:"level"`
+	DefaultStatus       string `db:"default_status"       json:"default_status,omitempty"`
+	DefaultPriority     string `db:"default_priority"     json:"default_priority,omitempty"`
+	DescriptionTemplate string `db:"description_template" json:"description_template,omitempty"`
+}
+
+type BoardDef struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	FilterQuery string      `json:"filter_query,omitempty"`
+	Columns     []ColumnDef `json:"columns"`
+}
+
+type ColumnDef struct {
+	Name     string   `json:"name"`
+	Statuses []string `json:"statuses"`
+}
+
+type LabelDef struct {
+	Name  string `db:"name"  json:"name"`
+	Color string `db:"color" json:"color"`
+}
+
+// CustomFieldDef is a custom field; IssueTypes limits it to those types and
+// is empty for a field on every type.
+type CustomFieldDef struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options,omitempty"`
+	IssueTypes []string `json:"issue_types,omitempty"`
+}
+
+// templateProject stands in for the project ID when a definition entry is
+// checked with its domain's own validation.
+const templateProject = "template"
+
+// Validate checks every entry with the rules of its domain, that names are
+// unique and that references name a status or issue type of the definition.
+func (def Definition) Validate() error {
+	statusNames := map[string]bool{}
+	for i, s := range def.Statuses {
+		if err := (statuses.CreateParams{ProjectID: templateProject, Name: s.Name, Category: s.Category}).Validate(); err != nil {
+			return fmt.Errorf("statuses[%d]: %w", i, err)
+		}
+		if statusNames[s.Name] {
+			return fmt.Errorf("statuses[%d]: duplicate name %q", i, s.Name)
+		}
+		statusNames[s.Name] = true
+	}
+
+	typeNames := map[string]bool{}
+	for i, t := range def.IssueTypes {
+		if err := (issuetypes.CreateParams{
+			ProjectID: templateProject, Name: t.Name, Icon: t.Icon, Level: t.Level,
+			DefaultPriority: t.DefaultPriority, DescriptionTemplate: t.DescriptionTemplate,
+		}).Validate(); err != nil {
+			return fmt.Errorf("issue_types[%d]: %w", i, err)
+		}
+		if typeNames[t.Name] {
+			return fmt.Errorf("issue_types[%d]: duplicate name %q", i, t.Name)
+		}
+		if t.DefaultStatus != "" && !statusNames[t.DefaultStatus] {
+			return fmt.Errorf("issue_types[%d]: unknown default_status %q", i, t.DefaultStatus)
+		}
+		typeNames[t.Name] = true
+	}
+
+	boardNames := map[string]bool{}
+	for i, b := range def.Boards {
+		if err := (boards.CreateParams{ProjectID: templateProject, Name: b.Name, Type: b.Type, FilterQuery: b.FilterQuery}).Validate(); err != nil {
+			return fmt.Errorf("boards[%d]: %w", i, err)
+		}
+		if boardNames[b.Name] {
+			return fmt.Errorf("boards[%d]: duplicate name %q", i, b.Name)
+		}
+		boardNames[b.Name] = true
+		columnNames := map[string]bool{}
+		for j, c := range b.Columns {
+			if c.Name == "" {
+				return fmt.Errorf("boards[%d].columns[%d]: name is required", i, j)
+			}
+			if columnNames[c.Name] {
+				return fmt.Errorf("boards[%d].columns[%d]: duplicate name %q", i, j, c.Name)
+			}
+			columnNames[c.Name] = true
+			columnStatuses := map[string]bool{}
+			for _, name := range c.Statuses {
+				if !statusNames[name] {
+					return fmt.Errorf("boards[%d].columns[%d]: unknown status %q", i, j, name)
+				}
+				if columnStatuses[name] {
+					return fmt.Errorf("boards[%d].columns[%d]: duplicate status %q", i, j, name)
+				}
+				columnStatuses[name] = true
+			}
+		}
+	}
+
+	labelNames := map[string]bool{}
+	for i, l := range def.Labels {
+		if err := (labels.CreateParams{ProjectID: templateProject, Name: l.Name, Color: l.Color}).Validate(); err != nil {
+			return fmt.Errorf("labels[%d]: %w", i, err)
+		}
+		if labelNames[strings.ToLower(l.Name)] {
+			return fmt.Errorf("labels[%d]: duplicate name %q", i, l.Name)
+		}
+		labelNames[strings.ToLower(l.Name)] = true
+	}
+
+	fieldNames := map[string]bool{}
+	for i, f := range def.CustomFields {
+		if err := (customfields.CreateParams{ProjectID: templateProject, Name: f.Name, Type: f.Type, Options: f.Options}).Validate(); err != nil {
+			return fmt.Errorf("custom_fields[%d]: %w", i, err)
+		}
+		if fieldNames[strings.ToLower(f.Name)] {
+			return fmt.Errorf("custom_fields[%d]: duplicate name %q", i, f.Name)
+		}
+		fieldNames[strings.ToLower(f.Name)] = true
+		fieldTypes := map[string]bool{}
+		for _, name := range f.IssueTypes {
+			if !typeNames[name] {
+				return fmt.Errorf("custom_fields[%d]: unknown issue type %q", i, name)
+			}
+			if fieldTypes[name] {
+				return fmt.Errorf("custom_fields[%d]: duplicate issue type %q", i, name)
+			}
+			fieldTypes[name] = true
+		}
+	}
+	return nil
+}
+
+// normalized returns the definition with missing sections, board columns and
+// column statuses as empty lists, so they encode as [] rather than null.
+func (def Definition) normalized() Definition {
+	def.Statuses = emptyIfNil(def.Statuses)
+	def.IssueTypes = emptyIfNil(def.IssueTypes)
+	def.Labels = emptyIfNil(def.Labels)
+	def.CustomFields = emptyIfNil(def.CustomFields)
+	boardDefs := make([]BoardDef, len(def.Boards))
+	for i, b := range def.Boards {
+		columns := make([]ColumnDef, len(b.Columns))
+		for j, c := range b.Columns {
+			c.Statuses = emptyIfNil(c.Statuses)
+			columns[j] = c
+		}
+		b.Columns = columns
+		boardDefs[i] = b
+	}
+	def.Boards = boardDefs
+	return def
+}
+
+func emptyIfNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+type CreateTemplateParams struct {
+	WorkspaceID string
+	Name        string
+	Description string
+	Definition  Definition
+}
+
+func (params CreateTemplateParams) Validate() error {
+	if params.WorkspaceID == "" {
+		return errors.New("workspace_id is required")
+	}
+	if params.Name == "" {
+		return errors.New("name is required")
+	}
+	return params.Definition.Validate()
+}
+
+// UpdateTemplateParams replaces a template. Projects already created from it
+// are not changed.
+type UpdateTemplateParams struct {
+	WorkspaceID string
+	TemplateID  string
+	Name        string
+	Description string
+	Definition  Definition
+}
+
+func (params UpdateTemplateParams) Validate() error {
+	if params.WorkspaceID == "" {
+		return errors.New("workspace_id is required")
+	}
+	if params.TemplateID == "" {
+		return errors.New("template_id is required")
+	}
+	if params.Name == "" {
+		return errors.New("name is required")
+	}
+	return params.Definition.Validate()
+}
+
+// SaveAsTemplateParams saves a project's current setup as a template of its
+// workspace.
+type SaveAsTemplateParams struct {
+	ProjectID   string
+	Name        string
+	Description string
+}
+
+func (params SaveAsTemplateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// CreateTemplate adds a project template to a workspace. It returns
+// ErrDuplicateTemplate when the workspace already has one with the name.
+func CreateTemplate(ctx context.Context, db *sqlx.DB, params CreateTemplateParams) (Template, error) {
+	if db == nil {
+		return Template{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Template{}, err
+	}
+	return createTemplate(ctx, db, params)
+}
+
+// ListTemplates returns the project templates of a workspace by name.
+func ListTemplates(ctx context.Context, db *sqlx.DB, workspaceID string) ([]Template, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if workspaceID == "" {
+		return nil, errors.New("workspace_id is required")
+	}
+	return listTemplates(ctx, db, workspaceID)
+}
+
+func GetTemplate(ctx context.Context, db *sqlx.DB, workspaceID, templateID string) (Template, error) {
+	if db == nil {
+		return Template{}, errors.New("db is required")
+	}
+	if workspaceID == "" {
+		return Template{}, errors.New("workspace_id is required")
+	}
+	if templateID == "" {
+		return Template{}, errors.New("template_id is required")
+	}
+	return getTemplate(ctx, db, workspaceID, templateID)
+}
+
+func UpdateTemplate(ctx context.Context, db *sqlx.DB, params UpdateTemplateParams) (Template, error) {
+	if db == nil {
+		return Template{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Template{}, err
+	}
+	return updateTemplate(ctx, db, params)
+}
+
+func DeleteTemplate(ctx context.Context, db *sqlx.DB, workspaceID, templateID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if workspaceID == "" {
+		return errors.New("workspace_id is required")
+	}
+	if templateID == "" {
+		return errors.New("template_id is required")
+	}
+	return deleteTemplate(ctx, db, workspaceID, templateID)
+}
+
+// SaveAsTemplate captures the active statuses, issue types, boards with their
+// columns, labels and custom fields of a project as a new template of its
+// workspace.
+func SaveAsTemplate(ctx context.Context, db *sqlx.DB, params SaveAsTemplateParams) (Template, error) {
+	if db == nil {
+		return Template{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Template{}, err
+	}
+	return saveAsTemplate(ctx, db, params)
+}
+
+// builtinDefinitions holds the kanban and scrum presets per locale. They
+// create the statuses and an empty board of the preset's type.
+var builtinDefinitions = map[string]map[string][]StatusDef{
+	"en": {
+		"kanban": {
+			{"To Do", "todo"},
+			{"In Progress", "doing"},
+			{"Done", "done"},
+		},
+		"scrum": {
+			{"Backlog", "todo"},
+			{"To Do", "todo"},
+			{"In Progress", "doing"},
+			{"In Review", "doing"},
+			{"Done", "done"},
+		},
+	},
+	"es": {
+		"kanban": {
+			{"Por hacer", "todo"},
+			{"En progreso", "doing"},
+			{"Hecho", "done"},
+		},
+		"scrum": {
+			{"Backlog", "todo"},
+			{"Por hacer", "todo"},
+			{"En progreso", "doing"},
+			{"En revisión", "doing"},
+			{"Hecho", "done"},
+		},
+	},
+}
+
+// builtinDefinition returns the definition of a built-in template, falling
+// back to English for unknown locales.
+func builtinDefinition(template, locale string) Definition {
+	defs, ok := builtinDefinitions[locale]
+	if !ok {
+		defs = builtinDefinitions["en"]
+	}
+	return Definition{
+		Statuses: defs[template],
+		Boards:   []BoardDef{{Name: "Board", Type: template}},
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package projects
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ListTrash returns the archived projects of a workspace, most recently archived first.
+func ListTrash(ctx context.Context, db *sqlx.DB, workspaceID string) ([]Project, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if workspaceID == "" {
+		return nil, errors.New("workspace_id is required")
+	}
+	return listTrash(ctx, db, workspaceID)
+}
+
+// Restore brings an archived project back with everything it held. Keys stay
+// reserved while a project is archived, so restoring never conflicts.
+func Restore(ctx context.Context, db *sqlx.DB, id string) (Project, error) {
+	if db == nil {
+		return Project{}, errors.New("db is required")
+	}
+	if id == "" {
+		return Project{}, errors.New("id is required")
+	}
+	return restoreProject(ctx, db, id)
+}
+
+// PurgeArchived permanently deletes projects archived before the given time,
+// with all their boards, statuses and issues. It returns how many were deleted.
+func PurgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	if db == nil {
+		return 0, errors.New("db is required")
+	}
+	return purgeArchived(ctx, db, before)
+}
@@ -32,6 +32,14 @@ func Error(w http.ResponseWriter, status int, msg string) {
 	_ = json.NewEncoder(w).Encode(envelope{Status: status, Error: msg})
 }
 
+// ErrorData writes an error response that also carries structured details,
+// for clients that act on more than the message.
+func ErrorData(w http.ResponseWriter, status int, msg string, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(envelope{Status: status, Data: data, Error: msg})
+}
+
 func Decode(r *http.Request, v any) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package search
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/respond"
+)
+
+func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
+	mux.HandleFunc("GET /workspaces/{workspaceID}/search", handleSearch(db))
+}
+
+func fail(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		respond.Error(w, http.StatusUnauthorized, "authentication required")
+	case errors.Is(err, authz.ErrForbidden):
+		respond.Error(w, http.StatusForbidden, "forbidden")
+	case errors.Is(err, authz.ErrWorkspaceNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	default:
+		slog.Error("search handler error", "error", err)
+		respond.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func handleSearch(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wsID := r.PathValue("workspaceID")
+		if err := authz.RequireWorkspaceMembership(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		q := r.URL.Query()
+		limit := 0
+		if raw := q.Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				respond.Error(w, http.StatusUnprocessableEntity, "limit must be an integer")
+				return
+			}
+			limit = n
+		}
+		params := Params{WorkspaceID: wsID, Query: q.Get("q"), Limit: limit}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		results, err := Search(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, results)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package search
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	KindIssue   = "issue"
+	KindComment = "comment"
+
+	defaultLimit  = 20
+	maxLimit      = 100
+	maxQueryBytes = 200
+)
+
+// reKeyPrefix matches issue keys being typed, such as "ENG-", "eng-4" or "ENG-42".
+var reKeyPrefix = regexp.MustCompile(`^([A-Za-z]{2,10})-([0-9]{0,9})$`)
+
+// Result is one search hit. Comment hits carry the comment ID and point at
+// the issue the comment belongs to.
+type Result struct {
+	Kind       string  `db:"kind"        json:"kind"`
+	IssueID    string  `db:"issue_id"    json:"issue_id"`
+	CommentID  *string `db:"comment_id"  json:"comment_id,omitempty"`
+	ProjectID  string  `db:"project_id"  json:"project_id"`
+	ProjectKey string  `db:"project_key" json:"project_key"`
+	Number     int     `db:"number"      json:"number"`
+	Key        string  `db:"issue_key"   json:"key"`
+	Title      string  `db:"title"       json:"title"`
+	// Snippet is HTML-escaped text with matched words wrapped in <mark>.
+	Snippet string  `db:"snippet" json:"snippet"`
+	Rank    float64 `db:"rank"    json:"rank"`
+}
+
+type Params struct {
+	WorkspaceID string
+	Query       string
+	Limit       int
+}
+
+func (params Params) Validate() error {
+	if params.WorkspaceID == "" {
+		return errors.New("workspace_id is required")
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		return errors.New("q is required")
+	}
+	if len(params.Query) > maxQueryBytes {
+		return errors.New("q must be at most 200 bytes")
+	}
+	if params.Limit < 0 || params.Limit > maxLimit {
+		return errors.New("limit must be between 0 and 100")
+	}
+	return nil
+}
+
+// keyPrefix splits a query that looks like an issue key into the project key
+// and the leading digits of the issue number.
+func keyPrefix(query string) (projectKey, number string, ok bool) {
+	m := reKeyPrefix.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return "", "", false
+	}
+	return strings.ToUpper(m[1]), m[2], true
+}
+
+// Search finds active issues and comments in the workspace's active projects.
+// Issue keys matching the query come first, then text matches by rank.
+// A zero Limit falls back to the default page size.
+func Search(ctx context.Context, db *sqlx.DB, params Params) ([]Result, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Limit == 0 {
+		params.Limit = defaultLimit
+	}
+	return search(ctx, db, params)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  Params
+		wantErr bool
+	}{
+		{name: "valid", params: Params{WorkspaceID: "w", Query: "login bug"}, wantErr: false},
+		{name: "valid with limit", params: Params{WorkspaceID: "w", Query: "ENG-42", Limit: 100}, wantErr: false},
+		{name: "missing workspace_id", params: Params{Query: "login"}, wantErr: true},
+		{name: "blank query", params: Params{WorkspaceID: "w", Query: "  "}, wantErr: true},
+		{name: "query too long", params: Params{WorkspaceID: "w", Query: strings.Repeat("a", 201)}, wantErr: true},
+		{name: "negative limit", params: Params{WorkspaceID: "w", Query: "login", Limit: -1}, wantErr: true},
+		{name: "limit too large", params: Params{WorkspaceID: "w", Query: "login", Limit: 101}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantKey    string
+		wantNumber string
+		wantOK     bool
+	}{
+		{query: "ENG-42", wantKey: "ENG", wantNumber: "42", wantOK: true},
+		{query: " eng-4 ", wantKey: "ENG", wantNumber: "4", wantOK: true},
+		{query: "ENG-", wantKey: "ENG", wantNumber: "", wantOK: true},
+		{query: "ENG", wantOK: false},
+		{query: "ENG-4x", wantOK: false},
+		{query: "login ENG-4", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			key, number, ok := keyPrefix(tt.query)
+			if ok != tt.wantOK || key != tt.wantKey || number != tt.wantNumber {
+				t.Fatalf("keyPrefix(%q) = %q, %q, %v; want %q, %q, %v", tt.query, key, number, ok, tt.wantKey, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSearch_NilDB(t *testing.T) {
+	_, err := Search(context.Background(), nil, Params{WorkspaceID: "w", Query: "login"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Search() error = %v, want %q", err, "db is required")
+	}
+}
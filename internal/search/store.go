@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ts_headline marks matches with these; they survive HTML escaping and are
+// then swapped for <mark> tags.
+const (
+	highlightStart = "{{hl}}"
+	highlightStop  = "{{/hl}}"
+)
+
+var headlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"` +
+	`, MaxWords=24, MinWords=8, MaxFragments=2, FragmentDelimiter=" ... "`
+
+var highlighter = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+func search(ctx context.Context, db *sqlx.DB, params Params) ([]Result, error) {
+	args := []any{params.WorkspaceID, params.Query, headlineOptions}
+	keyMatches := ""
+	if projectKey, number, ok := keyPrefix(params.Query); ok {
+		args = append(args, projectKey, number)
+		// An exact key outranks any text match, a prefix follows it.
+		keyMatches = `
+			UNION ALL
+			SELECT 'issue', i.id, NULL::uuid,
+			       CASE WHEN i.number::text = $5 THEN 3 ELSE 2 END
+			FROM issues i
+			JOIN projects p ON p.id = i.project_id
+			WHERE p.workspace_id = $1
+			  AND p.archived_at IS NULL
+			  AND i.archived_at IS NULL
+			  AND p.key = $4
+			  AND i.number::text LIKE $5 || '%'`
+	}
+	args = append(args, params.Limit)
+	limitArg := "$" + strconv.Itoa(len(args))
+
+	results := []Result{}
+	if err := db.SelectContext(ctx, &results,
+		`WITH q AS (
+			SELECT websearch_to_tsquery('simple', $2) AS query
+		),
+		matches AS (
+			SELECT 'issue' AS kind, i.id AS issue_id, NULL::uuid AS comment_id,
+			       ts_rank(i.search_vector, q.query, 32) AS rank
+			FROM issues i
+			JOIN projects p ON p.id = i.project_id
+			CROSS JOIN q
+			WHERE p.workspace_id = $1
+			  AND p.archived_at IS NULL
+			  AND i.archived_at IS NULL
+			  AND i.search_vector @@ q.query
+			UNION ALL
+			SELECT 'comment', c.issue_id, c.id,
+			       ts_rank(c.search_vector, q.query, 32)
+			FROM issue_comments c
+			JOIN issues i ON i.id = c.issue_id
+			JOIN projects p ON p.id = i.project_id
+			CROSS JOIN q
+			WHERE p.workspace_id = $1
+			  AND p.archived_at IS NULL
+			  AND i.archived_at IS NULL
+			  AND c.deleted_at IS NULL
+			  AND c.search_vector @@ q.query`+keyMatches+`
+		),
+		top AS (
+			SELECT kind, issue_id, comment_id, MAX(rank) AS rank
+			FROM matches
+			GROUP BY kind, issue_id, comment_id
+			ORDER BY rank DESC, issue_id, comment_id NULLS FIRST
+			LIMIT `+limitArg+`
+		)
+		SELECT t.kind, t.issue_id, t.comment_id,
+		       p.id AS project_id, p.key AS project_key, i.number,
+		       p.key || '-' || i.number AS issue_key, i.title,
+		       ts_headline('simple',
+		           CASE WHEN t.kind = 'comment' THEN c.body ELSE i.title || ' ' || i.description END,
+		           q.query, $3) AS snippet,
+		       t.rank
+		FROM top t
+		JOIN issues i ON i.id = t.issue_id
+		JOIN projects p ON p.id = i.project_id
+		LEFT JOIN issue_comments c ON c.id = t.comment_id
+		CROSS JOIN q
+		ORDER BY t.rank DESC, p.key, i.number, t.comment_id NULLS FIRST`,
+		args...,
+	); err != nil {
+		return nil, fmt.Errorf("search workspace: %w", err)
+	}
+	for i := range results {
+		results[i].Snippet = highlighter.Replace(html.EscapeString(results[i].Snippet))
+	}
+	return results, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+func TestSearch(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := testpg.SeedWorkspace(t, db)
+	otherWS := testpg.SeedWorkspace(t, db)
+	reporterID := testpg.SeedUser(t, db)
+	projectID := testpg.SeedProject(t, db, ws, "SRCH")
+	archivedProjectID := testpg.SeedProject(t, db, ws, "SRCA")
+	foreignProjectID := testpg.SeedProject(t, db, otherWS, "SRCH")
+	if _, err := db.Exec(`UPDATE projects SET archived_at = NOW() WHERE id = $1`, archivedProjectID); err != nil {
+		t.Fatalf("archive project: %v", err)
+	}
+
+	login := seedIssue(t, db, projectID, reporterID, 4, "Login fails on Safari", "The <b>session</b> cookie is dropped")
+	seedIssue(t, db, projectID, reporterID, 42, "Export report", "CSV export times out")
+	archived := seedIssue(t, db, projectID, reporterID, 43, "Login page redesign", "")
+	if _, err := db.Exec(`UPDATE issues SET archived_at = NOW() WHERE id = $1`, archived); err != nil {
+		t.Fatalf("archive issue: %v", err)
+	}
+	seedIssue(t, db, archivedProjectID, reporterID, 1, "Login in archived project", "")
+	seedIssue(t, db, foreignProjectID, reporterID, 1, "Login in other workspace", "")
+
+	var commentID string
+	if err := db.Get(&commentID, `INSERT INTO issue_comments (issue_id, author_id, body) VALUES ($1, $2, 'Happens after the login redirect too') RETURNING id`, login, reporterID); err != nil {
+		t.Fatalf("seed comment: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issue_comments (issue_id, author_id, body, deleted_at) VALUES ($1, $2, 'deleted login note', NOW())`, login, reporterID); err != nil {
+		t.Fatalf("seed deleted comment: %v", err)
+	}
+
+	results, err := Search(ctx, db, Params{WorkspaceID: ws, Query: "login"})
+	if err != nil {
+		t.Fatalf("Search(login) error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Search(login): got %+v, want the issue and its comment", results)
+	}
+	if results[0].Kind != KindIssue || results[0].IssueID != login || results[0].Key != "SRCH-4" {
+		t.Fatalf("first result: got %+v, want the title match", results[0])
+	}
+	if results[1].Kind != KindComment || results[1].CommentID == nil || *results[1].CommentID != commentID {
+		t.Fatalf("second result: got %+v, want the comment", results[1])
+	}
+	if !strings.Contains(results[0].Snippet, "<mark>Login</mark>") || !strings.Contains(results[0].Snippet, "&lt;b&gt;") {
+		t.Fatalf("snippet: got %q, want a highlighted, escaped match", results[0].Snippet)
+	}
+
+	results, err = Search(ctx, db, Params{WorkspaceID: ws, Query: "srch-4"})
+	if err != nil {
+		t.Fatalf("Search(srch-4) error = %v", err)
+	}
+	keys := []string{}
+	for _, r := range results {
+		keys = append(keys, r.Key)
+	}
+	if strings.Join(keys, ",") != "SRCH-4,SRCH-42" {
+		t.Fatalf("Search(srch-4): got keys %v, want exact key then prefix", keys)
+	}
+
+	results, err = Search(ctx, db, Params{WorkspaceID: ws, Query: "csv export", Limit: 1})
+	if err != nil {
+		t.Fatalf("Search(csv export) error = %v", err)
+	}
+	if len(results) != 1 || results[0].Number != 42 {
+		t.Fatalf("Search(csv export): got %+v", results)
+	}
+}
+
+func seedIssue(t *testing.T, db *sqlx.DB, projectID, reporterID string, number int, title, description string) string {
+	t.Helper()
+	var id string
+	if err := db.Get(&id,
+		`WITH st AS (
+			INSERT INTO statuses (project_id, name, category, position)
+			VALUES ($1, 'Status ' || $2::text, 'todo', $2)
+			RETURNING id
+		), it AS (
+			INSERT INTO issue_types (project_id, name, level)
+			VALUES ($1, 'Type ' || $2::text, 1)
+			RETURNING id
+		)
+		INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id)
+		SELECT $1, $2, it.id, st.id, $3, $4, 'medium', $5 FROM st, it
+		RETURNING id`,
+		projectID, number, title, description, reporterID,
+	); err != nil {
+		t.Fatalf("seed issue: %v", err)
+	}
+	return id
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package sprints
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/respond"
+)
+
+func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
+	mux.HandleFunc("POST /projects/{projectID}/sprints", handleCreate(db))
+	mux.HandleFunc("GET /projects/{projectID}/sprints", handleList(db))
+	mux.HandleFunc("GET /projects/{projectID}/sprints/{sprintID}", handleGet(db))
+	mux.HandleFunc("POST /projects/{projectID}/sprints/{sprintID}/issues", handleAddIssues(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/sprints/{sprintID}/issues/{issueID}", handleRemoveIssue(db))
+	mux.HandleFunc("POST /projects/{projectID}/sprints/{sprintID}/start", handleStart(db))
+	mux.HandleFunc("POST /projects/{projectID}/sprints/{sprintID}/complete", handleComplete(db))
+}
+
+func fail(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		respond.Error(w, http.StatusUnauthorized, "authentication required")
+	case errors.Is(err, authz.ErrForbidden):
+		respond.Error(w, http.StatusForbidden, "forbidden")
+	case errors.Is(err, authz.ErrWorkspaceNotFound),
+		errors.Is(err, authz.ErrProjectNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrIssueNotFound):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrActiveExists), errors.Is(err, ErrNotPlanned),
+		errors.Is(err, ErrNotActive), errors.Is(err, ErrClosed):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrTargetNotPlanned), errors.Is(err, ErrEndsBeforeStart):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		slog.Error("sprints handler error", "error", err)
+		respond.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func parseDate(name string, s *string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", *s)
+	if err != nil {
+		return nil, errors.New(name + " must be YYYY-MM-DD format")
+	}
+	return &t, nil
+}
+
+func handleCreate(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name      string  `json:"name"`
+			Goal      string  `json:"goal"`
+			StartDate *string `json:"start_date"`
+			EndDate   *string `json:"end_date"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		startDate, err := parseDate("start_date", body.StartDate)
+		if err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		endDate, err := parseDate("end_date", body.EndDate)
+		if err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		params := CreateParams{
+			ProjectID: r.PathValue("projectID"),
+			Name:      body.Name,
+			Goal:      body.Goal,
+			StartDate: startDate,
+			EndDate:   endDate,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		sprint, err := Create(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, sprint)
+	}
+}
+
+func handleList(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := List(r.Context(), db, r.PathValue("projectID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleGet(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		sprint, err := Get(r.Context(), db, r.PathValue("projectID"), r.PathValue("sprintID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, sprint)
+	}
+}
+
+func handleAddIssues(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			IssueIDs []string `json:"issue_ids"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := IssuesParams{
+			ProjectID: r.PathValue("projectID"),
+			SprintID:  r.PathValue("sprintID"),
+			IssueIDs:  body.IssueIDs,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := AddIssues(r.Context(), db, params); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleRemoveIssue(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := RemoveIssue(r.Context(), db, r.PathValue("projectID"), r.PathValue("sprintID"), r.PathValue("issueID")); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleStart(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		sprint, err := Start(r.Context(), db, r.PathValue("projectID"), r.PathValue("sprintID"))
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, sprint)
+	}
+}
+
+func handleComplete(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := authz.RequireProjectMembership(r.Context(), db, r.PathValue("projectID")); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			TargetSprintID string `json:"target_sprint_id"`
+		}
+		// The body is optional: without a target, unfinished issues go to the backlog.
+		if err := respond.Decode(r, &body); err != nil && !errors.Is(err, io.EOF) {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CompleteParams{
+			ProjectID:      r.PathValue("projectID"),
+			SprintID:       r.PathValue("sprintID"),
+			TargetSprintID: body.TargetSprintID,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		result, err := Complete(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, result)
+	}
+}
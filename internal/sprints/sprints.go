@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package sprints
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	StatePlanned = "planned"
+	StateActive  = "active"
+	StateClosed  = "closed"
+)
+
+var (
+	ErrNotFound         = errors.New("sprint not found")
+	ErrIssueNotFound    = errors.New("issue not found")
+	ErrActiveExists     = errors.New("project already has an active sprint")
+	ErrNotPlanned       = errors.New("sprint is not planned")
+	ErrNotActive        = errors.New("sprint is not active")
+	ErrClosed           = errors.New("sprint is closed")
+	ErrTargetNotPlanned = errors.New("target sprint must be a planned sprint of the same project")
+	ErrEndsBeforeStart  = errors.New("sprint without start_date would start today, after its end_date")
+)
+
+type Sprint struct {
+	ID          string     `db:"id"           json:"id"`
+	ProjectID   string     `db:"project_id"   json:"project_id"`
+	Name        string     `db:"name"         json:"name"`
+	Goal        string     `db:"goal"         json:"goal"`
+	StartDate   *time.Time `db:"start_date"   json:"start_date,omitempty"`
+	EndDate     *time.Time `db:"end_date"     json:"end_date,omitempty"`
+	State       string     `db:"state"        json:"state"`
+	StartedAt   *time.Time `db:"started_at"   json:"started_at,omitempty"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at"   json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"   json:"updated_at"`
+}
+
+// CompleteResult is a closed sprint and the unfinished issues carried out of it.
+type CompleteResult struct {
+	Sprint        Sprint   `json:"sprint"`
+	MovedIssueIDs []string `json:"moved_issue_ids"`
+}
+
+type CreateParams struct {
+	ProjectID string
+	Name      string
+	Goal      string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+func (params CreateParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return errors.New("name is required")
+	}
+	if params.StartDate != nil && params.EndDate != nil && params.EndDate.Before(*params.StartDate) {
+		return errors.New("end_date must not be before start_date")
+	}
+	return nil
+}
+
+type IssuesParams struct {
+	ProjectID string
+	SprintID  string
+	IssueIDs  []string
+}
+
+func (params IssuesParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.SprintID == "" {
+		return errors.New("sprint_id is required")
+	}
+	if len(params.IssueIDs) == 0 {
+		return errors.New("issue_ids is required")
+	}
+	for _, id := range params.IssueIDs {
+		if id == "" {
+			return errors.New("issue_ids must not contain empty values")
+		}
+	}
+	return nil
+}
+
+type CompleteParams struct {
+	ProjectID string
+	SprintID  string
+	// TargetSprintID receives the unfinished issues; empty sends them to the backlog.
+	TargetSprintID string
+}
+
+func (params CompleteParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.SprintID == "" {
+		return errors.New("sprint_id is required")
+	}
+	if params.TargetSprintID == params.SprintID {
+		return errors.New("target_sprint_id must differ from sprint_id")
+	}
+	return nil
+}
+
+func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Sprint, error) {
+	if db == nil {
+		return Sprint{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Sprint{}, err
+	}
+	return createSprint(ctx, db, params)
+}
+
+func Get(ctx context.Context, db *sqlx.DB, projectID, sprintID string) (Sprint, error) {
+	if db == nil {
+		return Sprint{}, errors.New("db is required")
+	}
+	if projectID == "" {
+		return Sprint{}, errors.New("project_id is required")
+	}
+	if sprintID == "" {
+		return Sprint{}, errors.New("sprint_id is required")
+	}
+	return getSprint(ctx, db, projectID, sprintID)
+}
+
+func List(ctx context.Context, db *sqlx.DB, projectID string) ([]Sprint, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listSprints(ctx, db, projectID)
+}
+
+// AddIssues moves issues of the project into a planned or active sprint,
+// taking them out of whichever sprint or backlog held them before.
+func AddIssues(ctx context.Context, db *sqlx.DB, params IssuesParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	return addIssues(ctx, db, params)
+}
+
+// RemoveIssue sends an issue of an open sprint back to the backlog.
+func RemoveIssue(ctx context.Context, db *sqlx.DB, projectID, sprintID, issueID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if sprintID == "" {
+		return errors.New("sprint_id is required")
+	}
+	if issueID == "" {
+		return errors.New("issue_id is required")
+	}
+	return removeIssue(ctx, db, projectID, sprintID, issueID)
+}
+
+// Start activates a planned sprint. A project has at most one active sprint.
+// A sprint without a start date starts today (UTC); it returns
+// ErrEndsBeforeStart when that is after the sprint's end date.
+func Start(ctx context.Context, db *sqlx.DB, projectID, sprintID string) (Sprint, error) {
+	if db == nil {
+		return Sprint{}, errors.New("db is required")
+	}
+	if projectID == "" {
+		return Sprint{}, errors.New("project_id is required")
+	}
+	if sprintID == "" {
+		return Sprint{}, errors.New("sprint_id is required")
+	}
+	return startSprint(ctx, db, projectID, sprintID)
+}
+
+// Complete closes the active sprint. Issues whose status category is not
+// "done" move to the target sprint, or to the backlog when none is given;
+// finished issues stay attached to the closed sprint.
+func Complete(ctx context.Context, db *sqlx.DB, params CompleteParams) (CompleteResult, error) {
+	if db == nil {
+		return CompleteResult{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return CompleteResult{}, err
+	}
+	return completeSprint(ctx, db, params)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package sprints
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCreateSprintParams_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 14)
+	valid := CreateParams{ProjectID: "p", Name: "Sprint 1", StartDate: &start, EndDate: &end}
+
+	tests := []struct {
+		name    string
+		params  CreateParams
+		wantErr bool
+	}{
+		{name: "valid", params: valid, wantErr: false},
+		{name: "valid without dates", params: CreateParams{ProjectID: "p", Name: "Sprint 1"}, wantErr: false},
+		{name: "missing project_id", params: func() CreateParams { c := valid; c.ProjectID = ""; return c }(), wantErr: true},
+		{name: "blank name", params: func() CreateParams { c := valid; c.Name = "  "; return c }(), wantErr: true},
+		{name: "end before start", params: func() CreateParams { c := valid; c.StartDate, c.EndDate = &end, &start; return c }(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssuesParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  IssuesParams
+		wantErr bool
+	}{
+		{name: "valid", params: IssuesParams{ProjectID: "p", SprintID: "s", IssueIDs: []string{"i"}}, wantErr: false},
+		{name: "missing project_id", params: IssuesParams{SprintID: "s", IssueIDs: []string{"i"}}, wantErr: true},
+		{name: "missing sprint_id", params: IssuesParams{ProjectID: "p", IssueIDs: []string{"i"}}, wantErr: true},
+		{name: "no issues", params: IssuesParams{ProjectID: "p", SprintID: "s"}, wantErr: true},
+		{name: "empty issue id", params: IssuesParams{ProjectID: "p", SprintID: "s", IssueIDs: []string{"i", ""}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompleteParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  CompleteParams
+		wantErr bool
+	}{
+		{name: "to backlog", params: CompleteParams{ProjectID: "p", SprintID: "s"}, wantErr: false},
+		{name: "to next sprint", params: CompleteParams{ProjectID: "p", SprintID: "s", TargetSprintID: "n"}, wantErr: false},
+		{name: "missing project_id", params: CompleteParams{SprintID: "s"}, wantErr: true},
+		{name: "missing sprint_id", params: CompleteParams{ProjectID: "p"}, wantErr: true},
+		{name: "target is itself", params: CompleteParams{ProjectID: "p", SprintID: "s", TargetSprintID: "s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateSprint_NilDB(t *testing.T) {
+	_, err := Create(context.Background(), nil, CreateParams{ProjectID: "p", Name: "Sprint 1"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Create() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestListSprints_NilDB(t *testing.T) {
+	_, err := List(context.Background(), nil, "p")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("List() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestAddIssues_NilDB(t *testing.T) {
+	err := AddIssues(context.Background(), nil, IssuesParams{ProjectID: "p", SprintID: "s", IssueIDs: []string{"i"}})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("AddIssues() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestStartSprint_NilDB(t *testing.T) {
+	_, err := Start(context.Background(), nil, "p", "s")
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Start() error = %v, want %q", err, "db is required")
+	}
+}
+
+func TestCompleteSprint_NilDB(t *testing.T) {
+	_, err := Complete(context.Background(), nil, CompleteParams{ProjectID: "p", SprintID: "s"})
+	if err == nil || err.Error() != "db is required" {
+		t.Fatalf("Complete() error = %v, want %q", err, "db is required")
+	}
+}
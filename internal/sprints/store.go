@@ -0,0 +1,268 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package sprints
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/pgutil"
+)
+
+const sprintCols = `id, project_id, name, goal, start_date, end_date, state, started_at, completed_at, created_at, updated_at`
+
+func createSprint(ctx context.Context, db *sqlx.DB, params CreateParams) (Sprint, error) {
+	var sprint Sprint
+	err := db.QueryRowxContext(
+		ctx,
+		`INSERT INTO sprints (project_id, name, goal, start_date, end_date)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING `+sprintCols,
+		params.ProjectID,
+		params.Name,
+		params.Goal,
+		params.StartDate,
+		params.EndDate,
+	).StructScan(&sprint)
+	if err != nil {
+		return Sprint{}, fmt.Errorf("insert sprint: %w", err)
+	}
+	return sprint, nil
+}
+
+func getSprint(ctx context.Context, db *sqlx.DB, projectID, sprintID string) (Sprint, error) {
+	var sprint Sprint
+	err := db.GetContext(
+		ctx,
+		&sprint,
+		`SELECT `+sprintCols+`
+		 FROM sprints
+		 WHERE id = $1
+		   AND project_id = $2`,
+		sprintID,
+		projectID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Sprint{}, ErrNotFound
+		}
+		return Sprint{}, fmt.Errorf("get sprint: %w", err)
+	}
+	return sprint, nil
+}
+
+func listSprints(ctx context.Context, db *sqlx.DB, projectID string) ([]Sprint, error) {
+	sprints := []Sprint{}
+	err := db.SelectContext(
+		ctx,
+		&sprints,
+		`SELECT `+sprintCols+`
+		 FROM sprints
+		 WHERE project_id = $1
+		 ORDER BY created_at ASC, id ASC`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list sprints: %w", err)
+	}
+	return sprints, nil
+}
+
+func lockSprint(ctx context.Context, tx *sqlx.Tx, projectID, sprintID string) (Sprint, error) {
+	var sprint Sprint
+	err := tx.GetContext(
+		ctx,
+		&sprint,
+		`SELECT `+sprintCols+`
+		 FROM sprints
+		 WHERE id = $1
+		   AND project_id = $2
+		 FOR UPDATE`,
+		sprintID,
+		projectID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Sprint{}, ErrNotFound
+		}
+		return Sprint{}, fmt.Errorf("lock sprint: %w", err)
+	}
+	return sprint, nil
+}
+
+func addIssues(ctx context.Context, db *sqlx.DB, params IssuesParams) error {
+	issueIDs := slices.Compact(slices.Sorted(slices.Values(params.IssueIDs)))
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit add sprint issues", func(tx *sqlx.Tx) error {
+		sprint, err := lockSprint(ctx, tx, params.ProjectID, params.SprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.State == StateClosed {
+			return ErrClosed
+		}
+		res, err := tx.ExecContext(
+			ctx,
+			`UPDATE issues
+			 SET sprint_id = $1
+			 WHERE project_id = $2
+			   AND id = ANY($3::uuid[])
+			   AND archived_at IS NULL`,
+			params.SprintID,
+			params.ProjectID,
+			pq.Array(issueIDs),
+		)
+		if err != nil {
+			return fmt.Errorf("add issues to sprint: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("add issues to sprint rows affected: %w", err)
+		}
+		if n != int64(len(issueIDs)) {
+			return ErrIssueNotFound
+		}
+		return nil
+	})
+}
+
+func removeIssue(ctx context.Context, db *sqlx.DB, projectID, sprintID, issueID string) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit remove sprint issue", func(tx *sqlx.Tx) error {
+		sprint, err := lockSprint(ctx, tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.State == StateClosed {
+			return ErrClosed
+		}
+		res, err := tx.ExecContext(
+			ctx,
+			`UPDATE issues
+			 SET sprint_id = NULL
+			 WHERE id = $1
+			   AND project_id = $2
+			   AND sprint_id = $3`,
+			issueID,
+			projectID,
+			sprintID,
+		)
+		if err != nil {
+			return fmt.Errorf("remove issue from sprint: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("remove issue from sprint rows affected: %w", err)
+		}
+		if n == 0 {
+			return ErrIssueNotFound
+		}
+		return nil
+	})
+}
+
+func startSprint(ctx context.Context, db *sqlx.DB, projectID, sprintID string) (Sprint, error) {
+	var sprint Sprint
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit start sprint", func(tx *sqlx.Tx) error {
+		current, err := lockSprint(ctx, tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if current.State != StatePlanned {
+			return ErrNotPlanned
+		}
+		startDate := time.Now().UTC().Format(time.DateOnly)
+		if current.StartDate != nil {
+			startDate = current.StartDate.Format(time.DateOnly)
+		}
+		if current.EndDate != nil && current.EndDate.Format(time.DateOnly) < startDate {
+			return ErrEndsBeforeStart
+		}
+		if err := tx.QueryRowxContext(
+			ctx,
+			`UPDATE sprints
+			 SET state      = 'active',
+			     started_at = NOW(),
+			     start_date = $2::date
+			 WHERE id = $1
+			 RETURNING `+sprintCols,
+			sprintID, startDate,
+		).StructScan(&sprint); err != nil {
+			if pgutil.IsUniqueViolation(err) {
+				return ErrActiveExists
+			}
+			return fmt.Errorf("start sprint: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return Sprint{}, err
+	}
+	return sprint, nil
+}
+
+func completeSprint(ctx context.Context, db *sqlx.DB, params CompleteParams) (CompleteResult, error) {
+	result := CompleteResult{MovedIssueIDs: []string{}}
+	if err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit complete sprint", func(tx *sqlx.Tx) error {
+		current, err := lockSprint(ctx, tx, params.ProjectID, params.SprintID)
+		if err != nil {
+			return err
+		}
+		if current.State != StateActive {
+			return ErrNotActive
+		}
+
+		var targetID *string
+		if params.TargetSprintID != "" {
+			target, err := lockSprint(ctx, tx, params.ProjectID, params.TargetSprintID)
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					return ErrTargetNotPlanned
+				}
+				return err
+			}
+			if target.State != StatePlanned {
+				return ErrTargetNotPlanned
+			}
+			targetID = &params.TargetSprintID
+		}
+
+		if err := tx.SelectContext(
+			ctx,
+			&result.MovedIssueIDs,
+			`UPDATE issues
+			 SET sprint_id = $1
+			 WHERE sprint_id = $2
+			   AND archived_at IS NULL
+			   AND status_id IN (SELECT id FROM statuses WHERE category <> 'done')
+			 RETURNING id`,
+			targetID,
+			params.SprintID,
+		); err != nil {
+			return fmt.Errorf("move unfinished sprint issues: %w", err)
+		}
+
+		if err := tx.QueryRowxContext(
+			ctx,
+			`UPDATE sprints
+			 SET state        = 'closed',
+			     completed_at = NOW(),
+			     end_date     = COALESCE(end_date, GREATEST(start_date, CURRENT_DATE))
+			 WHERE id = $1
+			 RETURNING `+sprintCols,
+			params.SprintID,
+		).StructScan(&result.Sprint); err != nil {
+			return fmt.Errorf("close sprint: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return CompleteResult{}, err
+	}
+	return result, nil
+}
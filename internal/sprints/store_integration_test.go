@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package sprints
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+type sprintSeed struct {
+	projectID    string
+	statusTodoID string
+	statusDoneID string
+	issueTypeID  string
+	reporterID   string
+}
+
+func seedProject(t *testing.T, db *sqlx.DB) sprintSeed {
+	t.Helper()
+	ws := testpg.SeedWorkspace(t, db)
+	seed := sprintSeed{
+		projectID:  testpg.SeedProject(t, db, ws, "SPR"),
+		reporterID: testpg.SeedUser(t, db),
+	}
+	if err := db.Get(&seed.statusTodoID,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'To Do', 'todo', 0) RETURNING id`,
+		seed.projectID,
+	); err != nil {
+		t.Fatalf("seed todo status: %v", err)
+	}
+	if err := db.Get(&seed.statusDoneID,
+		`INSERT INTO statuses (project_id, name, category, position) VALUES ($1, 'Done', 'done', 1) RETURNING id`,
+		seed.projectID,
+	); err != nil {
+		t.Fatalf("seed done status: %v", err)
+	}
+	if err := db.Get(&seed.issueTypeID,
+		`INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Story', 1) RETURNING id`,
+		seed.projectID,
+	); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	return seed
+}
+
+func insertIssue(t *testing.T, db *sqlx.DB, seed sprintSeed, number int, statusID string) string {
+	t.Helper()
+	var id string
+	if err := db.Get(&id,
+		`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, description, priority, reporter_id, status_position)
+		 VALUES ($1, $2, $3, $4, 'Issue', '', 'medium', $5, $2)
+		 RETURNING id`,
+		seed.projectID, number, seed.issueTypeID, statusID, seed.reporterID,
+	); err != nil {
+		t.Fatalf("insert issue: %v", err)
+	}
+	return id
+}
+
+func issueSprint(t *testing.T, db *sqlx.DB, issueID string) *string {
+	t.Helper()
+	var sprintID *string
+	if err := db.Get(&sprintID, `SELECT sprint_id FROM issues WHERE id = $1`, issueID); err != nil {
+		t.Fatalf("get issue sprint: %v", err)
+	}
+	return sprintID
+}
+
+func TestSprintLifecycle(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	open := insertIssue(t, db, seed, 1, seed.statusTodoID)
+	done := insertIssue(t, db, seed, 2, seed.statusDoneID)
+
+	first, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Sprint 1", Goal: "Ship login"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.State != StatePlanned {
+		t.Fatalf("state: got %q, want %q", first.State, StatePlanned)
+	}
+	second, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Sprint 2"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := AddIssues(ctx, db, IssuesParams{ProjectID: seed.projectID, SprintID: first.ID, IssueIDs: []string{open, done, open}}); err != nil {
+		t.Fatalf("AddIssues() error = %v", err)
+	}
+	err = AddIssues(ctx, db, IssuesParams{ProjectID: seed.projectID, SprintID: first.ID, IssueIDs: []string{"00000000-0000-0000-0000-000000000000"}})
+	if !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("AddIssues() unknown issue error = %v, want ErrIssueNotFound", err)
+	}
+
+	started, err := Start(ctx, db, seed.projectID, first.ID)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if started.State != StateActive || started.StartedAt == nil || started.StartDate == nil {
+		t.Fatalf("started sprint = %+v", started)
+	}
+	if _, err := Start(ctx, db, seed.projectID, second.ID); !errors.Is(err, ErrActiveExists) {
+		t.Fatalf("Start() second sprint error = %v, want ErrActiveExists", err)
+	}
+	if _, err := Start(ctx, db, seed.projectID, first.ID); !errors.Is(err, ErrNotPlanned) {
+		t.Fatalf("Start() active sprint error = %v, want ErrNotPlanned", err)
+	}
+
+	result, err := Complete(ctx, db, CompleteParams{ProjectID: seed.projectID, SprintID: first.ID, TargetSprintID: second.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if result.Sprint.State != StateClosed || result.Sprint.CompletedAt == nil {
+		t.Fatalf("completed sprint = %+v", result.Sprint)
+	}
+	if !slices.Equal(result.MovedIssueIDs, []string{open}) {
+		t.Fatalf("moved issues: got %v, want [%s]", result.MovedIssueIDs, open)
+	}
+	if got := issueSprint(t, db, open); got == nil || *got != second.ID {
+		t.Fatalf("unfinished issue sprint: got %v, want %s", got, second.ID)
+	}
+	if got := issueSprint(t, db, done); got == nil || *got != first.ID {
+		t.Fatalf("done issue sprint: got %v, want %s", got, first.ID)
+	}
+
+	if err := AddIssues(ctx, db, IssuesParams{ProjectID: seed.projectID, SprintID: first.ID, IssueIDs: []string{open}}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("AddIssues() closed sprint error = %v, want ErrClosed", err)
+	}
+	if _, err := Complete(ctx, db, CompleteParams{ProjectID: seed.projectID, SprintID: first.ID}); !errors.Is(err, ErrNotActive) {
+		t.Fatalf("Complete() closed sprint error = %v, want ErrNotActive", err)
+	}
+}
+
+func TestCompleteSprintToBacklog(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	open := insertIssue(t, db, seed, 1, seed.statusTodoID)
+
+	sprint, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Sprint 1"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := AddIssues(ctx, db, IssuesParams{ProjectID: seed.projectID, SprintID: sprint.ID, IssueIDs: []string{open}}); err != nil {
+		t.Fatalf("AddIssues() error = %v", err)
+	}
+	if _, err := Start(ctx, db, seed.projectID, sprint.ID); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if _, err := Complete(ctx, db, CompleteParams{ProjectID: seed.projectID, SprintID: sprint.ID, TargetSprintID: "00000000-0000-0000-0000-000000000000"}); !errors.Is(err, ErrTargetNotPlanned) {
+		t.Fatalf("Complete() unknown target error = %v, want ErrTargetNotPlanned", err)
+	}
+	if _, err := Complete(ctx, db, CompleteParams{ProjectID: seed.projectID, SprintID: sprint.ID}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if got := issueSprint(t, db, open); got != nil {
+		t.Fatalf("unfinished issue sprint: got %v, want backlog", *got)
+	}
+}
+
+func TestStartSprintEndingInPast(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	sprint, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Late", EndDate: &yesterday})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := Start(ctx, db, seed.projectID, sprint.ID); !errors.Is(err, ErrEndsBeforeStart) {
+		t.Fatalf("Start() error = %v, want ErrEndsBeforeStart", err)
+	}
+
+	lastWeek := yesterday.AddDate(0, 0, -6)
+	dated, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Dated", StartDate: &lastWeek, EndDate: &yesterday})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	started, err := Start(ctx, db, seed.projectID, dated.ID)
+	if err != nil {
+		t.Fatalf("Start() dated sprint error = %v", err)
+	}
+	if got := started.StartDate.Format(time.DateOnly); got != lastWeek.Format(time.DateOnly) {
+		t.Fatalf("start_date: got %s, want %s", got, lastWeek.Format(time.DateOnly))
+	}
+}
+
+func TestRemoveIssue(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	seed := seedProject(t, db)
+	issue := insertIssue(t, db, seed, 1, seed.statusTodoID)
+	sprint, err := Create(ctx, db, CreateParams{ProjectID: seed.projectID, Name: "Sprint 1"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := AddIssues(ctx, db, IssuesParams{ProjectID: seed.projectID, SprintID: sprint.ID, IssueIDs: []string{issue}}); err != nil {
+		t.Fatalf("AddIssues() error = %v", err)
+	}
+	if err := RemoveIssue(ctx, db, seed.projectID, sprint.ID, issue); err != nil {
+		t.Fatalf("RemoveIssue() error = %v", err)
+	}
+	if got := issueSprint(t, db, issue); got != nil {
+		t.Fatalf("issue sprint: got %v, want backlog", *got)
+	}
+	if err := RemoveIssue(ctx, db, seed.projectID, sprint.ID, issue); !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("RemoveIssue() twice error = %v, want ErrIssueNotFound", err)
+	}
+}
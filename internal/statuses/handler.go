@@ -7,6 +7,7 @@ package statuses
 
 import (
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -19,7 +20,14 @@ func RegisterRoutes(mux *http.ServeMux, db *sqlx.DB) {
 	mux.HandleFunc("POST /projects/{projectID}/statuses", handleCreate(db))
 	mux.HandleFunc("GET /projects/{projectID}/statuses", handleList(db))
 	mux.HandleFunc("PUT /projects/{projectID}/statuses/{statusID}", handleUpdate(db))
+	mux.HandleFunc("PUT /projects/{projectID}/statuses/order", handleReorder(db))
 	mux.HandleFunc("DELETE /projects/{projectID}/statuses/{statusID}", handleArchive(db))
+	mux.HandleFunc("GET /projects/{projectID}/statuses/trash", handleListTrash(db))
+	mux.HandleFunc("POST /projects/{projectID}/statuses/{statusID}/restore", handleRestore(db))
+	mux.HandleFunc("GET /projects/{projectID}/transitions", handleListTransitions(db))
+	mux.HandleFunc("POST /projects/{projectID}/transitions", handleCreateTransition(db))
+	mux.HandleFunc("PUT /projects/{projectID}/transitions/{transitionID}", handleUpdateTransition(db))
+	mux.HandleFunc("DELETE /projects/{projectID}/transitions/{transitionID}", handleDeleteTransition(db))
 }
 
 func fail(w http.ResponseWriter, err error) {
@@ -31,10 +39,14 @@ func fail(w http.ResponseWriter, err error) {
 	case errors.Is(err, authz.ErrWorkspaceNotFound),
 		errors.Is(err, authz.ErrProjectNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrNotFound):
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrTransitionNotFound):
 		respond.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, ErrDuplicate):
+	case errors.Is(err, ErrDuplicate), errors.Is(err, ErrTransitionExists), errors.Is(err, ErrLastInCategory),
+		errors.Is(err, ErrPositionTaken):
 		respond.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrInvalidOrder),
+		errors.Is(err, ErrTargetRequired), errors.Is(err, ErrInvalidTarget):
+		respond.Error(w, http.StatusUnprocessableEntity, err.Error())
 	default:
 		slog.Error("statuses handler error", "error", err)
 		respond.Error(w, http.StatusInternalServerError, "internal server error")
@@ -134,6 +146,39 @@ func handleUpdate(db *sqlx.DB) http.HandlerFunc {
 	}
 }
 
+func handleReorder(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			StatusIDs []string `json:"status_ids"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := ReorderParams{ProjectID: projID, StatusIDs: body.StatusIDs}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		list, err := Reorder(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
 func handleArchive(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projID := r.PathValue("projectID")
@@ -146,7 +191,188 @@ func handleArchive(db *sqlx.DB) http.HandlerFunc {
 			fail(w, err)
 			return
 		}
-		if err := Archive(r.Context(), db, projID, r.PathValue("statusID")); err != nil {
+		params := ArchiveParams{
+			ProjectID:      projID,
+			StatusID:       r.PathValue("statusID"),
+			TargetStatusID: r.URL.Query().Get("target_status_id"),
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := Archive(r.Context(), db, params); err != nil {
+			fail(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleListTrash(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		if _, err := authz.RequireProjectMembership(r.Context(), db, projID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListTrash(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleRestore(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := respond.Decode(r, &body); err != nil && !errors.Is(err, io.EOF) {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := RestoreParams{
+			StatusID:  r.PathValue("statusID"),
+			ProjectID: projID,
+			Name:      body.Name,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		s, err := Restore(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, s)
+	}
+}
+
+func handleListTransitions(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		if _, err := authz.RequireProjectMembership(r.Context(), db, projID); err != nil {
+			fail(w, err)
+			return
+		}
+		list, err := ListTransitions(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, list)
+	}
+}
+
+func handleCreateTransition(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			FromStatusID string   `json:"from_status_id"`
+			ToStatusID   string   `json:"to_status_id"`
+			IssueTypeID  string   `json:"issue_type_id"`
+			AdminOnly    bool     `json:"admin_only"`
+			Validators   []string `json:"validators"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := CreateTransitionParams{
+			ProjectID:    projID,
+			FromStatusID: body.FromStatusID,
+			ToStatusID:   body.ToStatusID,
+			IssueTypeID:  body.IssueTypeID,
+			AdminOnly:    body.AdminOnly,
+			Validators:   body.Validators,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		t, err := CreateTransition(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusCreated, t)
+	}
+}
+
+func handleUpdateTransition(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		var body struct {
+			AdminOnly  bool     `json:"admin_only"`
+			Validators []string `json:"validators"`
+		}
+		if err := respond.Decode(r, &body); err != nil {
+			respond.Error(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		params := UpdateTransitionParams{
+			ProjectID:    projID,
+			TransitionID: r.PathValue("transitionID"),
+			AdminOnly:    body.AdminOnly,
+			Validators:   body.Validators,
+		}
+		if err := params.Validate(); err != nil {
+			respond.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		t, err := UpdateTransition(r.Context(), db, params)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		respond.JSON(w, http.StatusOK, t)
+	}
+}
+
+func handleDeleteTransition(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projID := r.PathValue("projectID")
+		wsID, err := authz.RequireProjectMembership(r.Context(), db, projID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if err := authz.RequireWorkspaceAdmin(r.Context(), db, wsID); err != nil {
+			fail(w, err)
+			return
+		}
+		if err := DeleteTransition(r.Context(), db, projID, r.PathValue("transitionID")); err != nil {
 			fail(w, err)
 			return
 		}
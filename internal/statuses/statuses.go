@@ -14,8 +14,13 @@ import (
 )
 
 var (
-	ErrNotFound  = errors.New("status not found")
-	ErrDuplicate = errors.New("status name already exists in project")
+	ErrNotFound       = errors.New("status not found")
+	ErrDuplicate      = errors.New("status name already exists in project")
+	ErrPositionTaken  = errors.New("status position was taken by a concurrent change; retry")
+	ErrInvalidOrder   = errors.New("status_ids must list every active status of the project exactly once")
+	ErrTargetRequired = errors.New("target_status_id is required while the status has active issues")
+	ErrInvalidTarget  = errors.New("target status must be another active status of the project")
+	ErrLastInCategory = errors.New("cannot archive the last active status of its category")
 )
 
 var validCategories = map[string]bool{"todo": true, "doing": true, "done": true}
@@ -73,6 +78,54 @@ func (params UpdateParams) Validate() error {
 	return nil
 }
 
+// ReorderParams gives the new workflow order of all active statuses of a
+// project, first to last.
+type ReorderParams struct {
+	ProjectID string
+	StatusIDs []string
+}
+
+func (params ReorderParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if len(params.StatusIDs) == 0 {
+		return errors.New("status_ids is required")
+	}
+	seen := make(map[string]bool, len(params.StatusIDs))
+	for _, id := range params.StatusIDs {
+		if id == "" {
+			return errors.New("status_ids must not contain empty values")
+		}
+		if seen[id] {
+			return errors.New("status_ids must not contain duplicates")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ArchiveParams archives a status. TargetStatusID receives the status's
+// active issues and may be left empty when it has none.
+type ArchiveParams struct {
+	ProjectID      string
+	StatusID       string
+	TargetStatusID string
+}
+
+func (params ArchiveParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.StatusID == "" {
+		return errors.New("status_id is required")
+	}
+	if params.TargetStatusID == params.StatusID {
+		return errors.New("target_status_id must be a different status")
+	}
+	return nil
+}
+
 func Create(ctx context.Context, db *sqlx.DB, params CreateParams) (Status, error) {
 	if db == nil {
 		return Status{}, errors.New("db is required")
@@ -103,15 +156,28 @@ func Update(ctx context.Context, db *sqlx.DB, params UpdateParams) (Status, erro
 	return updateStatus(ctx, db, params)
 }
 
-func Archive(ctx context.Context, db *sqlx.DB, projectID, statusID string) error {
+// Reorder puts the statuses of a project in the given order and returns them.
+func Reorder(ctx context.Context, db *sqlx.DB, params ReorderParams) ([]Status, error) {
 	if db == nil {
-		return errors.New("db is required")
+		return nil, errors.New("db is required")
 	}
-	if projectID == "" {
-		return errors.New("project_id is required")
+	if err := params.Validate(); err != nil {
+		return nil, err
 	}
-	if statusID == "" {
-		return errors.New("status_id is required")
+	return reorderStatuses(ctx, db, params)
+}
+
+// Archive archives a status. Its active issues move to the target status,
+// after the issues already there, and the status leaves every board column.
+// It returns ErrTargetRequired when the status has active issues but no
+// target is given and ErrLastInCategory when no other active status shares
+// its category.
+func Archive(ctx context.Context, db *sqlx.DB, params ArchiveParams) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return err
 	}
-	return archiveStatus(ctx, db, projectID, statusID)
+	return archiveStatus(ctx, db, params)
 }
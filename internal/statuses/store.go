@@ -10,8 +10,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/activity"
 	"github.com/start-codex/tookly/internal/pgutil"
 )
 
@@ -19,26 +23,62 @@ const statusCols = `id, project_id, name, category, position, created_at, update
 
 func createStatus(ctx context.Context, db *sqlx.DB, params CreateParams) (Status, error) {
 	var status Status
-	err := db.QueryRowxContext(ctx,
-		`INSERT INTO statuses (project_id, name, category, position)
-		 VALUES ($1, $2, $3,
-		   COALESCE(
-		     (SELECT MAX(position) + 1 FROM statuses WHERE project_id = $1 AND archived_at IS NULL),
-		     0
-		   )
-		 )
-		 RETURNING `+statusCols,
-		params.ProjectID, params.Name, params.Category,
-	).StructScan(&status)
-	if err != nil {
-		if pgutil.IsUniqueViolation(err) {
-			return Status{}, ErrDuplicate
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit create status", func(tx *sqlx.Tx) error {
+		if _, err := lockActiveStatuses(ctx, tx, params.ProjectID); err != nil {
+			return err
+		}
+		if err := tx.QueryRowxContext(ctx,
+			`INSERT INTO statuses (project_id, name, category, position)
+			 VALUES ($1, $2, $3,
+			   COALESCE(
+			     (SELECT MAX(position) + 1 FROM statuses WHERE project_id = $1 AND archived_at IS NULL),
+			     0
+			   )
+			 )
+			 RETURNING `+statusCols,
+			params.ProjectID, params.Name, params.Category,
+		).StructScan(&status); err != nil {
+			return positionError("create status", err)
 		}
-		return Status{}, fmt.Errorf("create status: %w", err)
+		return nil
+	})
+	if err != nil {
+		return Status{}, err
 	}
 	return status, nil
 }
 
+// lockActiveStatuses locks the active statuses of a project in ID order.
+// Writes that depend on the set of active statuses, such as picking the next
+// position, take it first so that they run one after another.
+func lockActiveStatuses(ctx context.Context, tx *sqlx.Tx, projectID string) ([]Status, error) {
+	var active []Status
+	if err := tx.SelectContext(ctx, &active,
+		`SELECT `+statusCols+`
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND archived_at IS NULL
+		 ORDER BY id
+		 FOR UPDATE`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("lock statuses: %w", err)
+	}
+	return active, nil
+}
+
+// positionError maps a failed status insert or restore. With no active
+// status to lock, two writers can still pick the same first position.
+func positionError(action string, err error) error {
+	switch {
+	case pgutil.IsUniqueViolationOf(err, "uq_statuses_active_position"):
+		return ErrPositionTaken
+	case pgutil.IsUniqueViolation(err):
+		return ErrDuplicate
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
 func listStatuses(ctx context.Context, db *sqlx.DB, projectID string) ([]Status, error) {
 	statuses := []Status{}
 	if err := db.SelectContext(ctx, &statuses,
@@ -78,24 +118,354 @@ func updateStatus(ctx context.Context, db *sqlx.DB, params UpdateParams) (Status
 	return status, nil
 }
 
-func archiveStatus(ctx context.Context, db *sqlx.DB, projectID, statusID string) error {
+func reorderStatuses(ctx context.Context, db *sqlx.DB, params ReorderParams) ([]Status, error) {
+	var statuses []Status
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit status order", func(tx *sqlx.Tx) error {
+		locked, err := lockActiveStatuses(ctx, tx, params.ProjectID)
+		if err != nil {
+			return err
+		}
+		if len(locked) != len(params.StatusIDs) {
+			return ErrInvalidOrder
+		}
+		active := make(map[string]bool, len(locked))
+		for _, s := range locked {
+			active[s.ID] = true
+		}
+		for _, id := range params.StatusIDs {
+			if !active[id] {
+				return ErrInvalidOrder
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE statuses
+			 SET position = position + $1
+			 WHERE project_id = $2
+			   AND archived_at IS NULL`,
+			pgutil.ReorderOffset, params.ProjectID,
+		); err != nil {
+			return fmt.Errorf("phase 1 lift statuses: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE statuses s
+			 SET position = o.ord - 1
+			 FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+			 WHERE s.id = o.id
+			   AND s.project_id = $1`,
+			params.ProjectID, pq.Array(params.StatusIDs),
+		); err != nil {
+			return fmt.Errorf("phase 2 place statuses: %w", err)
+		}
+
+		if err := tx.SelectContext(ctx, &statuses,
+			`SELECT `+statusCols+`
+			 FROM statuses
+			 WHERE project_id = $1
+			   AND archived_at IS NULL
+			 ORDER BY position ASC`,
+			params.ProjectID,
+		); err != nil {
+			return fmt.Errorf("list statuses: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func archiveStatus(ctx context.Context, db *sqlx.DB, params ArchiveParams) error {
+	return pgutil.WithTx(ctx, db, nil, "begin tx", "commit status archive", func(tx *sqlx.Tx) error {
+		// Issue moves lock issue rows before statuses, so take the issues of
+		// both statuses first, in ID order like a move's affected issues.
+		if _, err := tx.ExecContext(ctx,
+			`SELECT id
+			 FROM issues
+			 WHERE project_id = $1
+			   AND archived_at IS NULL
+			   AND (status_id = $2 OR status_id = NULLIF($3, '')::uuid)
+			 ORDER BY id
+			 FOR UPDATE`,
+			params.ProjectID, params.StatusID, params.TargetStatusID,
+		); err != nil {
+			return fmt.Errorf("lock status issues: %w", err)
+		}
+		// Locking the statuses then makes moves into this status and
+		// archives in its category wait.
+		active, err := lockActiveStatuses(ctx, tx, params.ProjectID)
+		if err != nil {
+			return err
+		}
+		var status *Status
+		targetFound := false
+		for i := range active {
+			switch active[i].ID {
+			case params.StatusID:
+				status = &active[i]
+			case params.TargetStatusID:
+				targetFound = true
+			}
+		}
+		if status == nil {
+			return ErrNotFound
+		}
+		if params.TargetStatusID != "" && !targetFound {
+			return ErrInvalidTarget
+		}
+		if !slices.ContainsFunc(active, func(s Status) bool {
+			return s.ID != status.ID && s.Category == status.Category
+		}) {
+			return ErrLastInCategory
+		}
+
+		// Moves that committed before the statuses were locked count too.
+		var hasIssues bool
+		if err := tx.GetContext(ctx, &hasIssues,
+			`SELECT EXISTS (
+			   SELECT 1 FROM issues
+			   WHERE project_id = $1
+			     AND status_id = $2
+			     AND archived_at IS NULL
+			 )`,
+			params.ProjectID, params.StatusID,
+		); err != nil {
+			return fmt.Errorf("check status issues: %w", err)
+		}
+		if hasIssues {
+			if params.TargetStatusID == "" {
+				return ErrTargetRequired
+			}
+			if err := moveStatusIssues(ctx, tx, params); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM board_column_statuses WHERE status_id = $1`,
+			params.StatusID,
+		); err != nil {
+			return fmt.Errorf("unassign status from columns: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE statuses SET archived_at = NOW() WHERE id = $1`,
+			params.StatusID,
+		); err != nil {
+			return fmt.Errorf("archive status: %w", err)
+		}
+		return nil
+	})
+}
+
+// moveStatusIssues appends the active issues of the archived status to the
+// target status, keeping their order, and records a moved event for each.
+func moveStatusIssues(ctx context.Context, tx *sqlx.Tx, params ArchiveParams) error {
+	var moved []struct {
+		ID           string `db:"id"`
+		FromPosition int    `db:"from_position"`
+		ToPosition   int    `db:"to_position"`
+	}
+	if err := tx.SelectContext(ctx, &moved,
+		`WITH base AS (
+		   SELECT COALESCE(MAX(status_position), -1) AS position
+		   FROM issues
+		   WHERE project_id = $1
+		     AND status_id = $3
+		     AND archived_at IS NULL
+		 ), source AS (
+		   SELECT id, status_position,
+		          ROW_NUMBER() OVER (ORDER BY status_position, id) AS n
+		   FROM issues
+		   WHERE project_id = $1
+		     AND status_id = $2
+		     AND archived_at IS NULL
+		 )
+		 UPDATE issues i
+		 SET status_id       = $3,
+		     status_position = base.position + source.n
+		 FROM source, base
+		 WHERE i.id = source.id
+		 RETURNING i.id, source.status_position AS from_position, i.status_position AS to_position`,
+		params.ProjectID, params.StatusID, params.TargetStatusID,
+	); err != nil {
+		return fmt.Errorf("move status issues: %w", err)
+	}
+
+	actorID := activity.Actor(ctx, "")
+	for _, issue := range moved {
+		if err := activity.Record(ctx, tx, issue.ID, actorID, activity.Moved, map[string]any{
+			"from_status_id": params.StatusID,
+			"to_status_id":   params.TargetStatusID,
+			"from_position":  issue.FromPosition,
+			"to_position":    issue.ToPosition,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Status, error) {
+	statuses := []Status{}
+	if err := db.SelectContext(ctx, &statuses,
+		`SELECT `+statusCols+`
+		 FROM statuses
+		 WHERE project_id = $1
+		   AND archived_at IS NOT NULL
+		 ORDER BY archived_at DESC, name ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list archived statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+func restoreStatus(ctx context.Context, db *sqlx.DB, params RestoreParams) (Status, error) {
+	var status Status
+	err := pgutil.WithTx(ctx, db, nil, "begin tx", "commit restore status", func(tx *sqlx.Tx) error {
+		if _, err := lockActiveStatuses(ctx, tx, params.ProjectID); err != nil {
+			return err
+		}
+		if err := tx.QueryRowxContext(ctx,
+			`UPDATE statuses
+			 SET archived_at = NULL,
+			     name        = COALESCE(NULLIF($3, ''), name),
+			     position    = COALESCE(
+			       (SELECT MAX(position) + 1 FROM statuses WHERE project_id = $2 AND archived_at IS NULL),
+			       0
+			     )
+			 WHERE id         = $1
+			   AND project_id = $2
+			   AND archived_at IS NOT NULL
+			 RETURNING `+statusCols,
+			params.StatusID, params.ProjectID, params.Name,
+		).StructScan(&status); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return positionError("restore status", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	return status, nil
+}
+
+func purgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
 	res, err := db.ExecContext(ctx,
-		`UPDATE statuses
-		 SET archived_at = NOW()
+		`DELETE FROM statuses s
+		 WHERE s.archived_at < $1
+		   AND NOT EXISTS (SELECT 1 FROM issues i WHERE i.status_id = s.id)`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge archived statuses: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge archived statuses rows affected: %w", err)
+	}
+	return n, nil
+}
+
+const transitionCols = `id, project_id, from_status_id, to_status_id, issue_type_id, admin_only, validators, created_at`
+
+func createTransition(ctx context.Context, db *sqlx.DB, params CreateTransitionParams) (Transition, error) {
+	var transition Transition
+	err := db.QueryRowxContext(ctx,
+		`INSERT INTO status_transitions (project_id, from_status_id, to_status_id, issue_type_id, admin_only, validators)
+		 SELECT to_s.project_id, from_s.id, to_s.id, it.id, $5, $6
+		 FROM statuses to_s
+		 LEFT JOIN statuses from_s
+		   ON from_s.id = NULLIF($2, '')::uuid
+		  AND from_s.project_id = to_s.project_id
+		  AND from_s.archived_at IS NULL
+		 LEFT JOIN issue_types it
+		   ON it.id = NULLIF($4, '')::uuid
+		  AND it.project_id = to_s.project_id
+		  AND it.archived_at IS NULL
+		 WHERE to_s.id         = $3
+		   AND to_s.project_id = $1
+		   AND to_s.archived_at IS NULL
+		   AND ($2 = '' OR from_s.id IS NOT NULL)
+		   AND ($4 = '' OR it.id IS NOT NULL)
+		 RETURNING `+transitionCols,
+		params.ProjectID, params.FromStatusID, params.ToStatusID, params.IssueTypeID, params.AdminOnly,
+		pq.Array(nonNilStrings(params.Validators)),
+	).StructScan(&transition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Transition{}, ErrInvalidTransition
+		}
+		if pgutil.IsUniqueViolation(err) {
+			return Transition{}, ErrTransitionExists
+		}
+		return Transition{}, fmt.Errorf("create transition: %w", err)
+	}
+	return transition, nil
+}
+
+func listTransitions(ctx context.Context, db *sqlx.DB, projectID string) ([]Transition, error) {
+	transitions := []Transition{}
+	if err := db.SelectContext(ctx, &transitions,
+		`SELECT `+transitionCols+`
+		 FROM status_transitions
+		 WHERE project_id = $1
+		 ORDER BY created_at ASC, id ASC`,
+		projectID,
+	); err != nil {
+		return nil, fmt.Errorf("list transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+func updateTransition(ctx context.Context, db *sqlx.DB, params UpdateTransitionParams) (Transition, error) {
+	var transition Transition
+	err := db.QueryRowxContext(ctx,
+		`UPDATE status_transitions
+		 SET admin_only = $3,
+		     validators = $4
 		 WHERE id         = $1
 		   AND project_id = $2
-		   AND archived_at IS NULL`,
-		statusID, projectID,
+		 RETURNING `+transitionCols,
+		params.TransitionID, params.ProjectID, params.AdminOnly, pq.Array(nonNilStrings(params.Validators)),
+	).StructScan(&transition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Transition{}, ErrTransitionNotFound
+		}
+		return Transition{}, fmt.Errorf("update transition: %w", err)
+	}
+	return transition, nil
+}
+
+// nonNilStrings keeps an empty list from being stored as NULL.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func deleteTransition(ctx context.Context, db *sqlx.DB, projectID, transitionID string) error {
+	res, err := db.ExecContext(ctx,
+		`DELETE FROM status_transitions
+		 WHERE id         = $1
+		   AND project_id = $2`,
+		transitionID, projectID,
 	)
 	if err != nil {
-		return fmt.Errorf("archive status: %w", err)
+		return fmt.Errorf("delete transition: %w", err)
 	}
 	n, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("archive status rows affected: %w", err)
+		return fmt.Errorf("delete transition rows affected: %w", err)
 	}
 	if n == 0 {
-		return ErrNotFound
+		return ErrTransitionNotFound
 	}
 	return nil
 }
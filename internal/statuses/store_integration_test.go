@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package statuses
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/start-codex/tookly/internal/authz"
+	"github.com/start-codex/tookly/internal/testpg"
+)
+
+func TestRestoreStatus(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := testpg.SeedWorkspace(t, db)
+	projectID := testpg.SeedProject(t, db, ws, "STS")
+	todo, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "To Do", Category: "todo"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "Backlog", Category: "todo"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := db.Exec(`UPDATE statuses SET archived_at = NOW() WHERE id = $1`, todo.ID); err != nil {
+		t.Fatalf("archive status: %v", err)
+	}
+	// A new status takes the archived one's name meanwhile.
+	if _, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: "To Do", Category: "todo"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := Restore(ctx, db, RestoreParams{ProjectID: projectID, StatusID: todo.ID}); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Restore() with a taken name error = %v, want %v", err, ErrDuplicate)
+	}
+	restored, err := Restore(ctx, db, RestoreParams{ProjectID: projectID, StatusID: todo.ID, Name: "Ready"})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.ArchivedAt != nil || restored.Name != "Ready" || restored.Position != 3 {
+		t.Fatalf("restored status: got %+v, want Ready at position 3", restored)
+	}
+	if _, err := Restore(ctx, db, RestoreParams{ProjectID: projectID, StatusID: todo.ID}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore() of an active status error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestArchiveStatus(t *testing.T) {
+	db := testpg.Open(t)
+	testpg.EnsureMigrated(t, db)
+	ctx := context.Background()
+
+	ws := testpg.SeedWorkspace(t, db)
+	projectID := testpg.SeedProject(t, db, ws, "ARC")
+	reporterID := testpg.SeedUser(t, db)
+	ctx = authz.WithUserID(ctx, reporterID)
+	newStatus := func(name, category string) string {
+		t.Helper()
+		status, err := Create(ctx, db, CreateParams{ProjectID: projectID, Name: name, Category: category})
+		if err != nil {
+			t.Fatalf("create status %q: %v", name, err)
+		}
+		return status.ID
+	}
+	todo := newStatus("To Do", "todo")
+	ready := newStatus("Ready", "todo")
+	doing := newStatus("Doing", "doing")
+	var typeID string
+	if err := db.Get(&typeID, `INSERT INTO issue_types (project_id, name, level) VALUES ($1, 'Task', 1) RETURNING id`, projectID); err != nil {
+		t.Fatalf("seed issue type: %v", err)
+	}
+	number := 0
+	newIssue := func(statusID string, position int) string {
+		t.Helper()
+		number++
+		var id string
+		if err := db.Get(&id,
+			`INSERT INTO issues (project_id, number, issue_type_id, status_id, title, reporter_id, status_position)
+			 VALUES ($1, $2, $3, $4, 'Issue', $5, $6)
+			 RETURNING id`,
+			projectID, number, typeID, statusID, reporterID, position,
+		); err != nil {
+			t.Fatalf("seed issue: %v", err)
+		}
+		return id
+	}
+	first := newIssue(todo, 0)
+	second := newIssue(todo, 1)
+	waiting := newIssue(ready, 0)
+
+	var columnID string
+	if err := db.Get(&columnID,
+		`WITH b AS (
+		   INSERT INTO boards (project_id, name, type) VALUES ($1, 'Board', 'kanban') RETURNING id
+		 )
+		 INSERT INTO board_columns (board_id, name, position) SELECT id, 'Open', 0 FROM b RETURNING id`,
+		projectID,
+	); err != nil {
+		t.Fatalf("seed board column: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO board_column_statuses (board_column_id, status_id) VALUES ($1, $2), ($1, $3)`, columnID, todo, ready); err != nil {
+		t.Fatalf("map statuses: %v", err)
+	}
+
+	if err := Archive(ctx, db, ArchiveParams{ProjectID: projectID, StatusID: todo}); !errors.Is(err, ErrTargetRequired) {
+		t.Fatalf("Archive() without target error = %v, want %v", err, ErrTargetRequired)
+	}
+	if err := Archive(ctx, db, ArchiveParams{ProjectID: projectID, StatusID: doing}); !errors.Is(err, ErrLastInCategory) {
+		t.Fatalf("Archive() of the last doing status error = %v, want %v", err, ErrLastInCategory)
+	}
+	if err := Archive(ctx, db, ArchiveParams{ProjectID: projectID, StatusID: todo, TargetStatusID: ready}); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	var order []string
+	if err := db.Select(&order,
+		`SELECT id FROM issues WHERE status_id = $1 AND archived_at IS NULL ORDER BY status_position`, ready,
+	); err != nil {
+		t.Fatalf("list target issues: %v", err)
+	}
+	if want := []string{waiting, first, second}; !slices.Equal(order, want) {
+		t.Fatalf("target order: got %v, want %v", order, want)
+	}
+	assertMoved(t, db, first, reporterID)
+
+	var mapped []string
+	if err := db.Select(&mapped, `SELECT status_id FROM board_column_statuses WHERE board_column_id = $1`, columnID); err != nil {
+		t.Fatalf("list column statuses: %v", err)
+	}
+	if !slices.Equal(mapped, []string{ready}) {
+		t.Fatalf("column statuses: got %v, want only the target", mapped)
+	}
+	list, err := List(ctx, db, projectID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if slices.ContainsFunc(list, func(s Status) bool { return s.ID == todo }) {
+		t.Fatal("archived status is still listed")
+	}
+}
+
+func assertMoved(t *testing.T, db *sqlx.DB, issueID, actorID string) {
+	t.Helper()
+	var count int
+	if err := db.Get(&count,
+		`SELECT COUNT(*) FROM issue_events WHERE issue_id = $1 AND actor_id = $2 AND event_type = 'moved'`,
+		issueID, actorID,
+	); err != nil {
+		t.Fatalf("count moved events: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("moved events of %s: got %d, want 1", issueID, count)
+	}
+}
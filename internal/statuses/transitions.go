@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package statuses
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Validators a transition can carry. The issue must meet each one before it
+// may follow the transition.
+const (
+	// ValidatorAssigneeRequired requires the issue to have an assignee.
+	ValidatorAssigneeRequired = "assignee_required"
+	// ValidatorCommentRequired requires a comment added since the issue
+	// entered its current status, such as a resolution note.
+	ValidatorCommentRequired = "comment_required"
+)
+
+var (
+	ErrTransitionNotFound = errors.New("transition not found")
+	ErrTransitionExists   = errors.New("transition already exists")
+	ErrInvalidTransition  = errors.New("transition statuses and issue type must be active and belong to the project")
+	ErrInvalidValidator   = errors.New("validators must be 'assignee_required' or 'comment_required'")
+)
+
+var validValidators = map[string]bool{ValidatorAssigneeRequired: true, ValidatorCommentRequired: true}
+
+func validateValidators(validators []string) error {
+	seen := make(map[string]bool, len(validators))
+	for _, v := range validators {
+		if !validValidators[v] {
+			return ErrInvalidValidator
+		}
+		if seen[v] {
+			return errors.New("validators must not contain duplicates")
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+// Transition is a workflow rule of a project. While no rule applies to an
+// issue type, its issues move freely; once one does, they may only move
+// along the rules. A nil FromStatusID allows the move from any status and a
+// nil IssueTypeID applies the rule to every type. AdminOnly rules are only
+// followed by workspace and project admins. Validators lists the conditions
+// the issue must meet to follow the rule.
+type Transition struct {
+	ID           string         `db:"id"             json:"id"`
+	ProjectID    string         `db:"project_id"     json:"project_id"`
+	FromStatusID *string        `db:"from_status_id" json:"from_status_id"`
+	ToStatusID   string         `db:"to_status_id"   json:"to_status_id"`
+	IssueTypeID  *string        `db:"issue_type_id"  json:"issue_type_id"`
+	AdminOnly    bool           `db:"admin_only"     json:"admin_only"`
+	Validators   pq.StringArray `db:"validators"     json:"validators"`
+	CreatedAt    time.Time      `db:"created_at"     json:"created_at"`
+}
+
+// CreateTransitionParams adds a rule. Leave FromStatusID or IssueTypeID
+// empty to match any status or type.
+type CreateTransitionParams struct {
+	ProjectID    string
+	FromStatusID string
+	ToStatusID   string
+	IssueTypeID  string
+	AdminOnly    bool
+	Validators   []string
+}
+
+func (params CreateTransitionParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.ToStatusID == "" {
+		return errors.New("to_status_id is required")
+	}
+	if params.FromStatusID == params.ToStatusID {
+		return errors.New("from_status_id and to_status_id must differ")
+	}
+	return validateValidators(params.Validators)
+}
+
+// UpdateTransitionParams replaces who may follow a rule and its validators.
+// The statuses and issue type of a rule are fixed; delete and recreate it to
+// change them.
+type UpdateTransitionParams struct {
+	ProjectID    string
+	TransitionID string
+	AdminOnly    bool
+	Validators   []string
+}
+
+func (params UpdateTransitionParams) Validate() error {
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	if params.TransitionID == "" {
+		return errors.New("transition_id is required")
+	}
+	return validateValidators(params.Validators)
+}
+
+// CreateTransition adds a workflow rule to a project. It returns
+// ErrInvalidTransition when a status or the issue type is archived or belongs
+// to another project, and ErrTransitionExists for a duplicate rule.
+func CreateTransition(ctx context.Context, db *sqlx.DB, params CreateTransitionParams) (Transition, error) {
+	if db == nil {
+		return Transition{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Transition{}, err
+	}
+	return createTransition(ctx, db, params)
+}
+
+// ListTransitions returns the workflow rules of a project, oldest first.
+func ListTransitions(ctx context.Context, db *sqlx.DB, projectID string) ([]Transition, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listTransitions(ctx, db, projectID)
+}
+
+// UpdateTransition changes the admin restriction and validators of a rule.
+func UpdateTransition(ctx context.Context, db *sqlx.DB, params UpdateTransitionParams) (Transition, error) {
+	if db == nil {
+		return Transition{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Transition{}, err
+	}
+	return updateTransition(ctx, db, params)
+}
+
+// DeleteTransition removes a workflow rule. Removing the last rule of an
+// issue type lets its issues move freely again.
+func DeleteTransition(ctx context.Context, db *sqlx.DB, projectID, transitionID string) error {
+	if db == nil {
+		return errors.New("db is required")
+	}
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if transitionID == "" {
+		return errors.New("transition_id is required")
+	}
+	return deleteTransition(ctx, db, projectID, transitionID)
+}
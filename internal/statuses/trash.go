@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Start Codex SAS. All rights reserved.
+// SPDX-License-Identifier: BUSL-1.1
+// Use of this software is governed by the Business Source License 1.1
+// included in the LICENSE file at the root of this repository.
+
+package statuses
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RestoreParams restores an archived status. Name renames it on the way
+// back, for when an active status has taken its name meanwhile.
+type RestoreParams struct {
+	StatusID  string
+	ProjectID string
+	Name      string
+}
+
+func (params RestoreParams) Validate() error {
+	if params.StatusID == "" {
+		return errors.New("status_id is required")
+	}
+	if params.ProjectID == "" {
+		return errors.New("project_id is required")
+	}
+	return nil
+}
+
+// ListTrash returns the archived statuses of a project, most recently archived first.
+func ListTrash(ctx context.Context, db *sqlx.DB, projectID string) ([]Status, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if projectID == "" {
+		return nil, errors.New("project_id is required")
+	}
+	return listTrash(ctx, db, projectID)
+}
+
+// Restore brings an archived status back at the end of the project's
+// workflow. It returns ErrDuplicate when an active status already uses the name.
+func Restore(ctx context.Context, db *sqlx.DB, params RestoreParams) (Status, error) {
+	if db == nil {
+		return Status{}, errors.New("db is required")
+	}
+	if err := params.Validate(); err != nil {
+		return Status{}, err
+	}
+	return restoreStatus(ctx, db, params)
+}
+
+// PurgeArchived permanently deletes statuses archived before the given time
+// and returns how many were deleted. Statuses still used by an issue, active
+// or archived, are kept.
+func PurgeArchived(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	if db == nil {
+		return 0, errors.New("db is required")
+	}
+	return purgeArchived(ctx, db, before)
+}
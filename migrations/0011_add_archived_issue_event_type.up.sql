@@ -0,0 +1,3 @@
+ALTER TABLE issue_events DROP CONSTRAINT IF EXISTS issue_events_event_type_check;
+ALTER TABLE issue_events ADD CONSTRAINT issue_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'moved', 'archived', 'commented'));
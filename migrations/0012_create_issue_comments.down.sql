@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_set_updated_at_issue_comments ON issue_comments;
+DROP TABLE IF EXISTS issue_comment_mentions;
+DROP TABLE IF EXISTS issue_comment_revisions;
+DROP TABLE IF EXISTS issue_comments;
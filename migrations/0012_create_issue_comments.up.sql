@@ -0,0 +1,35 @@
+CREATE TABLE issue_comments (
+    id                UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    issue_id          UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    parent_comment_id UUID        REFERENCES issue_comments(id) ON DELETE CASCADE,
+    author_id         UUID        NOT NULL REFERENCES app_users(id),
+    body              TEXT        NOT NULL,
+    edited_at         TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at        TIMESTAMPTZ
+);
+
+CREATE TABLE issue_comment_revisions (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID        NOT NULL REFERENCES issue_comments(id) ON DELETE CASCADE,
+    body       TEXT        NOT NULL,
+    edited_by  UUID        NOT NULL REFERENCES app_users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE issue_comment_mentions (
+    comment_id UUID        NOT NULL REFERENCES issue_comments(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES app_users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX idx_issue_comments_issue_created_at ON issue_comments (issue_id, created_at);
+CREATE INDEX idx_issue_comments_parent ON issue_comments (parent_comment_id);
+CREATE INDEX idx_issue_comment_revisions_comment ON issue_comment_revisions (comment_id, created_at);
+CREATE INDEX idx_issue_comment_mentions_user ON issue_comment_mentions (user_id);
+
+CREATE TRIGGER trg_set_updated_at_issue_comments
+BEFORE UPDATE ON issue_comments
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
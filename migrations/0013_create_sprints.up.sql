@@ -0,0 +1,25 @@
+CREATE TABLE sprints (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id   UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name         TEXT        NOT NULL,
+    goal         TEXT        NOT NULL DEFAULT '',
+    start_date   DATE,
+    end_date     DATE,
+    state        TEXT        NOT NULL DEFAULT 'planned' CHECK (state IN ('planned', 'active', 'closed')),
+    started_at   TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (start_date IS NULL OR end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_sprints_project_state ON sprints (project_id, state);
+CREATE UNIQUE INDEX uq_sprints_one_active_per_project ON sprints (project_id) WHERE state = 'active';
+
+CREATE TRIGGER trg_set_updated_at_sprints
+BEFORE UPDATE ON sprints
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE issues ADD COLUMN sprint_id UUID REFERENCES sprints(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_issues_sprint ON issues (sprint_id) WHERE sprint_id IS NOT NULL;
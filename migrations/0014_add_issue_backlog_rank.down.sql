@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_set_issue_backlog_rank ON issues;
+DROP FUNCTION IF EXISTS set_issue_backlog_rank();
+DROP INDEX IF EXISTS uq_issues_active_backlog_rank;
+ALTER TABLE issues DROP COLUMN IF EXISTS backlog_rank;
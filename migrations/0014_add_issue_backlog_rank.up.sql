@@ -0,0 +1,35 @@
+ALTER TABLE issues ADD COLUMN backlog_rank INT;
+
+UPDATE issues i
+SET backlog_rank = ranked.rank
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY number) - 1 AS rank
+    FROM issues
+) ranked
+WHERE ranked.id = i.id;
+
+ALTER TABLE issues ALTER COLUMN backlog_rank SET NOT NULL;
+ALTER TABLE issues ADD CONSTRAINT issues_backlog_rank_check CHECK (backlog_rank >= 0);
+
+CREATE UNIQUE INDEX uq_issues_active_backlog_rank
+  ON issues (project_id, backlog_rank)
+  WHERE archived_at IS NULL;
+
+-- New issues join the bottom of the backlog unless a rank is given.
+CREATE OR REPLACE FUNCTION set_issue_backlog_rank()
+RETURNS trigger AS $$
+BEGIN
+  IF NEW.backlog_rank IS NULL THEN
+    SELECT COALESCE(MAX(backlog_rank), -1) + 1 INTO NEW.backlog_rank
+    FROM issues
+    WHERE project_id = NEW.project_id
+      AND archived_at IS NULL;
+  END IF;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_set_issue_backlog_rank
+BEFORE INSERT ON issues
+FOR EACH ROW EXECUTE FUNCTION set_issue_backlog_rank();
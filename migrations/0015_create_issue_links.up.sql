@@ -0,0 +1,21 @@
+CREATE TABLE issue_links (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_issue_id UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    target_issue_id UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    link_type       TEXT        NOT NULL CHECK (link_type IN ('blocks', 'duplicates', 'relates_to', 'clones')),
+    created_by      UUID        REFERENCES app_users(id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (source_issue_id <> target_issue_id)
+);
+
+-- A pair of issues has at most one link of each type, whichever way it points:
+-- "A blocks B" together with "B blocks A" is a contradiction, and "relates to"
+-- has no direction at all.
+CREATE UNIQUE INDEX uq_issue_links_pair_type ON issue_links (
+    LEAST(source_issue_id, target_issue_id),
+    GREATEST(source_issue_id, target_issue_id),
+    link_type
+);
+
+CREATE INDEX idx_issue_links_source ON issue_links (source_issue_id);
+CREATE INDEX idx_issue_links_target ON issue_links (target_issue_id);
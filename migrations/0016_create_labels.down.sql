@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS issue_labels;
+DROP TRIGGER IF EXISTS trg_set_updated_at_labels ON labels;
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,24 @@
+CREATE TABLE labels (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    color      TEXT        NOT NULL CHECK (color ~ '^#[0-9a-f]{6}$'),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Label names are matched case-insensitively by list and board filters.
+CREATE UNIQUE INDEX uq_labels_project_name ON labels (project_id, lower(name));
+
+CREATE TABLE issue_labels (
+    issue_id   UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    label_id   UUID        NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issue_id, label_id)
+);
+
+CREATE INDEX idx_issue_labels_label ON issue_labels (label_id);
+
+CREATE TRIGGER trg_set_updated_at_labels
+BEFORE UPDATE ON labels
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_set_updated_at_issue_field_values ON issue_field_values;
+DROP TABLE IF EXISTS issue_field_values;
+DROP TABLE IF EXISTS custom_field_issue_types;
+DROP TRIGGER IF EXISTS trg_set_updated_at_custom_fields ON custom_fields;
+DROP TABLE IF EXISTS custom_fields;
@@ -0,0 +1,43 @@
+CREATE TABLE custom_fields (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id  UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name        TEXT        NOT NULL,
+    field_type  TEXT        NOT NULL CHECK (field_type IN ('text', 'number', 'date', 'single_select', 'multi_select', 'user')),
+    options     TEXT[]      NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    archived_at TIMESTAMPTZ,
+    CHECK ((field_type IN ('single_select', 'multi_select')) = (cardinality(options) > 0))
+);
+
+CREATE UNIQUE INDEX uq_custom_fields_active_project_name
+    ON custom_fields (project_id, lower(name))
+    WHERE archived_at IS NULL;
+
+-- A field without rows here applies to every issue type of its project.
+CREATE TABLE custom_field_issue_types (
+    field_id      UUID NOT NULL REFERENCES custom_fields(id) ON DELETE CASCADE,
+    issue_type_id UUID NOT NULL REFERENCES issue_types(id) ON DELETE CASCADE,
+    PRIMARY KEY (field_id, issue_type_id)
+);
+
+-- Values are stored as JSON: a string for text, date ("YYYY-MM-DD"),
+-- single select and user (ID) fields, a number for number fields and an
+-- array of strings for multi select fields.
+CREATE TABLE issue_field_values (
+    issue_id   UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    field_id   UUID        NOT NULL REFERENCES custom_fields(id) ON DELETE CASCADE,
+    value      JSONB       NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issue_id, field_id)
+);
+
+CREATE INDEX idx_issue_field_values_field ON issue_field_values (field_id);
+
+CREATE TRIGGER trg_set_updated_at_custom_fields
+BEFORE UPDATE ON custom_fields
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER trg_set_updated_at_issue_field_values
+BEFORE UPDATE ON issue_field_values
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_issue_comments_search_vector;
+DROP INDEX IF EXISTS idx_issues_search_vector;
+ALTER TABLE issue_comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE issues DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,13 @@
+-- The 'simple' configuration does no stemming or stop words, so search works
+-- the same whatever language a workspace writes in.
+ALTER TABLE issues ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, title), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, description), 'B')
+  ) STORED;
+
+ALTER TABLE issue_comments ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, body)) STORED;
+
+CREATE INDEX idx_issues_search_vector ON issues USING GIN (search_vector);
+CREATE INDEX idx_issue_comments_search_vector ON issue_comments USING GIN (search_vector);
@@ -0,0 +1,13 @@
+DELETE FROM issue_events WHERE event_type = 'restored';
+ALTER TABLE issue_events DROP CONSTRAINT IF EXISTS issue_events_event_type_check;
+ALTER TABLE issue_events ADD CONSTRAINT issue_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'moved', 'archived', 'commented'));
+
+DROP INDEX IF EXISTS uq_boards_active_name;
+DROP INDEX IF EXISTS uq_statuses_active_position;
+DROP INDEX IF EXISTS uq_statuses_active_name;
+
+-- Fails if archived rows share a name or position with active ones.
+ALTER TABLE boards ADD CONSTRAINT boards_project_id_name_key UNIQUE (project_id, name);
+ALTER TABLE statuses ADD CONSTRAINT statuses_project_id_position_key UNIQUE (project_id, position);
+ALTER TABLE statuses ADD CONSTRAINT statuses_project_id_name_key UNIQUE (project_id, name);
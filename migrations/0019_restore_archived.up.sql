@@ -0,0 +1,21 @@
+-- Board and status names, and status positions, only need to be unique among
+-- active rows. Archived rows stay in the trash and can be restored later.
+ALTER TABLE statuses DROP CONSTRAINT IF EXISTS statuses_project_id_name_key;
+ALTER TABLE statuses DROP CONSTRAINT IF EXISTS statuses_project_id_position_key;
+ALTER TABLE boards DROP CONSTRAINT IF EXISTS boards_project_id_name_key;
+
+CREATE UNIQUE INDEX uq_statuses_active_name
+  ON statuses (project_id, name)
+  WHERE archived_at IS NULL;
+
+CREATE UNIQUE INDEX uq_statuses_active_position
+  ON statuses (project_id, position)
+  WHERE archived_at IS NULL;
+
+CREATE UNIQUE INDEX uq_boards_active_name
+  ON boards (project_id, name)
+  WHERE archived_at IS NULL;
+
+ALTER TABLE issue_events DROP CONSTRAINT IF EXISTS issue_events_event_type_check;
+ALTER TABLE issue_events ADD CONSTRAINT issue_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'moved', 'archived', 'restored', 'commented'));
@@ -0,0 +1,15 @@
+-- Keys an issue had before it was transferred to another project. Numbers are
+-- never reused within a project, so an old key cannot clash with a live one.
+CREATE TABLE issue_key_redirects (
+    project_id UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    number     INT         NOT NULL,
+    issue_id   UUID        NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, number)
+);
+
+CREATE INDEX idx_issue_key_redirects_issue ON issue_key_redirects (issue_id);
+
+ALTER TABLE issue_events DROP CONSTRAINT IF EXISTS issue_events_event_type_check;
+ALTER TABLE issue_events ADD CONSTRAINT issue_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'moved', 'archived', 'restored', 'transferred', 'commented'));
@@ -0,0 +1,20 @@
+-- Workflow rules. Once any rule applies to an issue's type, the issue may only
+-- move between statuses along a rule. A NULL from_status_id allows the move
+-- from any status and a NULL issue_type_id applies the rule to every type.
+CREATE TABLE status_transitions (
+    id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id     UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    from_status_id UUID        REFERENCES statuses(id) ON DELETE CASCADE,
+    to_status_id   UUID        NOT NULL REFERENCES statuses(id) ON DELETE CASCADE,
+    issue_type_id  UUID        REFERENCES issue_types(id) ON DELETE CASCADE,
+    admin_only     BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (from_status_id IS DISTINCT FROM to_status_id)
+);
+
+CREATE UNIQUE INDEX uq_status_transitions_rule ON status_transitions (
+    project_id,
+    COALESCE(from_status_id, '00000000-0000-0000-0000-000000000000'),
+    to_status_id,
+    COALESCE(issue_type_id, '00000000-0000-0000-0000-000000000000')
+);
@@ -0,0 +1 @@
+ALTER TABLE status_transitions DROP COLUMN IF EXISTS validators;
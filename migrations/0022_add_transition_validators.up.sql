@@ -0,0 +1,4 @@
+-- Conditions an issue must meet before it may follow a transition.
+ALTER TABLE status_transitions
+    ADD COLUMN validators TEXT[] NOT NULL DEFAULT '{}'
+    CHECK (validators <@ ARRAY['assignee_required', 'comment_required']::TEXT[]);
@@ -0,0 +1,5 @@
+ALTER TABLE board_columns
+    DROP CONSTRAINT IF EXISTS board_columns_wip_range_check,
+    DROP COLUMN IF EXISTS wip_mode,
+    DROP COLUMN IF EXISTS wip_max,
+    DROP COLUMN IF EXISTS wip_min;
@@ -0,0 +1,8 @@
+-- Work-in-progress limits, counted over the active issues of the statuses
+-- mapped to a column. 'warn' only reports a broken limit on the board view;
+-- 'block' also rejects issue moves that would break it.
+ALTER TABLE board_columns
+    ADD COLUMN wip_min  INT  CHECK (wip_min >= 0),
+    ADD COLUMN wip_max  INT  CHECK (wip_max > 0),
+    ADD COLUMN wip_mode TEXT NOT NULL DEFAULT 'warn' CHECK (wip_mode IN ('warn', 'block')),
+    ADD CONSTRAINT board_columns_wip_range_check CHECK (wip_min <= wip_max);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS board_lanes;
+
+ALTER TABLE boards
+    DROP COLUMN IF EXISTS swimlane_field_id,
+    DROP COLUMN IF EXISTS swimlane_by;
@@ -0,0 +1,17 @@
+-- Swimlane grouping of a board view. swimlane_field_id is only read when
+-- swimlane_by is 'custom_field'; a deleted field turns the lanes off.
+ALTER TABLE boards
+    ADD COLUMN swimlane_by       TEXT NOT NULL DEFAULT 'none'
+        CHECK (swimlane_by IN ('none', 'assignee', 'parent', 'priority', 'issue_type', 'custom_field')),
+    ADD COLUMN swimlane_field_id UUID REFERENCES custom_fields(id) ON DELETE SET NULL;
+
+-- Saved order and collapsed state of a board's lanes, keyed by the grouping
+-- value (user, parent issue, priority, issue type or field value). Cleared
+-- when the grouping changes.
+CREATE TABLE board_lanes (
+    board_id  UUID    NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    lane_key  TEXT    NOT NULL,
+    position  INT     NOT NULL CHECK (position >= 0),
+    collapsed BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (board_id, lane_key)
+);
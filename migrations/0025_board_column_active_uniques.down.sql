@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS uq_board_columns_active_position;
+DROP INDEX IF EXISTS uq_board_columns_active_name;
+
+-- Fails if archived columns share a name or position with active ones.
+ALTER TABLE board_columns ADD CONSTRAINT board_columns_board_id_position_key UNIQUE (board_id, position);
+ALTER TABLE board_columns ADD CONSTRAINT board_columns_board_id_name_key UNIQUE (board_id, name);
@@ -0,0 +1,12 @@
+-- Column names and positions only need to be unique among active columns,
+-- so reordering and renaming ignore archived ones.
+ALTER TABLE board_columns DROP CONSTRAINT IF EXISTS board_columns_board_id_name_key;
+ALTER TABLE board_columns DROP CONSTRAINT IF EXISTS board_columns_board_id_position_key;
+
+CREATE UNIQUE INDEX uq_board_columns_active_name
+  ON board_columns (board_id, name)
+  WHERE archived_at IS NULL;
+
+CREATE UNIQUE INDEX uq_board_columns_active_position
+  ON board_columns (board_id, position)
+  WHERE archived_at IS NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE issue_types
+    DROP COLUMN IF EXISTS description_template,
+    DROP COLUMN IF EXISTS default_priority,
+    DROP COLUMN IF EXISTS default_status_id,
+    ALTER COLUMN icon DROP NOT NULL,
+    ALTER COLUMN icon DROP DEFAULT;
@@ -0,0 +1,11 @@
+-- Defaults applied to new issues of a type when the client leaves the
+-- status, priority or description out. A default status only applies while
+-- it is active.
+UPDATE issue_types SET icon = '' WHERE icon IS NULL;
+
+ALTER TABLE issue_types
+    ALTER COLUMN icon SET DEFAULT '',
+    ALTER COLUMN icon SET NOT NULL,
+    ADD COLUMN default_status_id    UUID REFERENCES statuses(id) ON DELETE SET NULL,
+    ADD COLUMN default_priority     TEXT CHECK (default_priority IN ('low', 'medium', 'high', 'critical')),
+    ADD COLUMN description_template TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_set_updated_at_project_templates ON project_templates;
+DROP TABLE IF EXISTS project_templates;
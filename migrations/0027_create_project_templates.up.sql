@@ -0,0 +1,19 @@
+-- Project templates defined by workspace admins. definition holds the
+-- statuses, issue types, boards, labels and custom fields a new project
+-- starts with; entries refer to each other by name.
+CREATE TABLE project_templates (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID        NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name         TEXT        NOT NULL,
+    description  TEXT        NOT NULL DEFAULT '',
+    definition   JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_project_templates_workspace_name
+    ON project_templates (workspace_id, lower(name));
+
+CREATE TRIGGER trg_set_updated_at_project_templates
+BEFORE UPDATE ON project_templates
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
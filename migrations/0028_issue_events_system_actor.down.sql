@@ -0,0 +1,2 @@
+DELETE FROM issue_events WHERE actor_id IS NULL;
+ALTER TABLE issue_events ALTER COLUMN actor_id SET NOT NULL;
@@ -0,0 +1,3 @@
+-- Events written without a user in context (background jobs, imports) are
+-- recorded with a NULL actor and shown as made by the system.
+ALTER TABLE issue_events ALTER COLUMN actor_id DROP NOT NULL;
@@ -0,0 +1 @@
+-- The original icons are not kept, so there is nothing to restore.
@@ -0,0 +1,12 @@
+-- Issue type icons come from a fixed set (issuetypes.Icons) since 0026.
+-- Icons written before then keep their set name, case aside, or are cleared
+-- so that those types can be updated again.
+UPDATE issue_types
+SET icon = CASE
+    WHEN lower(icon) IN ('task', 'subtask', 'bug', 'story', 'epic', 'feature',
+                         'improvement', 'spike', 'incident', 'question')
+    THEN lower(icon)
+    ELSE ''
+END
+WHERE icon NOT IN ('', 'task', 'subtask', 'bug', 'story', 'epic', 'feature',
+                   'improvement', 'spike', 'incident', 'question');